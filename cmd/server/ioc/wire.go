@@ -8,7 +8,14 @@ import (
 	"loverrecipe/internal/ioc"
 	"loverrecipe/internal/repository"
 	"loverrecipe/internal/repository/dao"
+	"loverrecipe/internal/services/apitoken"
+	"loverrecipe/internal/services/audit"
+	"loverrecipe/internal/services/cooking"
 	"loverrecipe/internal/services/dishes"
+	"loverrecipe/internal/services/dishtype"
+	"loverrecipe/internal/services/nutrition"
+	"loverrecipe/internal/services/share"
+	"loverrecipe/internal/services/tags"
 	"loverrecipe/internal/services/user"
 	"loverrecipe/internal/token"
 )
@@ -19,26 +26,71 @@ var (
 		ioc.InitRedisCmd,
 		ioc.InitRedisClient,
 		ioc.InitIDGenerator,
+		ioc.InitRecipeFetcher,
 		token.RegisterJwt,
 	)
+	auditSet = wire.NewSet(
+		repository.NewAuditLogRepository,
+		ioc.InitAuditService,
+		wire.Bind(new(audit.Recorder), new(audit.Service)),
+	)
 	dishesSet = wire.NewSet(
 		dao.NewDishesDao,
-		repository.NewDishesRepository,
+		ioc.InitDishesCache,
+		ioc.InitDishesRepository,
+		repository.NewDishTypeRepository,
+		repository.NewDishFeedbackRepository,
+		repository.NewCookingLogRepository,
 		dishes.NewService,
 		controller.NewDishControllerWithRegister,
+		dishtype.NewService,
+		controller.NewDishTypeController,
+	)
+	cookingSet = wire.NewSet(
+		cooking.NewService,
+		controller.NewCookingLogController,
+	)
+	tagsSet = wire.NewSet(
+		repository.NewTagRepository,
+		tags.NewService,
+		controller.NewTagController,
+	)
+	nutritionSet = wire.NewSet(
+		repository.NewNutritionRepository,
+		nutrition.NewService,
+		controller.NewNutritionController,
+	)
+	shareSet = wire.NewSet(
+		repository.NewShareRepository,
+		share.NewService,
+		controller.NewShareController,
 	)
 	userSet = wire.NewSet(
 		dao.NewUserDao,
+		ioc.InitUserStatusCache,
 		repository.NewUserRepository,
+		ioc.InitLoginAttemptRepository,
+		ioc.InitMailer,
+		ioc.InitEmailTokenRepository,
+		ioc.InitEmailLinks,
 		user.NewService,
 		controller.NewUserController,
+		controller.NewAdminController,
+		repository.NewAPITokenRepository,
+		apitoken.NewService,
+		controller.NewAPITokenController,
 	)
 )
 
 func InitHttpServer() *ioc.App {
 	wire.Build(
 		BaseSet,
+		auditSet,
 		dishesSet,
+		cookingSet,
+		tagsSet,
+		nutritionSet,
+		shareSet,
 		userSet,
 		ioc.Crons,
 		ioc.InitHTTP,
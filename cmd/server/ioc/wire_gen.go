@@ -12,7 +12,14 @@ import (
 	"loverrecipe/internal/ioc"
 	"loverrecipe/internal/repository"
 	"loverrecipe/internal/repository/dao"
+	"loverrecipe/internal/services/apitoken"
+	"loverrecipe/internal/services/audit"
+	"loverrecipe/internal/services/cooking"
 	"loverrecipe/internal/services/dishes"
+	"loverrecipe/internal/services/dishtype"
+	"loverrecipe/internal/services/nutrition"
+	"loverrecipe/internal/services/share"
+	"loverrecipe/internal/services/tags"
 	"loverrecipe/internal/services/user"
 	"loverrecipe/internal/token"
 )
@@ -21,18 +28,48 @@ import (
 
 func InitHttpServer() *ioc.App {
 	db := ioc.InitDB()
-	dishesRepository := repository.NewDishesRepository(db)
-	service := dishes.NewService(dishesRepository)
-	dishController := controller.NewDishControllerWithRegister(service)
+	cmdable := ioc.InitRedisCmd()
+	dishesCache := ioc.InitDishesCache(cmdable)
+	dishesRepository := ioc.InitDishesRepository(db, dishesCache)
+	dishTypeRepository := repository.NewDishTypeRepository(db)
+	dishFeedbackRepository := repository.NewDishFeedbackRepository(db, dishesCache)
+	cookingLogRepository := repository.NewCookingLogRepository(db, dishesCache)
 	userDao := dao.NewUserDao(db)
-	userRepository := repository.NewUserRepository(userDao)
+	userStatusCache := ioc.InitUserStatusCache(cmdable)
+	userRepository := repository.NewUserRepository(userDao, userStatusCache, dishesCache)
+	fetcher := ioc.InitRecipeFetcher()
+	auditLogRepository := repository.NewAuditLogRepository(db)
+	auditService := ioc.InitAuditService(auditLogRepository)
+	shareRepository := repository.NewShareRepository(db)
+	service := dishes.NewService(dishesRepository, dishTypeRepository, dishFeedbackRepository, cookingLogRepository, userRepository, shareRepository, fetcher, auditService)
+	dishController := controller.NewDishControllerWithRegister(service)
+	dishtypeService := dishtype.NewService(dishTypeRepository, dishesRepository)
+	dishTypeController := controller.NewDishTypeController(dishtypeService)
+	cookingService := cooking.NewService(cookingLogRepository, dishesRepository)
+	cookingLogController := controller.NewCookingLogController(cookingService)
+	tagRepository := repository.NewTagRepository(db, dishesCache)
+	tagsService := tags.NewService(tagRepository, dishesRepository)
+	tagController := controller.NewTagController(tagsService)
+	nutritionRepository := repository.NewNutritionRepository(db)
+	nutritionService := nutrition.NewService(nutritionRepository, dishesRepository)
+	nutritionController := controller.NewNutritionController(nutritionService)
+	shareService := share.NewService(shareRepository, dishesRepository, dishTypeRepository, auditService)
+	shareController := controller.NewShareController(shareService)
+	loginAttemptRepository := ioc.InitLoginAttemptRepository(cmdable)
 	jwtTokenHandler := token.RegisterJwt()
 	sonyflake := ioc.InitIDGenerator()
-	userService := user.NewService(userRepository, jwtTokenHandler, sonyflake)
+	mailer := ioc.InitMailer()
+	emailTokenRepository := ioc.InitEmailTokenRepository(cmdable)
+	emailLinks := ioc.InitEmailLinks()
+	userService := user.NewService(userRepository, loginAttemptRepository, jwtTokenHandler, sonyflake, auditService, mailer, emailTokenRepository, emailLinks)
 	userController := controller.NewUserController(userService)
-	component := ioc.InitHTTP(dishController, userController)
-	v := ioc.InitTasks()
-	v2 := ioc.Crons()
+	adminController := controller.NewAdminController(userService, service, auditService)
+	apiTokenRepository := repository.NewAPITokenRepository(db)
+	apitokenService := apitoken.NewService(apiTokenRepository, auditService)
+	apiTokenController := controller.NewAPITokenController(apitokenService)
+	component := ioc.InitHTTP(dishController, dishTypeController, cookingLogController, tagController, nutritionController, userController, adminController, apiTokenController, shareController, cmdable, jwtTokenHandler, userService, apitokenService)
+	v := ioc.InitTasks(auditService)
+	v2 := ioc.Crons(nutritionService)
 	app := &ioc.App{
 		HttpServer: component,
 		Tasks:      v,
@@ -44,7 +81,12 @@ func InitHttpServer() *ioc.App {
 // wire.go:
 
 var (
-	BaseSet   = wire.NewSet(ioc.InitDB, ioc.InitRedisCmd, ioc.InitRedisClient, ioc.InitIDGenerator, token.RegisterJwt)
-	dishesSet = wire.NewSet(dao.NewDishesDao, repository.NewDishesRepository, dishes.NewService, controller.NewDishControllerWithRegister)
-	userSet   = wire.NewSet(dao.NewUserDao, repository.NewUserRepository, user.NewService, controller.NewUserController)
+	BaseSet      = wire.NewSet(ioc.InitDB, ioc.InitRedisCmd, ioc.InitRedisClient, ioc.InitIDGenerator, ioc.InitRecipeFetcher, token.RegisterJwt)
+	auditSet     = wire.NewSet(repository.NewAuditLogRepository, ioc.InitAuditService, wire.Bind(new(audit.Recorder), new(audit.Service)))
+	dishesSet    = wire.NewSet(dao.NewDishesDao, ioc.InitDishesCache, ioc.InitDishesRepository, repository.NewDishTypeRepository, repository.NewDishFeedbackRepository, repository.NewCookingLogRepository, dishes.NewService, controller.NewDishControllerWithRegister, dishtype.NewService, controller.NewDishTypeController)
+	cookingSet   = wire.NewSet(cooking.NewService, controller.NewCookingLogController)
+	tagsSet      = wire.NewSet(repository.NewTagRepository, tags.NewService, controller.NewTagController)
+	nutritionSet = wire.NewSet(repository.NewNutritionRepository, nutrition.NewService, controller.NewNutritionController)
+	shareSet     = wire.NewSet(repository.NewShareRepository, share.NewService, controller.NewShareController)
+	userSet      = wire.NewSet(dao.NewUserDao, ioc.InitUserStatusCache, repository.NewUserRepository, ioc.InitLoginAttemptRepository, ioc.InitMailer, ioc.InitEmailTokenRepository, ioc.InitEmailLinks, user.NewService, controller.NewUserController, controller.NewAdminController, repository.NewAPITokenRepository, apitoken.NewService, controller.NewAPITokenController)
 )
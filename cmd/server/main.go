@@ -66,6 +66,10 @@ func main() {
 	}(tp, ctx)
 
 	app := ioc.InitHttpServer()
+	// 后台任务使用独立的上下文，服务停止后单独取消
+	taskCtx, stopTasks := context.WithCancel(context.Background())
+	defer stopTasks()
+	app.StartTasks(taskCtx)
 
 	// 启动服务
 	if err := egoApp.Serve(
@@ -77,4 +81,8 @@ func main() {
 		Run(); err != nil {
 		elog.Panic("startup", elog.FieldErr(err))
 	}
+
+	// 服务停止后通知后台任务退出，等待审计日志等缓冲数据写完
+	stopTasks()
+	app.WaitTasks()
 }
@@ -0,0 +1,314 @@
+package controller
+
+import (
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/response"
+	"loverrecipe/internal/services/audit"
+	"loverrecipe/internal/services/dishes"
+	"loverrecipe/internal/services/user"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gotomicro/ego/core/elog"
+)
+
+// AdminController 管理后台接口，路由上需要管理员角色。
+// 首个管理员需在数据库中将 users.role 设为 admin，之后可通过接口授予其他用户
+type AdminController struct {
+	users  user.Service
+	dishes dishes.Service
+	audit  audit.Service
+}
+
+func NewAdminController(users user.Service, dishes dishes.Service, audits audit.Service) *AdminController {
+	return &AdminController{users: users, dishes: dishes, audit: audits}
+}
+
+// ListUsers 用户列表接口
+// @Summary 用户列表
+// @Description 管理员按创建时间倒序分页查看用户，可按用户名与显示名称模糊搜索
+// @Tags 管理后台
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 管理员令牌"
+// @Param keyword query string false "搜索关键词"
+// @Param page query int false "页码，默认1"
+// @Param size query int false "每页数量，默认20，最大100"
+// @Success 200 {object} response.Response{data=domain.UserListResponse} "获取成功"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/admin/users [get]
+func (ac *AdminController) ListUsers(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(ctx.DefaultQuery("size", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 20
+	}
+
+	users, total, err := ac.users.ListUsers(ctx.Request.Context(), domain.UserQuery{
+		Keyword: ctx.Query("keyword"),
+		Offset:  (page - 1) * size,
+		Limit:   size,
+	})
+	if err != nil {
+		response.InternalServerError(ctx, err.Error())
+		elog.Error("list users error", elog.String("error", err.Error()))
+		return
+	}
+
+	response.Success(ctx, domain.UserListResponse{List: users, Total: total, Page: page, Size: size})
+}
+
+// UpdateUserStatus 启用或禁用账号接口
+// @Summary 启用或禁用账号
+// @Description 管理员设置用户状态，1 为正常，0 为禁用。禁用时同时吊销该用户的刷新令牌，不能修改自己的状态
+// @Tags 管理后台
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 管理员令牌"
+// @Param id path int true "用户ID"
+// @Param body body domain.UpdateUserStatusRequest true "用户状态"
+// @Success 200 {object} response.Response "更新成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/admin/users/{id}/status [put]
+func (ac *AdminController) UpdateUserStatus(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "用户ID格式错误")
+		return
+	}
+	params := &domain.UpdateUserStatusRequest{}
+	if err := domain.BindJson(ctx, params); err != nil {
+		response.BadRequest(ctx, err.Error())
+		elog.Error("bind json error", elog.String("error", err.Error()))
+		return
+	}
+
+	if err := ac.users.SetUserStatus(ctx.Request.Context(), userIDFromContext(ctx), id, *params.Status); err != nil {
+		ac.errorResponse(ctx, err, "update user status error")
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "更新成功", nil)
+}
+
+// UpdateUserRole 修改用户角色接口
+// @Summary 修改用户角色
+// @Description 管理员设置用户角色 user 或 admin，新角色立即生效，不能修改自己的角色
+// @Tags 管理后台
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 管理员令牌"
+// @Param id path int true "用户ID"
+// @Param body body domain.UpdateUserRoleRequest true "用户角色"
+// @Success 200 {object} response.Response "更新成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/admin/users/{id}/role [put]
+func (ac *AdminController) UpdateUserRole(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "用户ID格式错误")
+		return
+	}
+	params := &domain.UpdateUserRoleRequest{}
+	if err := domain.BindJson(ctx, params); err != nil {
+		response.BadRequest(ctx, err.Error())
+		elog.Error("bind json error", elog.String("error", err.Error()))
+		return
+	}
+
+	if err := ac.users.SetUserRole(ctx.Request.Context(), userIDFromContext(ctx), id, params.Role); err != nil {
+		ac.errorResponse(ctx, err, "update user role error")
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "更新成功", nil)
+}
+
+// ForceLogout 强制下线接口
+// @Summary 强制下线
+// @Description 管理员吊销用户已签发的主令牌与刷新令牌，用户需要重新登录
+// @Tags 管理后台
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 管理员令牌"
+// @Param id path int true "用户ID"
+// @Success 200 {object} response.Response "操作成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/admin/users/{id}/logout [post]
+func (ac *AdminController) ForceLogout(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "用户ID格式错误")
+		return
+	}
+
+	if err := ac.users.ForceLogout(ctx.Request.Context(), userIDFromContext(ctx), id); err != nil {
+		ac.errorResponse(ctx, err, "force logout error")
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "操作成功", nil)
+}
+
+// Overview 系统概览接口
+// @Summary 系统概览
+// @Description 管理员查看全站用户数与菜品数
+// @Tags 管理后台
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 管理员令牌"
+// @Success 200 {object} response.Response{data=domain.AdminOverview} "获取成功"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/admin/overview [get]
+func (ac *AdminController) Overview(ctx *gin.Context) {
+	users, err := ac.users.CountUsers(ctx.Request.Context())
+	if err != nil {
+		ac.errorResponse(ctx, err, "count users error")
+		return
+	}
+	dishesCount, err := ac.dishes.GetDishesCount(ctx.Request.Context())
+	if err != nil {
+		ac.errorResponse(ctx, err, "count dishes error")
+		return
+	}
+
+	response.Success(ctx, domain.AdminOverview{Users: users, Dishes: dishesCount})
+}
+
+// ListLoginLockouts 登录锁定列表接口
+// @Summary 登录锁定列表
+// @Description 管理员查看当前被锁定的用户名与IP，按剩余锁定时间从长到短排序
+// @Tags 管理后台
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 管理员令牌"
+// @Param scope query string false "锁定维度 username 或 ip，为空表示全部"
+// @Success 200 {object} response.Response{data=[]domain.LoginLockout} "获取成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/admin/login-lockouts [get]
+func (ac *AdminController) ListLoginLockouts(ctx *gin.Context) {
+	scope, err := domain.ParseLockoutScope(ctx.Query("scope"))
+	if err != nil {
+		response.BadRequest(ctx, err.Error())
+		return
+	}
+
+	lockouts, err := ac.users.ListLoginLockouts(ctx.Request.Context(), scope)
+	if err != nil {
+		response.InternalServerError(ctx, err.Error())
+		elog.Error("list login lockouts error", elog.String("error", err.Error()))
+		return
+	}
+
+	response.Success(ctx, lockouts)
+}
+
+// ClearLoginLockout 解除登录锁定接口
+// @Summary 解除登录锁定
+// @Description 管理员解除一个用户名或IP的登录锁定，并清除其失败计数
+// @Tags 管理后台
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 管理员令牌"
+// @Param scope query string true "锁定维度 username 或 ip"
+// @Param subject query string true "用户名或IP"
+// @Success 200 {object} response.Response "解除成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/admin/login-lockouts [delete]
+func (ac *AdminController) ClearLoginLockout(ctx *gin.Context) {
+	scope, err := domain.ParseLockoutScope(ctx.Query("scope"))
+	if err != nil {
+		response.BadRequest(ctx, err.Error())
+		return
+	}
+
+	err = ac.users.ClearLoginLockout(ctx.Request.Context(), scope, ctx.Query("subject"))
+	if err != nil {
+		if err == domain.ErrLockoutScopeInvalid {
+			response.BadRequest(ctx, "需要指定锁定维度与用户名或IP")
+			return
+		}
+		response.InternalServerError(ctx, err.Error())
+		elog.Error("clear login lockout error", elog.String("error", err.Error()))
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "解除成功", nil)
+}
+
+// ListAuditLogs 审计日志接口
+// @Summary 审计日志
+// @Description 管理员按创建时间倒序分页查看审计日志，可按操作人、动作、对象与时间范围过滤
+// @Tags 管理后台
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 管理员令牌"
+// @Param actor_id query int false "操作人用户ID"
+// @Param action query string false "动作，如 login、role_change、dish_update"
+// @Param target_type query string false "对象类型 user 或 dishes"
+// @Param target_id query string false "对象ID"
+// @Param from query int false "开始时间，Unix 秒（含）"
+// @Param to query int false "结束时间，Unix 秒（不含）"
+// @Param page query int false "页码，默认1"
+// @Param size query int false "每页数量，默认20，最大100"
+// @Success 200 {object} response.Response{data=domain.AuditLogListResponse} "获取成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/admin/audit-logs [get]
+func (ac *AdminController) ListAuditLogs(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(ctx.DefaultQuery("size", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 20
+	}
+
+	query := domain.AuditLogQuery{
+		Action:     domain.AuditAction(ctx.Query("action")),
+		TargetType: ctx.Query("target_type"),
+		TargetID:   ctx.Query("target_id"),
+		Offset:     (page - 1) * size,
+		Limit:      size,
+	}
+	var err error
+	for name, dst := range map[string]*int64{"actor_id": &query.ActorID, "from": &query.From, "to": &query.To} {
+		if v := ctx.Query(name); v != "" {
+			if *dst, err = strconv.ParseInt(v, 10, 64); err != nil {
+				response.BadRequest(ctx, name+" 格式错误")
+				return
+			}
+		}
+	}
+
+	logs, total, err := ac.audit.List(ctx.Request.Context(), query)
+	if err != nil {
+		response.InternalServerError(ctx, err.Error())
+		elog.Error("list audit logs error", elog.String("error", err.Error()))
+		return
+	}
+
+	response.Success(ctx, domain.AuditLogListResponse{List: logs, Total: total, Page: page, Size: size})
+}
+
+// errorResponse 管理后台接口的错误响应
+func (ac *AdminController) errorResponse(ctx *gin.Context, err error, logMsg string) {
+	switch err {
+	case domain.ErrUserNotFound:
+		response.UserNotFound(ctx)
+	case domain.ErrAdminSelfModify:
+		response.BadRequest(ctx, err.Error())
+	default:
+		response.InternalServerError(ctx, err.Error())
+		elog.Error(logMsg, elog.String("error", err.Error()))
+	}
+}
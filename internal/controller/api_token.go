@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/response"
+	"loverrecipe/internal/services/apitoken"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gotomicro/ego/core/elog"
+)
+
+// APITokenController 个人访问令牌接口，只能使用登录令牌管理，访问令牌不能创建或吊销令牌
+type APITokenController struct {
+	svc apitoken.Service
+}
+
+func NewAPITokenController(svc apitoken.Service) *APITokenController {
+	return &APITokenController{svc: svc}
+}
+
+// CreateAPIToken 创建访问令牌接口
+// @Summary 创建访问令牌
+// @Description 创建供脚本与家庭设备使用的个人访问令牌，调用菜品接口时放在 Authorization: Bearer 中。
+// @Description 权限范围可选 dishes:read 与 dishes:write，过期时间不填表示永不过期。明文令牌只在创建时返回一次，每个用户最多 20 个
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param body body domain.CreateAPITokenRequest true "令牌名称、权限范围与过期时间"
+// @Success 200 {object} response.Response{data=domain.CreateAPITokenResponse} "创建成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/user/me/tokens [post]
+func (c *APITokenController) CreateAPIToken(ctx *gin.Context) {
+	params := &domain.CreateAPITokenRequest{}
+	if err := domain.BindJson(ctx, params); err != nil {
+		response.BadRequest(ctx, err.Error())
+		elog.Error("bind json error", elog.String("error", err.Error()))
+		return
+	}
+	params.UserID = userIDFromContext(ctx)
+
+	token, err := c.svc.Create(ctx.Request.Context(), *params)
+	if err != nil {
+		c.errorResponse(ctx, err, "create api token error")
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "创建成功", token)
+}
+
+// ListAPITokens 访问令牌列表接口
+// @Summary 访问令牌列表
+// @Description 获取当前用户的个人访问令牌，包含名称、开头几位、权限范围、过期时间与最近使用时间，不含明文
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Success 200 {object} response.Response{data=[]domain.APIToken} "获取成功"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/user/me/tokens [get]
+func (c *APITokenController) ListAPITokens(ctx *gin.Context) {
+	tokens, err := c.svc.List(ctx.Request.Context(), userIDFromContext(ctx))
+	if err != nil {
+		c.errorResponse(ctx, err, "list api tokens error")
+		return
+	}
+
+	response.Success(ctx, tokens)
+}
+
+// RevokeAPIToken 吊销访问令牌接口
+// @Summary 吊销访问令牌
+// @Description 吊销当前用户的一个个人访问令牌，立即生效
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path int true "令牌ID"
+// @Success 200 {object} response.Response "吊销成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 404 {object} response.Response{msg=string} "令牌不存在"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/user/me/tokens/{id} [delete]
+func (c *APITokenController) RevokeAPIToken(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "令牌ID格式错误")
+		return
+	}
+
+	if err := c.svc.Revoke(ctx.Request.Context(), userIDFromContext(ctx), id); err != nil {
+		c.errorResponse(ctx, err, "revoke api token error")
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "吊销成功", nil)
+}
+
+// errorResponse 访问令牌接口的错误响应
+func (c *APITokenController) errorResponse(ctx *gin.Context, err error, logMsg string) {
+	switch err {
+	case domain.ErrAPITokenNotFound:
+		response.NotFound(ctx, err.Error())
+	case domain.ErrAPITokenScopeInvalid, domain.ErrAPITokenExpiryInvalid, domain.ErrAPITokenLimitExceeded:
+		response.BadRequest(ctx, err.Error())
+	default:
+		response.InternalServerError(ctx, err.Error())
+		elog.Error(logMsg, elog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,9 @@
+package controller
+
+import "github.com/gin-gonic/gin"
+
+// userIDFromContext 获取登录鉴权中间件设置的用户ID，未经过鉴权时返回0。
+// 需要用户的接口都要挂载鉴权中间件，0 不对应任何用户，不会读写到其他人的数据
+func userIDFromContext(ctx *gin.Context) int64 {
+	return ctx.GetInt64("user_id")
+}
@@ -0,0 +1,222 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/response"
+	"loverrecipe/internal/services/cooking"
+)
+
+type CookingLogController struct {
+	service cooking.Service
+}
+
+func NewCookingLogController(service cooking.Service) *CookingLogController {
+	return &CookingLogController{
+		service: service,
+	}
+}
+
+// CreateCookingLog 记录一次烹饪
+// @Summary 创建烹饪记录
+// @Description 记录某道菜实际被做的时间、烹饪人、份数等信息
+// @Tags 烹饪记录
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param log body domain.CreateCookingLogRequest true "烹饪记录"
+// @Success 200 {object} response.Response{data=domain.CookingLog} "创建成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 403 {object} response.Response{msg=string} "菜品不属于当前用户"
+// @Failure 404 {object} response.Response{msg=string} "菜品不存在"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/cooking-logs [post]
+func (c *CookingLogController) CreateCookingLog(ctx *gin.Context) {
+	var req domain.CreateCookingLogRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "请求参数错误: "+err.Error())
+		return
+	}
+
+	req.UserID = userIDFromContext(ctx)
+
+	log, err := c.service.CreateCookingLog(ctx.Request.Context(), req)
+	if err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "创建成功", log)
+}
+
+// GetCookingLog 获取烹饪记录详情
+// @Summary 获取烹饪记录详情
+// @Description 根据ID获取烹饪记录
+// @Tags 烹饪记录
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path int true "烹饪记录ID"
+// @Success 200 {object} response.Response{data=domain.CookingLog} "获取成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 403 {object} response.Response{msg=string} "无权限"
+// @Failure 404 {object} response.Response{msg=string} "烹饪记录不存在"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/cooking-logs/{id} [get]
+func (c *CookingLogController) GetCookingLog(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的烹饪记录ID")
+		return
+	}
+
+	log, err := c.service.GetCookingLog(ctx.Request.Context(), id, userIDFromContext(ctx))
+	if err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.Success(ctx, log)
+}
+
+// UpdateCookingLog 更新烹饪记录
+// @Summary 更新烹饪记录
+// @Description 更新烹饪记录，菜品变更时会同步刷新新旧菜品的烹饪次数
+// @Tags 烹饪记录
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path int true "烹饪记录ID"
+// @Param log body domain.UpdateCookingLogRequest true "烹饪记录"
+// @Success 200 {object} response.Response{data=domain.CookingLog} "更新成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 403 {object} response.Response{msg=string} "无权限"
+// @Failure 404 {object} response.Response{msg=string} "烹饪记录不存在"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/cooking-logs/{id} [put]
+func (c *CookingLogController) UpdateCookingLog(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的烹饪记录ID")
+		return
+	}
+
+	var req domain.UpdateCookingLogRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "请求参数错误: "+err.Error())
+		return
+	}
+
+	req.ID = id
+	req.UserID = userIDFromContext(ctx)
+
+	log, err := c.service.UpdateCookingLog(ctx.Request.Context(), req)
+	if err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "更新成功", log)
+}
+
+// DeleteCookingLog 删除烹饪记录
+// @Summary 删除烹饪记录
+// @Description 删除指定烹饪记录
+// @Tags 烹饪记录
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path int true "烹饪记录ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 403 {object} response.Response{msg=string} "无权限"
+// @Failure 404 {object} response.Response{msg=string} "烹饪记录不存在"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/cooking-logs/{id} [delete]
+func (c *CookingLogController) DeleteCookingLog(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的烹饪记录ID")
+		return
+	}
+
+	if err := c.service.DeleteCookingLog(ctx.Request.Context(), id, userIDFromContext(ctx)); err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "删除成功", nil)
+}
+
+// ListCookingLogs 获取烹饪记录列表
+// @Summary 获取烹饪记录列表
+// @Description 按烹饪时间倒序分页获取当前用户的烹饪记录，可按菜品与时间范围过滤
+// @Tags 烹饪记录
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param dish_id query int false "菜品ID"
+// @Param from query int false "烹饪时间下限（Unix 秒，含）"
+// @Param to query int false "烹饪时间上限（Unix 秒，不含）"
+// @Param page query int false "页码，默认1"
+// @Param size query int false "每页数量，默认10，最大100"
+// @Success 200 {object} response.Response{data=domain.CookingLogListResponse} "获取成功"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/cooking-logs [get]
+func (c *CookingLogController) ListCookingLogs(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(ctx.DefaultQuery("size", "10"))
+	dishID, _ := strconv.ParseInt(ctx.Query("dish_id"), 10, 64)
+	from, _ := strconv.ParseInt(ctx.Query("from"), 10, 64)
+	to, _ := strconv.ParseInt(ctx.Query("to"), 10, 64)
+
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 10
+	}
+
+	query := domain.CookingLogQuery{
+		UserID: userIDFromContext(ctx),
+		DishID: dishID,
+		From:   from,
+		To:     to,
+		Offset: (page - 1) * size,
+		Limit:  size,
+	}
+
+	result, err := c.service.ListCookingLogs(ctx.Request.Context(), query)
+	if err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.Success(ctx, result)
+}
+
+// errorResponse 烹饪记录接口的错误响应
+func (c *CookingLogController) errorResponse(ctx *gin.Context, err error) {
+	switch err {
+	case domain.ErrCookingLogNotFound:
+		response.NotFound(ctx, err.Error())
+	case domain.ErrCookingLogUserMismatch:
+		response.Forbidden(ctx, err.Error())
+	case domain.ErrDishesNotFound:
+		response.DishNotFound(ctx)
+	case domain.ErrDishesUserMismatch:
+		response.DishUserMismatch(ctx)
+	case domain.ErrCookingLogServingsInvalid, domain.ErrCookingLogTimeInvalid:
+		response.BadRequest(ctx, err.Error())
+	default:
+		response.AppErrorResponse(ctx, err)
+	}
+}
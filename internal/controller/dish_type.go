@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/response"
+	"loverrecipe/internal/services/dishtype"
+)
+
+type DishTypeController struct {
+	service dishtype.Service
+}
+
+func NewDishTypeController(service dishtype.Service) *DishTypeController {
+	return &DishTypeController{
+		service: service,
+	}
+}
+
+// CreateDishType 创建菜品种类
+// @Summary 创建菜品种类
+// @Description 创建当前用户的菜品种类，填写 parent_id 时作为该种类的子种类，例如 热菜 → 川菜 → 水煮类，最多5层
+// @Tags 菜品种类
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param dishType body domain.CreateDishTypeRequest true "种类信息"
+// @Success 200 {object} response.Response{data=domain.DishType} "创建成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/dish-types [post]
+func (c *DishTypeController) CreateDishType(ctx *gin.Context) {
+	var req domain.CreateDishTypeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "请求参数错误: "+err.Error())
+		return
+	}
+
+	req.UserID = userIDFromContext(ctx)
+
+	dishType, err := c.service.CreateDishType(ctx.Request.Context(), req)
+	if err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "创建成功", dishType)
+}
+
+// GetDishTypeTree 获取种类树
+// @Summary 获取种类树
+// @Description 获取当前用户的完整种类树。dish_count 为直接属于该种类的菜品数，total_count 包含全部子孙种类；同一层按排序权重从大到小排列
+// @Tags 菜品种类
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Success 200 {object} response.Response{data=[]domain.DishTypeNode} "获取成功"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/dish-types/tree [get]
+func (c *DishTypeController) GetDishTypeTree(ctx *gin.Context) {
+	tree, err := c.service.GetDishTypeTree(ctx.Request.Context(), userIDFromContext(ctx))
+	if err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.Success(ctx, tree)
+}
+
+// MoveDishType 调整种类的上级
+// @Summary 调整种类的上级
+// @Description 把种类连同全部子孙种类移到新的上级下，parent_id 为0表示移到顶层。不能移到自身或自身的子孙种类下，移动后层数不能超过5层
+// @Tags 菜品种类
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path int true "种类ID"
+// @Param body body domain.MoveDishTypeRequest true "新的上级种类"
+// @Success 200 {object} response.Response{data=domain.DishType} "移动成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 403 {object} response.Response{msg=string} "种类不属于当前用户"
+// @Failure 404 {object} response.Response{msg=string} "种类不存在"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/dish-types/{id}/parent [put]
+func (c *DishTypeController) MoveDishType(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的菜品种类ID")
+		return
+	}
+	var req domain.MoveDishTypeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "请求参数错误: "+err.Error())
+		return
+	}
+	req.UserID = userIDFromContext(ctx)
+	req.ID = id
+
+	dishType, err := c.service.MoveDishType(ctx.Request.Context(), req)
+	if err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "移动成功", dishType)
+}
+
+// ReorderDishTypes 调整种类顺序
+// @Summary 调整种类顺序
+// @Description 按 ids 的顺序排列种类，ids 为同一上级下全部子种类拖动后的顺序，一次最多1000项。只改写顺序发生变化的种类，种类树与种类列表按此顺序返回
+// @Tags 菜品种类
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param body body domain.ReorderRequest true "排序后的种类ID"
+// @Success 200 {object} response.Response "排序成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 403 {object} response.Response{msg=string} "种类不属于当前用户"
+// @Failure 404 {object} response.Response{msg=string} "种类不存在"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/dish-types/order [put]
+func (c *DishTypeController) ReorderDishTypes(ctx *gin.Context) {
+	var req domain.ReorderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "请求参数错误: "+err.Error())
+		return
+	}
+	req.UserID = userIDFromContext(ctx)
+
+	if err := c.service.ReorderDishTypes(ctx.Request.Context(), req); err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "排序成功", nil)
+}
+
+// errorResponse 菜品种类接口的错误响应
+func (c *DishTypeController) errorResponse(ctx *gin.Context, err error) {
+	switch err {
+	case domain.ErrDishTypeNotFound:
+		response.DishTypeNotFound(ctx)
+	case domain.ErrDishTypeUserMismatch:
+		response.DishTypeUserMismatch(ctx)
+	case domain.ErrDishTypeNameEmpty:
+		response.DishTypeNameEmpty(ctx)
+	case domain.ErrDishTypeParentInvalid, domain.ErrDishTypeCycle, domain.ErrDishTypeTooDeep,
+		domain.ErrReorderEmpty, domain.ErrReorderTooMany, domain.ErrReorderDuplicate, domain.ErrReorderIncomplete:
+		response.BadRequest(ctx, err.Error())
+	default:
+		response.AppErrorResponse(ctx, err)
+	}
+}
@@ -1,15 +1,25 @@
 package controller
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gotomicro/ego/core/elog"
 
 	"loverrecipe/internal/domain"
+	"loverrecipe/internal/pkg/schemaorg"
 	"loverrecipe/internal/response"
 	"loverrecipe/internal/services/dishes"
 )
 
+// maxImportSize 导入文件的最大字节数
+const maxImportSize = 5 << 20
+
 type DishController struct {
 	service dishes.Service
 }
@@ -30,7 +40,9 @@ func NewDishControllerWithRegister(service dishes.Service) *DishController {
 // @Produce json
 // @Param Authorization header string true "Bearer 用户令牌"
 // @Param dishes body domain.CreateDishesRequest true "菜品信息"
-// @Success 200 {object} response.Response{data=domain.Dishes} "创建成功"
+// @Param check_duplicates query bool false "为 true 时在 duplicates 中返回名称相同或相近的已有菜品，不影响创建"
+// @Param Idempotency-Key header string false "幂等键，超时重试时携带相同的值不会重复创建"
+// @Success 200 {object} response.Response{data=domain.CreateDishesResponse} "创建成功"
 // @Failure 400 {object} response.Response{msg=string} "请求参数错误"
 // @Failure 401 {object} response.Response{msg=string} "未授权"
 // @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
@@ -43,16 +55,29 @@ func (c *DishController) CreateDishes(ctx *gin.Context) {
 	}
 
 	// 从JWT中获取用户ID（这里需要根据您的JWT实现调整）
-	userID := c.getUserIDFromContext(ctx)
+	userID := userIDFromContext(ctx)
 	req.UserID = userID
 
 	dishes, err := c.service.CreateDishes(ctx.Request.Context(), req)
 	if err != nil {
+		if err == domain.ErrAllergenUnknown || err == domain.ErrAllergensTooMany {
+			response.BadRequest(ctx, err.Error())
+			return
+		}
 		response.AppErrorResponse(ctx, err)
 		return
 	}
 
-	response.SuccessWithMsg(ctx, "创建成功", dishes)
+	resp := domain.CreateDishesResponse{Dishes: *dishes}
+	if check, _ := strconv.ParseBool(ctx.Query("check_duplicates")); check {
+		// 查重只是提示，失败时照常返回创建结果
+		resp.Duplicates, err = c.service.FindSimilarDishes(ctx.Request.Context(), userID, dishes.Name, dishes.ID)
+		if err != nil {
+			elog.Warn("查找相近菜品失败", elog.FieldErr(err), elog.Int64("dishID", dishes.ID))
+		}
+	}
+
+	response.SuccessWithMsg(ctx, "创建成功", resp)
 }
 
 // GetDishesByID 根据ID获取菜品详情
@@ -119,10 +144,14 @@ func (c *DishController) UpdateDishes(ctx *gin.Context) {
 	}
 
 	req.ID = id
-	req.UserID = c.getUserIDFromContext(ctx)
+	req.UserID = userIDFromContext(ctx)
 
 	dishes, err := c.service.UpdateDishes(ctx.Request.Context(), req)
 	if err != nil {
+		if err == domain.ErrAllergenUnknown || err == domain.ErrAllergensTooMany {
+			response.BadRequest(ctx, err.Error())
+			return
+		}
 		if err == domain.ErrDishesNotFound {
 			response.DishNotFound(ctx)
 			return
@@ -161,7 +190,7 @@ func (c *DishController) DeleteDishes(ctx *gin.Context) {
 		return
 	}
 
-	userID := c.getUserIDFromContext(ctx)
+	userID := userIDFromContext(ctx)
 	err = c.service.DeleteDishes(ctx.Request.Context(), id, userID)
 	if err != nil {
 		if err == domain.ErrDishesNotFound {
@@ -189,6 +218,13 @@ func (c *DishController) DeleteDishes(ctx *gin.Context) {
 // @Param page query int false "页码，默认1"
 // @Param size query int false "每页数量，默认10，最大100"
 // @Param type query int false "菜品种类ID"
+// @Param include_descendants query bool false "按种类过滤时同时包含全部子种类"
+// @Param favorite query bool false "仅返回已收藏的菜品"
+// @Param sort query string false "排序方式 rating/favorites/cooked，默认按自定义顺序，未排过序的按创建顺序排在后面"
+// @Param tags query string false "包含的标签ID，逗号分隔"
+// @Param tag_match query string false "多个标签的匹配方式 any（任意一个，默认）/all（全部）"
+// @Param exclude_tags query string false "排除的标签ID，逗号分隔"
+// @Param exclude_conflicts query bool false "排除与饮食档案冲突的菜品，默认仅标记冲突"
 // @Success 200 {object} response.Response{data=domain.DishesListResponse} "获取成功"
 // @Failure 400 {object} response.Response{msg=string} "请求参数错误"
 // @Failure 401 {object} response.Response{msg=string} "未授权"
@@ -198,6 +234,19 @@ func (c *DishController) ListDishes(ctx *gin.Context) {
 	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
 	size, _ := strconv.Atoi(ctx.DefaultQuery("size", "10"))
 	typeID, _ := strconv.ParseInt(ctx.Query("type"), 10, 64)
+	includeDescendants, _ := strconv.ParseBool(ctx.Query("include_descendants"))
+	favorite, _ := strconv.ParseBool(ctx.Query("favorite"))
+	excludeConflicts, _ := strconv.ParseBool(ctx.Query("exclude_conflicts"))
+	sort, err := domain.ParseDishesSort(ctx.Query("sort"))
+	if err != nil {
+		response.BadRequest(ctx, err.Error())
+		return
+	}
+	tagIDs, excludeTagIDs, tagMatch, err := c.parseTagFilter(ctx)
+	if err != nil {
+		response.BadRequest(ctx, err.Error())
+		return
+	}
 
 	if page < 1 {
 		page = 1
@@ -210,13 +259,20 @@ func (c *DishController) ListDishes(ctx *gin.Context) {
 	}
 
 	offset := (page - 1) * size
-	userID := c.getUserIDFromContext(ctx)
+	userID := userIDFromContext(ctx)
 
 	query := domain.DishesQuery{
-		UserID: userID,
-		Type:   typeID,
-		Offset: offset,
-		Limit:  size,
+		UserID:             userID,
+		Type:               typeID,
+		IncludeDescendants: includeDescendants,
+		Favorite:           favorite,
+		Sort:               sort,
+		TagIDs:             tagIDs,
+		TagMatch:           tagMatch,
+		ExcludeTagIDs:      excludeTagIDs,
+		ExcludeConflicts:   excludeConflicts,
+		Offset:             offset,
+		Limit:              size,
 	}
 
 	result, err := c.service.ListDishes(ctx.Request.Context(), query)
@@ -238,6 +294,12 @@ func (c *DishController) ListDishes(ctx *gin.Context) {
 // @Param keyword query string true "搜索关键词"
 // @Param page query int false "页码，默认1"
 // @Param size query int false "每页数量，默认10，最大100"
+// @Param favorite query bool false "仅返回已收藏的菜品"
+// @Param sort query string false "排序方式 rating/favorites/cooked，默认按自定义顺序，未排过序的按创建顺序排在后面"
+// @Param tags query string false "包含的标签ID，逗号分隔"
+// @Param tag_match query string false "多个标签的匹配方式 any（任意一个，默认）/all（全部）"
+// @Param exclude_tags query string false "排除的标签ID，逗号分隔"
+// @Param exclude_conflicts query bool false "排除与饮食档案冲突的菜品，默认仅标记冲突"
 // @Success 200 {object} response.Response{data=domain.DishesListResponse} "搜索成功"
 // @Failure 400 {object} response.Response{msg=string} "请求参数错误"
 // @Failure 401 {object} response.Response{msg=string} "未授权"
@@ -247,6 +309,18 @@ func (c *DishController) SearchDishes(ctx *gin.Context) {
 	keyword := ctx.Query("keyword")
 	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
 	size, _ := strconv.Atoi(ctx.DefaultQuery("size", "10"))
+	favorite, _ := strconv.ParseBool(ctx.Query("favorite"))
+	excludeConflicts, _ := strconv.ParseBool(ctx.Query("exclude_conflicts"))
+	sort, err := domain.ParseDishesSort(ctx.Query("sort"))
+	if err != nil {
+		response.BadRequest(ctx, err.Error())
+		return
+	}
+	tagIDs, excludeTagIDs, tagMatch, err := c.parseTagFilter(ctx)
+	if err != nil {
+		response.BadRequest(ctx, err.Error())
+		return
+	}
 
 	if page < 1 {
 		page = 1
@@ -259,9 +333,22 @@ func (c *DishController) SearchDishes(ctx *gin.Context) {
 	}
 
 	offset := (page - 1) * size
-	userID := c.getUserIDFromContext(ctx)
+	userID := userIDFromContext(ctx)
+
+	query := domain.DishesQuery{
+		UserID:           userID,
+		Keyword:          keyword,
+		Favorite:         favorite,
+		Sort:             sort,
+		TagIDs:           tagIDs,
+		TagMatch:         tagMatch,
+		ExcludeTagIDs:    excludeTagIDs,
+		ExcludeConflicts: excludeConflicts,
+		Offset:           offset,
+		Limit:            size,
+	}
 
-	result, err := c.service.SearchDishes(ctx.Request.Context(), userID, keyword, offset, size)
+	result, err := c.service.SearchDishes(ctx.Request.Context(), query)
 	if err != nil {
 		response.AppErrorResponse(ctx, err)
 		return
@@ -270,21 +357,100 @@ func (c *DishController) SearchDishes(ctx *gin.Context) {
 	response.SuccessWithMsg(ctx, "搜索成功", result)
 }
 
+// RandomDishes 随机挑选菜品
+// @Summary 随机挑选菜品
+// @Description 在满足过滤条件的菜品中随机挑选一道，同样会标记或排除与饮食档案冲突的菜品
+// @Tags 菜品管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param type query int false "菜品种类ID"
+// @Param include_descendants query bool false "按种类过滤时同时包含全部子种类"
+// @Param favorite query bool false "仅从已收藏的菜品中挑选"
+// @Param tags query string false "包含的标签ID，逗号分隔"
+// @Param tag_match query string false "多个标签的匹配方式 any（任意一个，默认）/all（全部）"
+// @Param exclude_tags query string false "排除的标签ID，逗号分隔"
+// @Param exclude_conflicts query bool false "排除与饮食档案冲突的菜品，默认仅标记冲突"
+// @Success 200 {object} response.Response{data=domain.DishesWithType} "获取成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 404 {object} response.Response{msg=string} "没有符合条件的菜品"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/dishes/random [get]
+func (c *DishController) RandomDishes(ctx *gin.Context) {
+	typeID, _ := strconv.ParseInt(ctx.Query("type"), 10, 64)
+	includeDescendants, _ := strconv.ParseBool(ctx.Query("include_descendants"))
+	favorite, _ := strconv.ParseBool(ctx.Query("favorite"))
+	excludeConflicts, _ := strconv.ParseBool(ctx.Query("exclude_conflicts"))
+	tagIDs, excludeTagIDs, tagMatch, err := c.parseTagFilter(ctx)
+	if err != nil {
+		response.BadRequest(ctx, err.Error())
+		return
+	}
+
+	query := domain.DishesQuery{
+		UserID:             userIDFromContext(ctx),
+		Type:               typeID,
+		IncludeDescendants: includeDescendants,
+		Favorite:           favorite,
+		TagIDs:             tagIDs,
+		TagMatch:           tagMatch,
+		ExcludeTagIDs:      excludeTagIDs,
+		ExcludeConflicts:   excludeConflicts,
+	}
+
+	dish, err := c.service.RandomDishes(ctx.Request.Context(), query)
+	if err != nil {
+		if err == domain.ErrDishesNotFound {
+			response.DishNotFound(ctx)
+			return
+		}
+		response.AppErrorResponse(ctx, err)
+		return
+	}
+
+	response.Success(ctx, dish)
+}
+
 // GetDishesStatistics 获取菜品统计
 // @Summary 获取菜品统计
-// @Description 获取当前用户的菜品统计信息
+// @Description 获取当前用户的菜品统计信息：价格与卡路里的合计、极值、中位数与百分位，可按种类或创建月份分组，
+// @Description 包含每周新建菜品数序列，以及按周或按月汇总的实际烹饪卡路里与花费
 // @Tags 菜品管理
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer 用户令牌"
+// @Param period query string false "统计周期：week 或 month，默认 month"
+// @Param periods query int false "统计的周期数，默认 12"
+// @Param group_by query string false "分组方式：type 或 month，默认不分组"
+// @Param weeks query int false "新建菜品周序列的周数，默认 12，最大 104"
 // @Success 200 {object} response.Response{data=dishes.DishesStatistics} "获取成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
 // @Failure 401 {object} response.Response{msg=string} "未授权"
 // @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
 // @Router /api/v1/dishes/statistics [get]
 func (c *DishController) GetDishesStatistics(ctx *gin.Context) {
-	userID := c.getUserIDFromContext(ctx)
+	period, err := domain.ParseStatisticsPeriod(ctx.Query("period"))
+	if err != nil {
+		response.BadRequest(ctx, err.Error())
+		return
+	}
+	periods, _ := strconv.Atoi(ctx.Query("periods"))
+	groupBy, err := domain.ParseDishesGroupBy(ctx.Query("group_by"))
+	if err != nil {
+		response.BadRequest(ctx, err.Error())
+		return
+	}
+	weeks, _ := strconv.Atoi(ctx.Query("weeks"))
+
+	query := domain.DishesStatisticsQuery{
+		UserID:  userIDFromContext(ctx),
+		Period:  period,
+		Periods: periods,
+		GroupBy: groupBy,
+		Weeks:   weeks,
+	}
 
-	stats, err := c.service.GetDishesStatistics(ctx.Request.Context(), userID)
+	stats, err := c.service.GetDishesStatistics(ctx.Request.Context(), query)
 	if err != nil {
 		response.AppErrorResponse(ctx, err)
 		return
@@ -301,9 +467,12 @@ func (c *DishController) GetDishesStatistics(ctx *gin.Context) {
 // @Produce json
 // @Param Authorization header string true "Bearer 用户令牌"
 // @Param typeId path int true "菜品种类ID"
+// @Param include_descendants query bool false "同时包含全部子种类下的菜品"
 // @Success 200 {object} response.Response{data=[]domain.Dishes} "获取成功"
 // @Failure 400 {object} response.Response{msg=string} "请求参数错误"
 // @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 403 {object} response.Response{msg=string} "种类不属于当前用户"
+// @Failure 404 {object} response.Response{msg=string} "种类不存在"
 // @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
 // @Router /api/v1/dishes/type/{typeId} [get]
 func (c *DishController) GetDishesByType(ctx *gin.Context) {
@@ -314,7 +483,16 @@ func (c *DishController) GetDishesByType(ctx *gin.Context) {
 		return
 	}
 
-	dishes, err := c.service.GetDishesByType(ctx.Request.Context(), typeID)
+	includeDescendants, _ := strconv.ParseBool(ctx.Query("include_descendants"))
+	dishes, err := c.service.GetDishesByType(ctx.Request.Context(), userIDFromContext(ctx), typeID, includeDescendants)
+	if err == domain.ErrDishTypeNotFound {
+		response.DishTypeNotFound(ctx)
+		return
+	}
+	if err == domain.ErrDishTypeUserMismatch {
+		response.DishTypeUserMismatch(ctx)
+		return
+	}
 	if err != nil {
 		response.AppErrorResponse(ctx, err)
 		return
@@ -335,7 +513,7 @@ func (c *DishController) GetDishesByType(ctx *gin.Context) {
 // @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
 // @Router /api/v1/dishes/with-type [get]
 func (c *DishController) GetDishesWithTypeInfo(ctx *gin.Context) {
-	userID := c.getUserIDFromContext(ctx)
+	userID := userIDFromContext(ctx)
 
 	dishesWithType, err := c.service.GetDishesWithTypeInfo(ctx.Request.Context(), userID)
 	if err != nil {
@@ -346,16 +524,587 @@ func (c *DishController) GetDishesWithTypeInfo(ctx *gin.Context) {
 	response.Success(ctx, dishesWithType)
 }
 
-// getUserIDFromContext 从上下文中获取用户ID
-// 这里需要根据您的JWT实现来调整
-func (c *DishController) getUserIDFromContext(ctx *gin.Context) int64 {
-	// 示例实现，需要根据您的JWT中间件调整
-	// 假设JWT中间件将用户ID存储在上下文中
-	if userID, exists := ctx.Get("user_id"); exists {
-		if id, ok := userID.(int64); ok {
-			return id
+// ExportDishes 导出菜品
+// @Summary 导出菜品
+// @Description 导出当前用户的菜品及其种类信息，支持 JSON（无损）、CSV（扁平）与 Markdown（可读）格式
+// @Tags 菜品管理
+// @Produce json
+// @Produce text/csv
+// @Produce text/markdown
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param format query string false "导出格式 json/csv/markdown，默认json"
+// @Success 200 {file} file "导出文件"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/dishes/export [get]
+func (c *DishController) ExportDishes(ctx *gin.Context) {
+	format, err := domain.ParseDishesExchangeFormat(ctx.DefaultQuery("format", string(domain.DishesExchangeJSON)))
+	if err != nil {
+		response.BadRequest(ctx, err.Error())
+		return
+	}
+
+	userID := userIDFromContext(ctx)
+	file, err := c.service.ExportDishes(ctx.Request.Context(), userID, format)
+	if err != nil {
+		response.AppErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.Name))
+	ctx.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
+// ImportDishes 导入菜品
+// @Summary 导入菜品
+// @Description 从 JSON 或 CSV 导入菜品，逐行校验，自动创建缺失的种类，按名称跳过重复菜品并返回逐行导入报告
+// @Tags 菜品管理
+// @Accept json
+// @Accept text/csv
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param format query string false "导入格式 json/csv，上传文件时可由扩展名推断"
+// @Param file formData file false "导入文件，未上传文件时读取请求体"
+// @Success 200 {object} response.Response{data=domain.DishesImportResult} "导入完成"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/dishes/import [post]
+func (c *DishController) ImportDishes(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportSize)
+
+	formatName := ctx.Query("format")
+	var body io.Reader = ctx.Request.Body
+	if ctx.ContentType() == gin.MIMEMultipartPOSTForm {
+		fileHeader, err := ctx.FormFile("file")
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				response.FileSizeExceeded(ctx)
+				return
+			}
+			response.BadRequest(ctx, "请上传导入文件")
+			return
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			response.FileUploadError(ctx)
+			return
+		}
+		defer file.Close()
+		body = file
+		if formatName == "" {
+			formatName = filepath.Ext(fileHeader.Filename)
+		}
+	}
+	if formatName == "" {
+		formatName = string(domain.DishesExchangeJSON)
+	}
+
+	format, err := domain.ParseDishesExchangeFormat(formatName)
+	if err != nil || format == domain.DishesExchangeMarkdown {
+		response.BadRequest(ctx, domain.ErrDishesExchangeFormatInvalid.Error())
+		return
+	}
+
+	userID := userIDFromContext(ctx)
+	result, err := c.service.ImportDishes(ctx.Request.Context(), userID, format, body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			response.FileSizeExceeded(ctx)
+			return
+		}
+		if errors.Is(err, domain.ErrDishesImportMalformed) || err == domain.ErrDishesImportEmpty ||
+			err == domain.ErrDishesImportTooLarge || err == domain.ErrDishesExchangeFormatInvalid {
+			response.BadRequest(ctx, err.Error())
+			return
+		}
+		response.AppErrorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "导入完成", result)
+}
+
+// ImportRecipe 从 schema.org Recipe 导入菜品
+// @Summary 导入网页菜谱
+// @Description 解析网页中嵌入的 schema.org Recipe JSON-LD 并创建菜品，可提交网页链接、粘贴 HTML/JSON-LD 或上传文件，菜谱分类会映射为菜品种类
+// @Tags 菜品管理
+// @Accept json
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param recipe body domain.RecipeImportRequest false "菜谱链接或内容"
+// @Param file formData file false "HTML 或 JSON-LD 文件"
+// @Success 200 {object} response.Response{data=domain.Dishes} "导入成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/dishes/import/recipe [post]
+func (c *DishController) ImportRecipe(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportSize)
+
+	var req domain.RecipeImportRequest
+	if ctx.ContentType() == gin.MIMEMultipartPOSTForm {
+		fileHeader, err := ctx.FormFile("file")
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				response.FileSizeExceeded(ctx)
+				return
+			}
+			response.BadRequest(ctx, "请上传菜谱文件")
+			return
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			response.FileUploadError(ctx)
+			return
+		}
+		defer file.Close()
+		content, err := io.ReadAll(file)
+		if err != nil {
+			response.FileUploadError(ctx)
+			return
+		}
+		req.Content = string(content)
+	} else if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "请求参数错误: "+err.Error())
+		return
+	}
+
+	req.UserID = userIDFromContext(ctx)
+	dishes, err := c.service.ImportRecipe(ctx.Request.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, schemaorg.ErrFetchFailed) || err == schemaorg.ErrDocumentLarge:
+			response.ThirdPartyError(ctx, err.Error())
+		case err == domain.ErrRecipeImportSourceEmpty || err == schemaorg.ErrInvalidURL ||
+			err == schemaorg.ErrRecipeNotFound || err == schemaorg.ErrInvalidJSONLD:
+			response.BadRequest(ctx, err.Error())
+		default:
+			response.AppErrorResponse(ctx, err)
+		}
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "导入成功", dishes)
+}
+
+// FavoriteDishes 收藏菜品
+// @Summary 收藏菜品
+// @Description 收藏指定菜品，重复收藏不会报错
+// @Tags 菜品管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path int true "菜品ID"
+// @Success 200 {object} response.Response{msg=string} "收藏成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 404 {object} response.Response{msg=string} "菜品不存在"
+// @Failure 403 {object} response.Response{msg=string} "菜品不属于当前用户且未被分享"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/dishes/{id}/favorite [post]
+func (c *DishController) FavoriteDishes(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的菜品ID")
+		return
+	}
+
+	userID := userIDFromContext(ctx)
+	if err := c.service.FavoriteDishes(ctx.Request.Context(), userID, id); err != nil {
+		c.feedbackErrorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "收藏成功", nil)
+}
+
+// UnfavoriteDishes 取消收藏菜品
+// @Summary 取消收藏菜品
+// @Description 取消收藏指定菜品
+// @Tags 菜品管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path int true "菜品ID"
+// @Success 200 {object} response.Response{msg=string} "取消收藏成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 404 {object} response.Response{msg=string} "菜品不存在"
+// @Failure 403 {object} response.Response{msg=string} "菜品不属于当前用户且未被分享"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/dishes/{id}/favorite [delete]
+func (c *DishController) UnfavoriteDishes(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的菜品ID")
+		return
+	}
+
+	userID := userIDFromContext(ctx)
+	if err := c.service.UnfavoriteDishes(ctx.Request.Context(), userID, id); err != nil {
+		c.feedbackErrorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "取消收藏成功", nil)
+}
+
+// RateDishes 为菜品评分
+// @Summary 为菜品评分
+// @Description 为指定菜品打 1-5 分并可附带评价，重复评分会覆盖之前的评分
+// @Tags 菜品管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path int true "菜品ID"
+// @Param rating body domain.RateDishesRequest true "评分信息"
+// @Success 200 {object} response.Response{data=domain.DishRating} "评分成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 404 {object} response.Response{msg=string} "菜品不存在"
+// @Failure 403 {object} response.Response{msg=string} "菜品不属于当前用户且未被分享"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/dishes/{id}/rating [put]
+func (c *DishController) RateDishes(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的菜品ID")
+		return
+	}
+
+	var req domain.RateDishesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "请求参数错误: "+err.Error())
+		return
+	}
+
+	req.DishID = id
+	req.UserID = userIDFromContext(ctx)
+
+	rating, err := c.service.RateDishes(ctx.Request.Context(), req)
+	if err != nil {
+		c.feedbackErrorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "评分成功", rating)
+}
+
+// DeleteDishesRating 删除菜品评分
+// @Summary 删除菜品评分
+// @Description 删除当前用户对指定菜品的评分
+// @Tags 菜品管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path int true "菜品ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 404 {object} response.Response{msg=string} "菜品不存在"
+// @Failure 403 {object} response.Response{msg=string} "菜品不属于当前用户且未被分享"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/dishes/{id}/rating [delete]
+func (c *DishController) DeleteDishesRating(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的菜品ID")
+		return
+	}
+
+	userID := userIDFromContext(ctx)
+	if err := c.service.DeleteDishesRating(ctx.Request.Context(), userID, id); err != nil {
+		c.feedbackErrorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "删除成功", nil)
+}
+
+// ListDishesRatings 获取菜品评分列表
+// @Summary 获取菜品评分列表
+// @Description 分页获取指定菜品的评分与评价
+// @Tags 菜品管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path int true "菜品ID"
+// @Param page query int false "页码，默认1"
+// @Param size query int false "每页数量，默认10，最大100"
+// @Success 200 {object} response.Response{data=domain.DishRatingListResponse} "获取成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 403 {object} response.Response{msg=string} "菜品不属于当前用户且未被分享"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/dishes/{id}/ratings [get]
+func (c *DishController) ListDishesRatings(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的菜品ID")
+		return
+	}
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(ctx.DefaultQuery("size", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = 10
+	}
+	if size > 100 {
+		size = 100
+	}
+
+	result, err := c.service.ListDishesRatings(ctx.Request.Context(), userIDFromContext(ctx), id, (page-1)*size, size)
+	if err != nil {
+		c.feedbackErrorResponse(ctx, err)
+		return
+	}
+
+	response.Success(ctx, result)
+}
+
+// parseTagFilter 解析标签过滤参数
+func (c *DishController) parseTagFilter(ctx *gin.Context) ([]int64, []int64, domain.TagMatchMode, error) {
+	tagIDs, err := domain.ParseTagIDs(ctx.Query("tags"))
+	if err != nil {
+		return nil, nil, "", err
+	}
+	excludeTagIDs, err := domain.ParseTagIDs(ctx.Query("exclude_tags"))
+	if err != nil {
+		return nil, nil, "", err
+	}
+	tagMatch, err := domain.ParseTagMatchMode(ctx.Query("tag_match"))
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return tagIDs, excludeTagIDs, tagMatch, nil
+}
+
+// CloneDishes 复制菜品
+// @Summary 复制菜品
+// @Description 将可读的菜品（自己的菜品或他人通过有效分享链接公开的菜品）复制到自己的菜品中，复制全部内容并记录来源菜品ID与来源用户ID，不复制评分、收藏与标签。
+// @Description 来源种类不属于当前用户时，按名称匹配已有种类，没有则复制一个同名种类
+// @Tags 菜品管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path int true "来源菜品ID"
+// @Success 200 {object} response.Response{data=domain.Dishes} "复制成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 403 {object} response.Response{msg=string} "菜品不属于当前用户且未被分享"
+// @Failure 404 {object} response.Response{msg=string} "菜品不存在"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/dishes/{id}/clone [post]
+func (c *DishController) CloneDishes(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的菜品ID")
+		return
+	}
+
+	dishes, err := c.service.CloneDishes(ctx.Request.Context(), userIDFromContext(ctx), id)
+	if err != nil {
+		c.cloneErrorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "复制成功", dishes)
+}
+
+// GetDishesSourceStatus 查看复制来源的改动
+// @Summary 查看复制来源的改动
+// @Description 对复制而来的菜品，查看来源菜品是否仍然存在，以及复制后来源菜品的内容是否有改动。来源的分享失效后按来源不存在处理
+// @Tags 菜品管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path int true "菜品ID"
+// @Success 200 {object} response.Response{data=domain.DishesSourceStatus} "获取成功"
+// @Failure 400 {object} response.Response{msg=string} "菜品不是复制而来"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 403 {object} response.Response{msg=string} "菜品不属于当前用户"
+// @Failure 404 {object} response.Response{msg=string} "菜品不存在"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/dishes/{id}/source [get]
+func (c *DishController) GetDishesSourceStatus(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的菜品ID")
+		return
+	}
+
+	status, err := c.service.GetDishesSourceStatus(ctx.Request.Context(), userIDFromContext(ctx), id)
+	if err != nil {
+		c.cloneErrorResponse(ctx, err)
+		return
+	}
+
+	response.Success(ctx, status)
+}
+
+// FindDuplicateDishes 查找重复菜品
+// @Summary 查找重复菜品
+// @Description 在当前用户的菜品中查找名称相同或相近的菜品并给出合并建议。比较前忽略大小写、全角半角、空白标点与括号内的备注，繁体字按简体比较，再按编辑距离计算相似度。
+// @Description 每组中 keep_id 为建议保留的菜品（烹饪、评分与收藏最多，其次创建最早），merge_ids 可直接用于合并接口
+// @Tags 菜品管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param min_similarity query number false "名称相似度阈值，0.5到1之间，默认0.75"
+// @Success 200 {object} response.Response{data=[]domain.DishesDuplicateGroup} "获取成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/dishes/duplicates [get]
+func (c *DishController) FindDuplicateDishes(ctx *gin.Context) {
+	var minSimilarity float64
+	if v := ctx.Query("min_similarity"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			response.BadRequest(ctx, domain.ErrDuplicateSimilarityInvalid.Error())
+			return
+		}
+		minSimilarity = parsed
+	}
+
+	groups, err := c.service.FindDuplicateDishes(ctx.Request.Context(), userIDFromContext(ctx), minSimilarity)
+	if err != nil {
+		c.mergeErrorResponse(ctx, err)
+		return
+	}
+
+	response.Success(ctx, groups)
+}
+
+// MergeDishes 合并菜品
+// @Summary 合并菜品
+// @Description 把 merge_ids 中的菜品合并到路径中的保留菜品后删除。收藏、评分与标签转移到保留菜品（同一用户已对保留菜品收藏或评分的保留原有记录），
+// @Description 烹饪记录、饮食记录、分享链接与复制来源改指保留菜品，并重新计算保留菜品的评分、收藏与烹饪次数。所有菜品都必须属于当前用户，一次最多合并50道
+// @Tags 菜品管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path int true "保留的菜品ID"
+// @Param body body domain.MergeDishesRequest true "被合并的菜品ID"
+// @Success 200 {object} response.Response{data=domain.Dishes} "合并成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 403 {object} response.Response{msg=string} "菜品不属于当前用户"
+// @Failure 404 {object} response.Response{msg=string} "菜品不存在"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/dishes/{id}/merge [post]
+func (c *DishController) MergeDishes(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的菜品ID")
+		return
+	}
+	var req domain.MergeDishesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "请求参数错误: "+err.Error())
+		return
+	}
+	req.UserID = userIDFromContext(ctx)
+	req.KeepID = id
+
+	dishes, err := c.service.MergeDishes(ctx.Request.Context(), req)
+	if err != nil {
+		c.mergeErrorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "合并成功", dishes)
+}
+
+// ReorderDishes 调整菜品顺序
+// @Summary 调整菜品顺序
+// @Description 按 ids 的顺序排列菜品，ids 为拖动后的完整列表，必须包含当前用户的全部菜品，一次最多1000项。只改写顺序发生变化的菜品，
+// @Description 菜品列表默认按此顺序返回，未排过序的菜品按创建顺序排在后面。所有菜品都必须属于当前用户
+// @Tags 菜品管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param body body domain.ReorderRequest true "排序后的菜品ID"
+// @Success 200 {object} response.Response "排序成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 403 {object} response.Response{msg=string} "菜品不属于当前用户"
+// @Failure 404 {object} response.Response{msg=string} "菜品不存在"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/dishes/order [put]
+func (c *DishController) ReorderDishes(ctx *gin.Context) {
+	var req domain.ReorderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "请求参数错误: "+err.Error())
+		return
+	}
+	req.UserID = userIDFromContext(ctx)
+
+	if err := c.service.ReorderDishes(ctx.Request.Context(), req); err != nil {
+		switch err {
+		case domain.ErrDishesNotFound:
+			response.DishNotFound(ctx)
+		case domain.ErrDishesUserMismatch:
+			response.DishUserMismatch(ctx)
+		case domain.ErrReorderEmpty, domain.ErrReorderTooMany, domain.ErrReorderDuplicate, domain.ErrReorderIncomplete:
+			response.BadRequest(ctx, err.Error())
+		default:
+			response.AppErrorResponse(ctx, err)
 		}
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "排序成功", nil)
+}
+
+// mergeErrorResponse 查重与合并接口的错误响应
+func (c *DishController) mergeErrorResponse(ctx *gin.Context, err error) {
+	switch err {
+	case domain.ErrDishesNotFound:
+		response.DishNotFound(ctx)
+	case domain.ErrDishesUserMismatch:
+		response.DishUserMismatch(ctx)
+	case domain.ErrDuplicateSimilarityInvalid, domain.ErrDishesMergeInvalid, domain.ErrDishesMergeTooMany:
+		response.BadRequest(ctx, err.Error())
+	default:
+		response.AppErrorResponse(ctx, err)
+	}
+}
+
+// cloneErrorResponse 复制菜品接口的错误响应
+func (c *DishController) cloneErrorResponse(ctx *gin.Context, err error) {
+	switch err {
+	case domain.ErrDishesNotFound:
+		response.DishNotFound(ctx)
+	case domain.ErrDishesUserMismatch:
+		response.DishUserMismatch(ctx)
+	case domain.ErrDishesNotCloned:
+		response.BadRequest(ctx, err.Error())
+	default:
+		response.AppErrorResponse(ctx, err)
+	}
+}
+
+// feedbackErrorResponse 评分与收藏接口的错误响应
+func (c *DishController) feedbackErrorResponse(ctx *gin.Context, err error) {
+	switch err {
+	case domain.ErrDishesNotFound:
+		response.DishNotFound(ctx)
+	case domain.ErrDishesUserMismatch:
+		response.DishUserMismatch(ctx)
+	case domain.ErrDishRatingInvalid, domain.ErrDishRatingCommentTooLong, domain.ErrDishFeedbackUserIDInvalid:
+		response.BadRequest(ctx, err.Error())
+	default:
+		response.AppErrorResponse(ctx, err)
 	}
-	// 临时返回默认值，实际项目中应该从JWT中解析
-	return 1
 }
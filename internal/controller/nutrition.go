@@ -0,0 +1,225 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/response"
+	"loverrecipe/internal/services/nutrition"
+)
+
+type NutritionController struct {
+	service nutrition.Service
+}
+
+func NewNutritionController(service nutrition.Service) *NutritionController {
+	return &NutritionController{
+		service: service,
+	}
+}
+
+// SetGoal 设置卡路里目标与花费预算
+// @Summary 设置饮食目标
+// @Description 整体覆盖当前用户的每日/每周卡路里目标与花费预算，0 表示不设置
+// @Tags 饮食目标
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param goal body domain.SetNutritionGoalRequest true "目标"
+// @Success 200 {object} response.Response{data=domain.NutritionGoal} "设置成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/nutrition/goals [put]
+func (c *NutritionController) SetGoal(ctx *gin.Context) {
+	var req domain.SetNutritionGoalRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "请求参数错误: "+err.Error())
+		return
+	}
+
+	req.UserID = userIDFromContext(ctx)
+
+	goal, err := c.service.SetGoal(ctx.Request.Context(), req)
+	if err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "设置成功", goal)
+}
+
+// GetGoal 获取卡路里目标与花费预算
+// @Summary 获取饮食目标
+// @Description 获取当前用户的每日/每周卡路里目标与花费预算，未设置时各项为0
+// @Tags 饮食目标
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Success 200 {object} response.Response{data=domain.NutritionGoal} "获取成功"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/nutrition/goals [get]
+func (c *NutritionController) GetGoal(ctx *gin.Context) {
+	goal, err := c.service.GetGoal(ctx.Request.Context(), userIDFromContext(ctx))
+	if err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.Success(ctx, goal)
+}
+
+// RecordMeal 记录吃了某道菜
+// @Summary 创建饮食记录
+// @Description 记录某天吃了某道菜，卡路里与花费按当前菜品数据乘以份数保存
+// @Tags 饮食目标
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param meal body domain.CreateMealRecordRequest true "饮食记录"
+// @Success 200 {object} response.Response{data=domain.MealRecord} "记录成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 403 {object} response.Response{msg=string} "无权限"
+// @Failure 404 {object} response.Response{msg=string} "菜品不存在"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/nutrition/meals [post]
+func (c *NutritionController) RecordMeal(ctx *gin.Context) {
+	var req domain.CreateMealRecordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "请求参数错误: "+err.Error())
+		return
+	}
+
+	req.UserID = userIDFromContext(ctx)
+
+	meal, err := c.service.RecordMeal(ctx.Request.Context(), req)
+	if err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "记录成功", meal)
+}
+
+// ListMeals 获取某一天的饮食记录
+// @Summary 获取饮食记录
+// @Description 获取当前用户某一天的饮食记录
+// @Tags 饮食目标
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param day query string false "日期 YYYY-MM-DD，默认今天"
+// @Success 200 {object} response.Response{data=[]domain.MealRecord} "获取成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/nutrition/meals [get]
+func (c *NutritionController) ListMeals(ctx *gin.Context) {
+	meals, err := c.service.ListMeals(ctx.Request.Context(), userIDFromContext(ctx), ctx.Query("day"))
+	if err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.Success(ctx, meals)
+}
+
+// DeleteMeal 删除饮食记录
+// @Summary 删除饮食记录
+// @Description 删除指定饮食记录
+// @Tags 饮食目标
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path int true "饮食记录ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 403 {object} response.Response{msg=string} "无权限"
+// @Failure 404 {object} response.Response{msg=string} "饮食记录不存在"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/nutrition/meals/{id} [delete]
+func (c *NutritionController) DeleteMeal(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的饮食记录ID")
+		return
+	}
+
+	if err := c.service.DeleteMeal(ctx.Request.Context(), id, userIDFromContext(ctx)); err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "删除成功", nil)
+}
+
+// GetProgress 获取目标完成情况
+// @Summary 获取目标完成情况
+// @Description 获取指定日期当天与所在周（周一至周日）的卡路里与花费完成情况，任一周期花费超出预算时 over_budget 为 true
+// @Tags 饮食目标
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param day query string false "日期 YYYY-MM-DD，默认今天"
+// @Success 200 {object} response.Response{data=domain.NutritionProgress} "获取成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/nutrition/progress [get]
+func (c *NutritionController) GetProgress(ctx *gin.Context) {
+	progress, err := c.service.GetProgress(ctx.Request.Context(), userIDFromContext(ctx), ctx.Query("day"))
+	if err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.Success(ctx, progress)
+}
+
+// Trend 获取每日饮食趋势
+// @Summary 获取每日饮食趋势
+// @Description 获取日期范围内（含首尾）每天的饮食合计快照，快照由每日定时任务生成，没有快照的日期各项为0
+// @Tags 饮食目标
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param from query string false "开始日期 YYYY-MM-DD，默认结束日期前30天"
+// @Param to query string false "结束日期 YYYY-MM-DD，默认今天"
+// @Success 200 {object} response.Response{data=[]domain.DailyNutritionSnapshot} "获取成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/nutrition/trend [get]
+func (c *NutritionController) Trend(ctx *gin.Context) {
+	trend, err := c.service.Trend(ctx.Request.Context(), userIDFromContext(ctx), ctx.Query("from"), ctx.Query("to"))
+	if err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.Success(ctx, trend)
+}
+
+// errorResponse 饮食目标接口的错误响应
+func (c *NutritionController) errorResponse(ctx *gin.Context, err error) {
+	switch err {
+	case domain.ErrMealRecordNotFound:
+		response.NotFound(ctx, err.Error())
+	case domain.ErrMealRecordUserMismatch:
+		response.Forbidden(ctx, err.Error())
+	case domain.ErrDishesNotFound:
+		response.DishNotFound(ctx)
+	case domain.ErrDishesUserMismatch:
+		response.DishUserMismatch(ctx)
+	case domain.ErrNutritionGoalInvalid, domain.ErrNutritionDayInvalid, domain.ErrNutritionDayInFuture,
+		domain.ErrNutritionRangeInvalid, domain.ErrMealRecordServingsInvalid:
+		response.BadRequest(ctx, err.Error())
+	default:
+		response.AppErrorResponse(ctx, err)
+	}
+}
@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/response"
+	"loverrecipe/internal/services/share"
+)
+
+type ShareController struct {
+	service share.Service
+}
+
+func NewShareController(service share.Service) *ShareController {
+	return &ShareController{
+		service: service,
+	}
+}
+
+// CreateShare 创建分享链接
+// @Summary 创建分享链接
+// @Description 为自己的单个菜品（dish）或整个种类（dish_type）创建只读分享链接，没有账号的人打开 /s/{token} 即可查看。过期时间不填表示永不过期，每个用户最多 100 个
+// @Tags 分享
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param share body domain.CreateShareRequest true "分享对象与过期时间"
+// @Success 200 {object} response.Response{data=domain.Share} "创建成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 403 {object} response.Response{msg=string} "只能分享自己的菜品或种类"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/shares [post]
+func (c *ShareController) CreateShare(ctx *gin.Context) {
+	var req domain.CreateShareRequest
+	if err := domain.BindJson(ctx, &req); err != nil {
+		response.BadRequest(ctx, err.Error())
+		return
+	}
+	req.UserID = userIDFromContext(ctx)
+
+	result, err := c.service.Create(ctx.Request.Context(), req)
+	if err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "创建成功", result)
+}
+
+// ListShares 获取分享链接列表
+// @Summary 获取分享链接列表
+// @Description 获取当前用户创建的分享链接及访问次数
+// @Tags 分享
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Success 200 {object} response.Response{data=[]domain.Share} "获取成功"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/shares [get]
+func (c *ShareController) ListShares(ctx *gin.Context) {
+	result, err := c.service.List(ctx.Request.Context(), userIDFromContext(ctx))
+	if err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.Success(ctx, result)
+}
+
+// RevokeShare 吊销分享链接
+// @Summary 吊销分享链接
+// @Description 吊销自己的分享链接，立即失效
+// @Tags 分享
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path int true "分享ID"
+// @Success 200 {object} response.Response "吊销成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 404 {object} response.Response{msg=string} "分享不存在"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/shares/{id} [delete]
+func (c *ShareController) RevokeShare(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "分享ID格式错误")
+		return
+	}
+
+	if err := c.service.Revoke(ctx.Request.Context(), userIDFromContext(ctx), id); err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "吊销成功", nil)
+}
+
+// ViewShare 查看分享内容
+// @Summary 查看分享内容
+// @Description 公开接口，无需登录。返回分享的菜品及其种类信息，或整个种类下的菜品，不包含所有者ID。每次访问计数加一
+// @Tags 分享
+// @Accept json
+// @Produce json
+// @Param token path string true "分享令牌"
+// @Success 200 {object} response.Response{data=domain.SharedContent} "获取成功"
+// @Failure 404 {object} response.Response{msg=string} "分享不存在或已失效"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /s/{token} [get]
+func (c *ShareController) ViewShare(ctx *gin.Context) {
+	result, err := c.service.View(ctx.Request.Context(), ctx.Param("token"))
+	if err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.Success(ctx, result)
+}
+
+// errorResponse 分享接口的错误响应
+func (c *ShareController) errorResponse(ctx *gin.Context, err error) {
+	switch err {
+	case domain.ErrShareNotFound:
+		response.NotFound(ctx, err.Error())
+	case domain.ErrShareTargetNotOwned:
+		response.Forbidden(ctx, err.Error())
+	case domain.ErrDishesNotFound:
+		response.DishNotFound(ctx)
+	case domain.ErrDishTypeNotFound:
+		response.DishTypeNotFound(ctx)
+	case domain.ErrShareExpiryInvalid, domain.ErrShareLimitExceeded:
+		response.BadRequest(ctx, err.Error())
+	default:
+		response.AppErrorResponse(ctx, err)
+	}
+}
@@ -0,0 +1,238 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/response"
+	"loverrecipe/internal/services/tags"
+)
+
+type TagController struct {
+	service tags.Service
+}
+
+func NewTagController(service tags.Service) *TagController {
+	return &TagController{
+		service: service,
+	}
+}
+
+// CreateTag 创建标签
+// @Summary 创建标签
+// @Description 创建当前用户的自定义标签，同一用户下名称不能重复
+// @Tags 标签管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param tag body domain.CreateTagRequest true "标签信息"
+// @Success 200 {object} response.Response{data=domain.Tag} "创建成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/tags [post]
+func (c *TagController) CreateTag(ctx *gin.Context) {
+	var req domain.CreateTagRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "请求参数错误: "+err.Error())
+		return
+	}
+
+	req.UserID = userIDFromContext(ctx)
+
+	tag, err := c.service.CreateTag(ctx.Request.Context(), req)
+	if err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "创建成功", tag)
+}
+
+// ListTags 获取标签列表
+// @Summary 获取标签列表
+// @Description 获取当前用户的全部标签及每个标签下的菜品数
+// @Tags 标签管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Success 200 {object} response.Response{data=[]domain.Tag} "获取成功"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/tags [get]
+func (c *TagController) ListTags(ctx *gin.Context) {
+	result, err := c.service.ListTags(ctx.Request.Context(), userIDFromContext(ctx))
+	if err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.Success(ctx, result)
+}
+
+// UpdateTag 更新标签
+// @Summary 更新标签
+// @Description 修改标签名称或颜色
+// @Tags 标签管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path int true "标签ID"
+// @Param tag body domain.UpdateTagRequest true "标签信息"
+// @Success 200 {object} response.Response{data=domain.Tag} "更新成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 403 {object} response.Response{msg=string} "无权限"
+// @Failure 404 {object} response.Response{msg=string} "标签不存在"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/tags/{id} [put]
+func (c *TagController) UpdateTag(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的标签ID")
+		return
+	}
+
+	var req domain.UpdateTagRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "请求参数错误: "+err.Error())
+		return
+	}
+
+	req.ID = id
+	req.UserID = userIDFromContext(ctx)
+
+	tag, err := c.service.UpdateTag(ctx.Request.Context(), req)
+	if err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "更新成功", tag)
+}
+
+// DeleteTag 删除标签
+// @Summary 删除标签
+// @Description 删除标签，同时解除它与所有菜品的关联，菜品本身不受影响
+// @Tags 标签管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path int true "标签ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 403 {object} response.Response{msg=string} "无权限"
+// @Failure 404 {object} response.Response{msg=string} "标签不存在"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/tags/{id} [delete]
+func (c *TagController) DeleteTag(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的标签ID")
+		return
+	}
+
+	if err := c.service.DeleteTag(ctx.Request.Context(), id, userIDFromContext(ctx)); err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "删除成功", nil)
+}
+
+// AttachDishes 为标签关联菜品
+// @Summary 为标签关联菜品
+// @Description 为标签批量关联菜品，已关联的菜品会被忽略
+// @Tags 标签管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path int true "标签ID"
+// @Param dishes body domain.TagDishesRequest true "菜品ID列表"
+// @Success 200 {object} response.Response{msg=string} "关联成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 403 {object} response.Response{msg=string} "无权限"
+// @Failure 404 {object} response.Response{msg=string} "标签或菜品不存在"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/tags/{id}/dishes [post]
+func (c *TagController) AttachDishes(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的标签ID")
+		return
+	}
+
+	var req domain.TagDishesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "请求参数错误: "+err.Error())
+		return
+	}
+
+	err = c.service.AttachDishes(ctx.Request.Context(), userIDFromContext(ctx), id, req.DishIDs)
+	if err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "关联成功", nil)
+}
+
+// DetachDish 取消标签与菜品的关联
+// @Summary 取消标签与菜品的关联
+// @Description 从标签中移除指定菜品
+// @Tags 标签管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path int true "标签ID"
+// @Param dishId path int true "菜品ID"
+// @Success 200 {object} response.Response{msg=string} "取消关联成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 403 {object} response.Response{msg=string} "无权限"
+// @Failure 404 {object} response.Response{msg=string} "标签不存在"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/tags/{id}/dishes/{dishId} [delete]
+func (c *TagController) DetachDish(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的标签ID")
+		return
+	}
+	dishID, err := strconv.ParseInt(ctx.Param("dishId"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的菜品ID")
+		return
+	}
+
+	err = c.service.DetachDish(ctx.Request.Context(), userIDFromContext(ctx), id, dishID)
+	if err != nil {
+		c.errorResponse(ctx, err)
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "取消关联成功", nil)
+}
+
+// errorResponse 标签接口的错误响应
+func (c *TagController) errorResponse(ctx *gin.Context, err error) {
+	switch err {
+	case domain.ErrTagNotFound:
+		response.NotFound(ctx, err.Error())
+	case domain.ErrTagUserMismatch:
+		response.Forbidden(ctx, err.Error())
+	case domain.ErrDishesNotFound:
+		response.DishNotFound(ctx)
+	case domain.ErrDishesUserMismatch:
+		response.DishUserMismatch(ctx)
+	case domain.ErrTagNameEmpty, domain.ErrTagNameTooLong, domain.ErrTagNameExists,
+		domain.ErrTagDishesEmpty, domain.ErrTagDishesTooMany:
+		response.BadRequest(ctx, err.Error())
+	default:
+		response.AppErrorResponse(ctx, err)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"loverrecipe/internal/domain"
 	"loverrecipe/internal/response"
 	"loverrecipe/internal/services/user"
+	"math"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gotomicro/ego/core/elog"
@@ -19,11 +21,12 @@ func NewUserController(s user.Service) *UserController {
 
 // Register 用户注册接口
 // @Summary 用户注册
-// @Description 处理用户注册请求，验证输入参数并创建新用户
+// @Description 处理用户注册请求，验证输入参数并创建新用户。用户名去除首尾空白、NFKC 规范化并折叠大小写后保存，系统保留名不可注册，已被注册时返回 1002。新用户的状态总是正常
 // @Tags 用户管理
 // @Accept json
 // @Produce json
 // @Param user body domain.CreateUserInput true "用户注册信息"
+// @Param Idempotency-Key header string false "幂等键，超时重试时携带相同的值不会重复注册"
 // @Success 200 {object} response.Response{data=domain.CreateUserOutput} "注册成功"
 // @Failure 400 {object} response.Response{msg=string} "请求参数错误"
 // @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
@@ -43,6 +46,14 @@ func (uc *UserController) Register(ctx *gin.Context) {
 
 	// 调用服务层创建用户
 	data, err := uc.Service.Create(ctx.Request.Context(), params)
+	if err == domain.ErrUserAlreadyExists {
+		response.ErrorWithMsg(ctx, response.CodeUserAlreadyExists, err.Error())
+		return
+	}
+	if err == domain.ErrUsernameInvalid || err == domain.ErrUsernameReserved {
+		response.BadRequest(ctx, err.Error())
+		return
+	}
 	if err != nil {
 		// 创建用户失败，返回 500 错误
 		response.InternalServerError(ctx, err.Error())
@@ -54,3 +65,414 @@ func (uc *UserController) Register(ctx *gin.Context) {
 	// 注册成功，返回用户信息
 	response.Success(ctx, data)
 }
+
+// Login 用户登录接口
+// @Summary 用户登录
+// @Description 使用用户名与密码登录。用户名不存在与密码错误返回相同的错误；同一用户名或IP连续失败达到阈值后锁定，锁定时间随失败次数指数增长，锁定期间返回 429 并设置 Retry-After 响应头；密码正确但账号已禁用时返回 1007
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param user body domain.LoginReq true "登录信息"
+// @Success 200 {object} response.Response{data=domain.LoginOutput} "登录成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/user/login [post]
+func (uc *UserController) Login(ctx *gin.Context) {
+	params := &domain.LoginReq{}
+	if err := domain.BindJson(ctx, params); err != nil {
+		response.BadRequest(ctx, err.Error())
+		elog.Error("bind json error", elog.String("error", err.Error()))
+		return
+	}
+	params.IP = ctx.ClientIP()
+
+	data, err := uc.Service.Login(ctx.Request.Context(), *params)
+	switch err {
+	case nil:
+		response.SuccessWithMsg(ctx, "登录成功", data)
+	case domain.ErrLoginLocked:
+		ctx.Header("Retry-After", strconv.FormatInt(int64(math.Ceil(data.RetryAfter.Seconds())), 10))
+		response.ErrorWithMsg(ctx, response.CodeTooManyRequests, err.Error())
+	case domain.ErrInvalidCredentials:
+		response.ErrorWithMsg(ctx, response.CodeInvalidCredentials, err.Error())
+	case domain.ErrUserDisabled:
+		response.UserDisabled(ctx)
+	default:
+		response.InternalServerError(ctx, err.Error())
+		elog.Error("login error", elog.String("error", err.Error()))
+	}
+}
+
+// RefreshToken 刷新令牌接口
+// @Summary 刷新令牌
+// @Description 用刷新 Token 换取新的主 Token 与刷新 Token。修改密码或注销账号后，之前签发的刷新 Token 失效；账号已禁用时返回 1007
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param body body domain.RefreshTokenRequest true "刷新 Token"
+// @Success 200 {object} response.Response{data=domain.LoginOutput} "刷新成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/user/refresh [post]
+func (uc *UserController) RefreshToken(ctx *gin.Context) {
+	params := &domain.RefreshTokenRequest{}
+	if err := domain.BindJson(ctx, params); err != nil {
+		response.BadRequest(ctx, err.Error())
+		elog.Error("bind json error", elog.String("error", err.Error()))
+		return
+	}
+
+	data, err := uc.Service.RefreshToken(ctx.Request.Context(), params.RefreshToken)
+	if err != nil {
+		if err == domain.ErrRefreshTokenInvalid {
+			response.ErrorWithMsg(ctx, response.CodeTokenInvalid, err.Error())
+			return
+		}
+		if err == domain.ErrUserDisabled {
+			response.UserDisabled(ctx)
+			return
+		}
+		response.InternalServerError(ctx, err.Error())
+		elog.Error("refresh token error", elog.String("error", err.Error()))
+		return
+	}
+
+	response.Success(ctx, data)
+}
+
+// GetProfile 获取个人资料接口
+// @Summary 获取个人资料
+// @Description 获取当前登录用户的资料
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Success 200 {object} response.Response{data=domain.User} "获取成功"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/user/me [get]
+func (uc *UserController) GetProfile(ctx *gin.Context) {
+	u, err := uc.Service.GetProfile(ctx.Request.Context(), userIDFromContext(ctx))
+	if err != nil {
+		uc.handleProfileError(ctx, err, "get profile error")
+		return
+	}
+
+	response.Success(ctx, u)
+}
+
+// UpdateProfile 更新个人资料接口
+// @Summary 更新个人资料
+// @Description 更新当前用户的显示名称与头像，未传的字段保持不变
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param profile body domain.UpdateProfileRequest true "个人资料"
+// @Success 200 {object} response.Response{data=domain.User} "更新成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/user/me [put]
+func (uc *UserController) UpdateProfile(ctx *gin.Context) {
+	params := &domain.UpdateProfileRequest{}
+	if err := domain.BindJson(ctx, params); err != nil {
+		response.BadRequest(ctx, err.Error())
+		elog.Error("bind json error", elog.String("error", err.Error()))
+		return
+	}
+	params.UserID = userIDFromContext(ctx)
+
+	u, err := uc.Service.UpdateProfile(ctx.Request.Context(), *params)
+	if err != nil {
+		uc.handleProfileError(ctx, err, "update profile error")
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "更新成功", u)
+}
+
+// ChangePassword 修改密码接口
+// @Summary 修改密码
+// @Description 校验原密码后修改密码。之前签发的刷新 Token 全部失效，响应中返回新的一组 Token
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param body body domain.ChangePasswordRequest true "原密码与新密码"
+// @Success 200 {object} response.Response{data=domain.LoginOutput} "修改成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/user/me/password [put]
+func (uc *UserController) ChangePassword(ctx *gin.Context) {
+	params := &domain.ChangePasswordRequest{}
+	if err := domain.BindJson(ctx, params); err != nil {
+		response.BadRequest(ctx, err.Error())
+		elog.Error("bind json error", elog.String("error", err.Error()))
+		return
+	}
+	params.UserID = userIDFromContext(ctx)
+
+	data, err := uc.Service.ChangePassword(ctx.Request.Context(), *params)
+	if err != nil {
+		uc.handleProfileError(ctx, err, "change password error")
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "修改成功", data)
+}
+
+// DeleteAccount 注销账号接口
+// @Summary 注销账号
+// @Description 校验密码后注销当前账号，同时删除该用户的菜品、种类、标签、烹饪记录与营养数据，不可恢复
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param body body domain.DeleteAccountRequest true "密码确认"
+// @Success 200 {object} response.Response "注销成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/user/me [delete]
+func (uc *UserController) DeleteAccount(ctx *gin.Context) {
+	params := &domain.DeleteAccountRequest{}
+	if err := domain.BindJson(ctx, params); err != nil {
+		response.BadRequest(ctx, err.Error())
+		elog.Error("bind json error", elog.String("error", err.Error()))
+		return
+	}
+	params.UserID = userIDFromContext(ctx)
+
+	if err := uc.Service.DeleteAccount(ctx.Request.Context(), *params); err != nil {
+		uc.handleProfileError(ctx, err, "delete account error")
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "注销成功", nil)
+}
+
+// Logout 退出登录接口
+// @Summary 退出登录
+// @Description 吊销当前用户在所有设备上签发的主令牌与刷新令牌
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Success 200 {object} response.Response "退出成功"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/user/logout [post]
+func (uc *UserController) Logout(ctx *gin.Context) {
+	if err := uc.Service.Logout(ctx.Request.Context(), userIDFromContext(ctx)); err != nil {
+		uc.handleProfileError(ctx, err, "logout error")
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "退出成功", nil)
+}
+
+// UpdateEmail 设置邮箱接口
+// @Summary 设置邮箱
+// @Description 校验密码后设置邮箱并发送验证邮件，邮箱为空表示解除绑定。邮箱去除首尾空白并转为小写后作为待验证的邮箱保存，验证后才替换当前邮箱。不提示邮箱是否已被其他账号使用
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param body body domain.UpdateEmailRequest true "邮箱与密码确认"
+// @Success 200 {object} response.Response "设置成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/user/me/email [put]
+func (uc *UserController) UpdateEmail(ctx *gin.Context) {
+	params := &domain.UpdateEmailRequest{}
+	if err := domain.BindJson(ctx, params); err != nil {
+		response.BadRequest(ctx, err.Error())
+		elog.Error("bind json error", elog.String("error", err.Error()))
+		return
+	}
+	params.UserID = userIDFromContext(ctx)
+
+	if err := uc.Service.UpdateEmail(ctx.Request.Context(), *params); err != nil {
+		uc.handleProfileError(ctx, err, "update email error")
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "设置成功", nil)
+}
+
+// ResendVerification 重新发送验证邮件接口
+// @Summary 重新发送验证邮件
+// @Description 向当前用户待验证的邮箱重新发送验证邮件，同一用户每分钟最多一次
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Success 200 {object} response.Response "发送成功"
+// @Failure 400 {object} response.Response{msg=string} "未设置邮箱或邮箱已验证"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/user/me/email/verification [post]
+func (uc *UserController) ResendVerification(ctx *gin.Context) {
+	if err := uc.Service.ResendVerification(ctx.Request.Context(), userIDFromContext(ctx)); err != nil {
+		uc.handleProfileError(ctx, err, "resend verification error")
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "发送成功", nil)
+}
+
+// VerifyEmail 验证邮箱接口
+// @Summary 验证邮箱
+// @Description 使用验证邮件中的令牌验证邮箱，令牌只能使用一次。验证后待验证的邮箱替换当前邮箱，已被其他账号验证时返回 409
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param body body domain.VerifyEmailRequest true "验证令牌"
+// @Success 200 {object} response.Response "验证成功"
+// @Failure 400 {object} response.Response{msg=string} "链接无效或已过期"
+// @Failure 409 {object} response.Response{msg=string} "邮箱已被其他账号使用"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/user/email/verify [post]
+func (uc *UserController) VerifyEmail(ctx *gin.Context) {
+	params := &domain.VerifyEmailRequest{}
+	if err := domain.BindJson(ctx, params); err != nil {
+		response.BadRequest(ctx, err.Error())
+		elog.Error("bind json error", elog.String("error", err.Error()))
+		return
+	}
+
+	if err := uc.Service.VerifyEmail(ctx.Request.Context(), params.Token); err != nil {
+		uc.handleProfileError(ctx, err, "verify email error")
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "验证成功", nil)
+}
+
+// ForgotPassword 忘记密码接口
+// @Summary 忘记密码
+// @Description 向已验证的邮箱发送重置密码邮件。无论邮箱是否注册都返回成功，不泄露邮箱是否存在
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param body body domain.ForgotPasswordRequest true "邮箱"
+// @Success 200 {object} response.Response "如果邮箱已验证，将收到重置密码邮件"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/user/password/forgot [post]
+func (uc *UserController) ForgotPassword(ctx *gin.Context) {
+	params := &domain.ForgotPasswordRequest{}
+	if err := domain.BindJson(ctx, params); err != nil {
+		response.BadRequest(ctx, err.Error())
+		elog.Error("bind json error", elog.String("error", err.Error()))
+		return
+	}
+
+	if err := uc.Service.ForgotPassword(ctx.Request.Context(), params.Email); err != nil {
+		uc.handleProfileError(ctx, err, "forgot password error")
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "如果邮箱已验证，将收到重置密码邮件", nil)
+}
+
+// ResetPassword 重置密码接口
+// @Summary 重置密码
+// @Description 使用重置密码邮件中的令牌设置新密码，令牌只能使用一次。重置后之前签发的登录令牌全部失效，并解除该用户名的登录锁定
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param body body domain.ResetPasswordRequest true "重置令牌与新密码"
+// @Success 200 {object} response.Response "重置成功"
+// @Failure 400 {object} response.Response{msg=string} "链接无效或已过期"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/user/password/reset [post]
+func (uc *UserController) ResetPassword(ctx *gin.Context) {
+	params := &domain.ResetPasswordRequest{}
+	if err := domain.BindJson(ctx, params); err != nil {
+		response.BadRequest(ctx, err.Error())
+		elog.Error("bind json error", elog.String("error", err.Error()))
+		return
+	}
+
+	if err := uc.Service.ResetPassword(ctx.Request.Context(), *params); err != nil {
+		uc.handleProfileError(ctx, err, "reset password error")
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "重置成功", nil)
+}
+
+// handleProfileError 将个人资料相关的业务错误映射为响应
+func (uc *UserController) handleProfileError(ctx *gin.Context, err error, logMsg string) {
+	switch err {
+	case domain.ErrUserNotFound:
+		response.UserNotFound(ctx)
+	case domain.ErrPasswordIncorrect:
+		response.ErrorWithMsg(ctx, response.CodeInvalidCredentials, err.Error())
+	case domain.ErrPasswordUnchanged, domain.ErrEmailInvalid, domain.ErrEmailNotSet,
+		domain.ErrEmailAlreadyVerified, domain.ErrEmailTokenInvalid:
+		response.BadRequest(ctx, err.Error())
+	case domain.ErrEmailAlreadyUsed:
+		response.ErrorWithMsg(ctx, response.CodeConflict, err.Error())
+	case domain.ErrEmailSendTooFrequent:
+		response.ErrorWithMsg(ctx, response.CodeTooManyRequests, err.Error())
+	default:
+		response.InternalServerError(ctx, err.Error())
+		elog.Error(logMsg, elog.String("error", err.Error()))
+	}
+}
+
+// GetDietaryProfile 获取饮食档案接口
+// @Summary 获取饮食档案
+// @Description 获取当前用户登记的过敏原与饮食要求
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Success 200 {object} response.Response{data=domain.DietaryProfile} "获取成功"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/user/dietary-profile [get]
+func (uc *UserController) GetDietaryProfile(ctx *gin.Context) {
+	profile, err := uc.Service.GetDietaryProfile(ctx.Request.Context(), userIDFromContext(ctx))
+	if err != nil {
+		response.InternalServerError(ctx, err.Error())
+		elog.Error("get dietary profile error", elog.String("error", err.Error()))
+		return
+	}
+
+	response.Success(ctx, profile)
+}
+
+// UpdateDietaryProfile 更新饮食档案接口
+// @Summary 更新饮食档案
+// @Description 整体覆盖当前用户的过敏原与饮食要求。过敏原可选 peanut、tree_nut、milk、egg、fish、shellfish、gluten、soy、sesame、meat、pork、alcohol、honey；饮食要求可选 vegetarian、vegan、pescatarian、halal、gluten_free
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param profile body domain.UpdateDietaryProfileRequest true "饮食档案"
+// @Success 200 {object} response.Response{data=domain.DietaryProfile} "更新成功"
+// @Failure 400 {object} response.Response{msg=string} "请求参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器内部错误"
+// @Router /api/v1/user/dietary-profile [put]
+func (uc *UserController) UpdateDietaryProfile(ctx *gin.Context) {
+	params := &domain.UpdateDietaryProfileRequest{}
+	if err := domain.BindJson(ctx, params); err != nil {
+		response.BadRequest(ctx, err.Error())
+		elog.Error("bind json error", elog.String("error", err.Error()))
+		return
+	}
+	params.UserID = userIDFromContext(ctx)
+
+	profile, err := uc.Service.UpdateDietaryProfile(ctx.Request.Context(), *params)
+	if err != nil {
+		if err == domain.ErrAllergenUnknown || err == domain.ErrDietUnknown || err == domain.ErrAllergensTooMany {
+			response.BadRequest(ctx, err.Error())
+			return
+		}
+		response.InternalServerError(ctx, err.Error())
+		elog.Error("update dietary profile error", elog.String("error", err.Error()))
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "更新成功", profile)
+}
@@ -0,0 +1,68 @@
+package domain
+
+import "errors"
+
+// APITokenPrefix 个人访问令牌的前缀，鉴权中间件据此区分访问令牌与 JWT
+const APITokenPrefix = "lrp_"
+
+// 访问令牌的权限范围，覆盖菜品、种类、标签与烹饪记录接口
+const (
+	ScopeDishesRead  = "dishes:read"
+	ScopeDishesWrite = "dishes:write"
+)
+
+// APITokenScopes 可授予的全部权限范围
+var APITokenScopes = []string{ScopeDishesRead, ScopeDishesWrite}
+
+// MaxAPITokensPerUser 每个用户最多持有的访问令牌数量
+const MaxAPITokensPerUser = 20
+
+// APIToken 个人访问令牌，供脚本与家庭设备调用接口。库中只保存令牌的哈希
+type APIToken struct {
+	ID         int64    `json:"id"`
+	UserID     int64    `json:"-"`
+	Name       string   `json:"name"`
+	Hint       string   `json:"hint"` // 令牌开头的几位，便于用户辨认
+	Scopes     []string `json:"scopes"`
+	ExpiresAt  int64    `json:"expires_at"`   // 过期时间，0 表示永不过期
+	LastUsedAt int64    `json:"last_used_at"` // 最近使用时间，按分钟更新
+	Ctime      int64    `json:"ctime"`
+}
+
+// Expired 判断令牌在 now 时刻是否已过期
+func (t APIToken) Expired(now int64) bool {
+	return t.ExpiresAt > 0 && now >= t.ExpiresAt
+}
+
+// HasScope 判断令牌是否具有指定权限
+func (t APIToken) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateAPITokenRequest 创建访问令牌请求
+type CreateAPITokenRequest struct {
+	UserID    int64    `json:"-"`
+	Name      string   `json:"name" validate:"required,max=50"`
+	Scopes    []string `json:"scopes" validate:"required,min=1"`
+	ExpiresAt int64    `json:"expires_at"` // 过期时间（Unix 秒），不填表示永不过期
+}
+
+// CreateAPITokenResponse 创建访问令牌响应，明文令牌只在创建时返回一次
+type CreateAPITokenResponse struct {
+	APIToken
+	Token string `json:"token"`
+}
+
+var (
+	ErrAPITokenNotFound      = errors.New("访问令牌不存在")
+	ErrAPITokenScopeInvalid  = errors.New("访问令牌权限范围无效")
+	ErrAPITokenExpiryInvalid = errors.New("过期时间必须晚于当前时间")
+	ErrAPITokenLimitExceeded = errors.New("访问令牌数量已达上限")
+	// ErrAPITokenInvalid 访问令牌不存在、已吊销或已过期
+	ErrAPITokenInvalid = errors.New("访问令牌无效")
+)
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+)
+
+// AuditAction 审计动作
+type AuditAction string
+
+const (
+	AuditLogin          AuditAction = "login"
+	AuditLoginFailed    AuditAction = "login_failed"
+	AuditLogout         AuditAction = "logout"
+	AuditPasswordChange AuditAction = "password_change"
+	AuditAccountDelete  AuditAction = "account_delete"
+	AuditRoleChange     AuditAction = "role_change"
+	AuditStatusChange   AuditAction = "status_change"
+	AuditForceLogout    AuditAction = "force_logout"
+	AuditEmailChange    AuditAction = "email_change"
+	AuditEmailVerify    AuditAction = "email_verify"
+	AuditPasswordReset  AuditAction = "password_reset"
+	AuditDishCreate     AuditAction = "dish_create"
+	AuditDishUpdate     AuditAction = "dish_update"
+	AuditDishDelete     AuditAction = "dish_delete"
+	AuditDishMerge      AuditAction = "dish_merge"
+	AuditAPITokenCreate AuditAction = "api_token_create"
+	AuditAPITokenRevoke AuditAction = "api_token_revoke"
+	AuditShareCreate    AuditAction = "share_create"
+	AuditShareRevoke    AuditAction = "share_revoke"
+)
+
+// 审计对象类型
+const (
+	AuditTargetUser     = "user"
+	AuditTargetDishes   = "dishes"
+	AuditTargetAPIToken = "api_token"
+	AuditTargetShare    = "share"
+)
+
+// AuditLog 一条审计记录，只追加不修改
+type AuditLog struct {
+	ID         int64           `json:"id"`
+	ActorID    int64           `json:"actor_id"` // 操作人，登录失败时为0
+	Action     AuditAction     `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetID   string          `json:"target_id"`
+	Diff       json.RawMessage `json:"diff,omitempty" swaggertype:"object"` // 字段名到 {before, after} 的变更
+	IP         string          `json:"ip"`
+	UserAgent  string          `json:"user_agent"`
+	TraceID    string          `json:"trace_id"`
+	Ctime      int64           `json:"ctime"`
+}
+
+// AuditLogQuery 审计记录查询条件，零值表示不过滤
+type AuditLogQuery struct {
+	ActorID    int64       `json:"actor_id"`
+	Action     AuditAction `json:"action"`
+	TargetType string      `json:"target_type"`
+	TargetID   string      `json:"target_id"`
+	From       int64       `json:"from"` // 创建时间下限（含）
+	To         int64       `json:"to"`   // 创建时间上限（不含）
+	Offset     int         `json:"offset"`
+	Limit      int         `json:"limit"`
+}
+
+// AuditLogListResponse 审计记录列表响应
+type AuditLogListResponse struct {
+	List  []AuditLog `json:"list"`
+	Total int64      `json:"total"`
+	Page  int        `json:"page"`
+	Size  int        `json:"size"`
+}
+
+// RequestMeta 请求来源信息，由中间件放入请求上下文，供审计记录使用
+type RequestMeta struct {
+	IP        string
+	UserAgent string
+	TraceID   string
+}
+
+type requestMetaKey struct{}
+
+// WithRequestMeta 在上下文中保存请求来源信息
+func WithRequestMeta(ctx context.Context, meta RequestMeta) context.Context {
+	return context.WithValue(ctx, requestMetaKey{}, meta)
+}
+
+// RequestMetaFrom 读取上下文中的请求来源信息，不存在时返回零值
+func RequestMetaFrom(ctx context.Context) RequestMeta {
+	meta, _ := ctx.Value(requestMetaKey{}).(RequestMeta)
+	return meta
+}
+
+// auditChange 一个字段的变更
+type auditChange struct {
+	Before any `json:"before,omitempty"`
+	After  any `json:"after,omitempty"`
+}
+
+// NewAuditDiff 比较两个对象的 JSON 字段，只保留发生变化的字段。
+// before 为 nil 表示新建，after 为 nil 表示删除；对象无法序列化时返回 nil
+func NewAuditDiff(before any, after any) json.RawMessage {
+	beforeFields, ok := auditFields(before)
+	if !ok {
+		return nil
+	}
+	afterFields, ok := auditFields(after)
+	if !ok {
+		return nil
+	}
+
+	changes := make(map[string]auditChange)
+	for key, b := range beforeFields {
+		a, exists := afterFields[key]
+		if !exists || !reflect.DeepEqual(a, b) {
+			changes[key] = auditChange{Before: b, After: a}
+		}
+	}
+	for key, a := range afterFields {
+		if _, exists := beforeFields[key]; !exists {
+			changes[key] = auditChange{After: a}
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// auditFields 将对象转换为字段表，nil 返回空表
+func auditFields(v any) (map[string]any, bool) {
+	fields := make(map[string]any)
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil()) {
+		return fields, true
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, false
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, false
+	}
+	return fields, true
+}
@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// CookingLog 烹饪记录，记录某道菜实际被做的时间与情况
+type CookingLog struct {
+	ID       int64  `json:"id"`
+	UserID   int64  `json:"user_id"`
+	DishID   int64  `json:"dish_id"`
+	CookedAt int64  `json:"cooked_at"`
+	CookedBy string `json:"cooked_by"`
+	Servings int64  `json:"servings"`
+	Notes    string `json:"notes"`
+	Photo    string `json:"photo"`
+	Ctime    int64  `json:"ctime"`
+	Utime    int64  `json:"utime"`
+}
+
+// CreateCookingLogRequest 创建烹饪记录请求
+type CreateCookingLogRequest struct {
+	UserID   int64  `json:"-"`
+	DishID   int64  `json:"dish_id" validate:"required"`
+	CookedAt int64  `json:"cooked_at"` // 为空时取当前时间
+	CookedBy string `json:"cooked_by" validate:"max=50"`
+	Servings int64  `json:"servings"` // 为空时按1份计算
+	Notes    string `json:"notes" validate:"max=500"`
+	Photo    string `json:"photo" validate:"max=200"`
+}
+
+// UpdateCookingLogRequest 更新烹饪记录请求
+type UpdateCookingLogRequest struct {
+	ID       int64  `json:"-"`
+	UserID   int64  `json:"-"`
+	DishID   int64  `json:"dish_id" validate:"required"`
+	CookedAt int64  `json:"cooked_at" validate:"required"`
+	CookedBy string `json:"cooked_by" validate:"max=50"`
+	Servings int64  `json:"servings" validate:"min=1"`
+	Notes    string `json:"notes" validate:"max=500"`
+	Photo    string `json:"photo" validate:"max=200"`
+}
+
+// CookingLogQuery 烹饪记录查询条件
+type CookingLogQuery struct {
+	UserID int64 `json:"user_id"`
+	DishID int64 `json:"dish_id"`
+	From   int64 `json:"from"` // 烹饪时间下限（含）
+	To     int64 `json:"to"`   // 烹饪时间上限（不含）
+	Offset int   `json:"offset"`
+	Limit  int   `json:"limit"`
+}
+
+// CookingLogListResponse 烹饪记录列表响应
+type CookingLogListResponse struct {
+	List  []CookingLog `json:"list"`
+	Total int64        `json:"total"`
+	Page  int          `json:"page"`
+	Size  int          `json:"size"`
+}
+
+// 烹饪记录字段限制
+const (
+	MaxCookingLogServings = 100
+	maxCookingLogFuture   = 5 * time.Minute // 允许客户端时钟比服务器快的上限
+)
+
+// 错误定义
+var (
+	ErrCookingLogNotFound        = errors.New("烹饪记录不存在")
+	ErrCookingLogUserMismatch    = errors.New("烹饪记录不属于该用户")
+	ErrCookingLogServingsInvalid = errors.New("份数无效")
+	ErrCookingLogTimeInvalid     = errors.New("烹饪时间无效")
+)
+
+// NewCookingLog 创建新的烹饪记录实例
+func NewCookingLog(req CreateCookingLogRequest) (*CookingLog, error) {
+	now := time.Now()
+	if req.CookedAt == 0 {
+		req.CookedAt = now.Unix()
+	}
+	if req.Servings == 0 {
+		req.Servings = 1
+	}
+	if err := validateCookingLog(req.UserID, req.DishID, req.CookedAt, req.CookedBy, req.Servings, req.Notes, req.Photo); err != nil {
+		return nil, err
+	}
+
+	return &CookingLog{
+		UserID:   req.UserID,
+		DishID:   req.DishID,
+		CookedAt: req.CookedAt,
+		CookedBy: req.CookedBy,
+		Servings: req.Servings,
+		Notes:    req.Notes,
+		Photo:    req.Photo,
+		Ctime:    now.Unix(),
+		Utime:    now.Unix(),
+	}, nil
+}
+
+// Update 更新烹饪记录
+func (l *CookingLog) Update(req UpdateCookingLogRequest) error {
+	if l.UserID != req.UserID {
+		return ErrCookingLogUserMismatch
+	}
+	if err := validateCookingLog(req.UserID, req.DishID, req.CookedAt, req.CookedBy, req.Servings, req.Notes, req.Photo); err != nil {
+		return err
+	}
+
+	l.DishID = req.DishID
+	l.CookedAt = req.CookedAt
+	l.CookedBy = req.CookedBy
+	l.Servings = req.Servings
+	l.Notes = req.Notes
+	l.Photo = req.Photo
+	l.Utime = time.Now().Unix()
+	return nil
+}
+
+// CanDelete 检查是否可以删除
+func (l *CookingLog) CanDelete(userID int64) error {
+	if l.UserID != userID {
+		return ErrCookingLogUserMismatch
+	}
+	return nil
+}
+
+// validateCookingLog 验证烹饪记录字段
+func validateCookingLog(userID, dishID, cookedAt int64, cookedBy string, servings int64, notes, photo string) error {
+	if userID <= 0 {
+		return errors.New("用户ID无效")
+	}
+	if dishID <= 0 {
+		return ErrDishesNotFound
+	}
+	// 允许少量的时钟偏差，但不允许记录未来的烹饪
+	if cookedAt <= 0 || cookedAt > time.Now().Add(maxCookingLogFuture).Unix() {
+		return ErrCookingLogTimeInvalid
+	}
+	if len(cookedBy) > 50 {
+		return errors.New("烹饪人名称过长")
+	}
+	if servings <= 0 || servings > MaxCookingLogServings {
+		return ErrCookingLogServingsInvalid
+	}
+	if len(notes) > 500 {
+		return errors.New("备注过长")
+	}
+	if len(photo) > 200 {
+		return errors.New("图片URL过长")
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Allergen 过敏原或饮食禁忌成分，菜品用它声明自己含有的成分
+type Allergen string
+
+const (
+	AllergenPeanut    Allergen = "peanut"    // 花生
+	AllergenTreeNut   Allergen = "tree_nut"  // 坚果
+	AllergenMilk      Allergen = "milk"      // 奶制品
+	AllergenEgg       Allergen = "egg"       // 蛋
+	AllergenFish      Allergen = "fish"      // 鱼
+	AllergenShellfish Allergen = "shellfish" // 甲壳类与贝类
+	AllergenGluten    Allergen = "gluten"    // 麸质
+	AllergenSoy       Allergen = "soy"       // 大豆
+	AllergenSesame    Allergen = "sesame"    // 芝麻
+	AllergenMeat      Allergen = "meat"      // 畜禽肉
+	AllergenPork      Allergen = "pork"      // 猪肉
+	AllergenAlcohol   Allergen = "alcohol"   // 酒精
+	AllergenHoney     Allergen = "honey"     // 蜂蜜
+)
+
+// Diet 饮食习惯或宗教饮食要求
+type Diet string
+
+const (
+	DietVegetarian  Diet = "vegetarian"  // 素食（蛋奶素）
+	DietVegan       Diet = "vegan"       // 纯素
+	DietPescatarian Diet = "pescatarian" // 鱼素
+	DietHalal       Diet = "halal"       // 清真
+	DietGlutenFree  Diet = "gluten_free" // 无麸质
+)
+
+// knownAllergens 支持的成分
+var knownAllergens = map[Allergen]struct{}{
+	AllergenPeanut: {}, AllergenTreeNut: {}, AllergenMilk: {}, AllergenEgg: {},
+	AllergenFish: {}, AllergenShellfish: {}, AllergenGluten: {}, AllergenSoy: {},
+	AllergenSesame: {}, AllergenMeat: {}, AllergenPork: {}, AllergenAlcohol: {},
+	AllergenHoney: {},
+}
+
+// dietForbidden 每种饮食要求禁止的成分
+var dietForbidden = map[Diet][]Allergen{
+	DietVegetarian:  {AllergenMeat, AllergenPork, AllergenFish, AllergenShellfish},
+	DietVegan:       {AllergenMeat, AllergenPork, AllergenFish, AllergenShellfish, AllergenMilk, AllergenEgg, AllergenHoney},
+	DietPescatarian: {AllergenMeat, AllergenPork},
+	DietHalal:       {AllergenPork, AllergenAlcohol},
+	DietGlutenFree:  {AllergenGluten},
+}
+
+// 饮食档案限制
+const MaxDishesAllergens = 20
+
+// 错误定义
+var (
+	ErrAllergenUnknown  = errors.New("不支持的过敏原")
+	ErrDietUnknown      = errors.New("不支持的饮食要求")
+	ErrAllergensTooMany = errors.New("过敏原数量过多")
+)
+
+// DietaryProfile 用户的过敏原与饮食要求
+type DietaryProfile struct {
+	Allergens []Allergen `json:"allergens"`
+	Diets     []Diet     `json:"diets"`
+}
+
+// UpdateDietaryProfileRequest 更新饮食档案请求
+type UpdateDietaryProfileRequest struct {
+	UserID    int64      `json:"-"`
+	Allergens []Allergen `json:"allergens"`
+	Diets     []Diet     `json:"diets"`
+}
+
+// DietaryConflict 菜品与饮食档案的一处冲突
+type DietaryConflict struct {
+	Allergen Allergen `json:"allergen"`
+	// Source 冲突来源，过敏原冲突为 allergen，饮食要求冲突为对应的饮食名称
+	Source string `json:"source"`
+}
+
+// DietaryConflictSourceAllergen 过敏原冲突的来源标识
+const DietaryConflictSourceAllergen = "allergen"
+
+// NewDietaryProfile 校验并规范化饮食档案
+func NewDietaryProfile(req UpdateDietaryProfileRequest) (DietaryProfile, error) {
+	allergens, err := NormalizeAllergens(req.Allergens)
+	if err != nil {
+		return DietaryProfile{}, err
+	}
+	diets, err := NormalizeDiets(req.Diets)
+	if err != nil {
+		return DietaryProfile{}, err
+	}
+	return DietaryProfile{Allergens: allergens, Diets: diets}, nil
+}
+
+// IsEmpty 档案中没有任何限制
+func (p DietaryProfile) IsEmpty() bool {
+	return len(p.Allergens) == 0 && len(p.Diets) == 0
+}
+
+// ForbiddenAllergens 返回档案禁止的全部成分，按名称排序
+func (p DietaryProfile) ForbiddenAllergens() []Allergen {
+	set := make(map[Allergen]struct{})
+	for _, allergen := range p.Allergens {
+		set[allergen] = struct{}{}
+	}
+	for _, diet := range p.Diets {
+		for _, allergen := range dietForbidden[diet] {
+			set[allergen] = struct{}{}
+		}
+	}
+
+	result := make([]Allergen, 0, len(set))
+	for allergen := range set {
+		result = append(result, allergen)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// CheckDietaryConflicts 检查菜品含有的成分与饮食档案的冲突。
+// 同一成分同时触发过敏原与饮食要求时会分别列出，顺序为先过敏原、再按档案中饮食要求的顺序
+func CheckDietaryConflicts(profile DietaryProfile, contains []Allergen) []DietaryConflict {
+	if profile.IsEmpty() || len(contains) == 0 {
+		return nil
+	}
+
+	present := make(map[Allergen]struct{}, len(contains))
+	for _, allergen := range contains {
+		present[allergen] = struct{}{}
+	}
+
+	var conflicts []DietaryConflict
+	seen := make(map[DietaryConflict]struct{})
+	add := func(conflict DietaryConflict) {
+		if _, ok := seen[conflict]; ok {
+			return
+		}
+		seen[conflict] = struct{}{}
+		conflicts = append(conflicts, conflict)
+	}
+
+	for _, allergen := range profile.Allergens {
+		if _, ok := present[allergen]; ok {
+			add(DietaryConflict{Allergen: allergen, Source: DietaryConflictSourceAllergen})
+		}
+	}
+	for _, diet := range profile.Diets {
+		for _, allergen := range dietForbidden[diet] {
+			if _, ok := present[allergen]; ok {
+				add(DietaryConflict{Allergen: allergen, Source: string(diet)})
+			}
+		}
+	}
+	return conflicts
+}
+
+// NormalizeAllergens 校验成分列表，统一为小写并去重
+func NormalizeAllergens(values []Allergen) ([]Allergen, error) {
+	result := make([]Allergen, 0, len(values))
+	seen := make(map[Allergen]struct{}, len(values))
+	for _, value := range values {
+		allergen := Allergen(strings.ToLower(strings.TrimSpace(string(value))))
+		if allergen == "" {
+			continue
+		}
+		if _, ok := knownAllergens[allergen]; !ok {
+			return nil, ErrAllergenUnknown
+		}
+		if _, ok := seen[allergen]; ok {
+			continue
+		}
+		seen[allergen] = struct{}{}
+		result = append(result, allergen)
+	}
+	if len(result) > MaxDishesAllergens {
+		return nil, ErrAllergensTooMany
+	}
+	return result, nil
+}
+
+// NormalizeDiets 校验饮食要求列表，统一为小写并去重
+func NormalizeDiets(values []Diet) ([]Diet, error) {
+	result := make([]Diet, 0, len(values))
+	seen := make(map[Diet]struct{}, len(values))
+	for _, value := range values {
+		diet := Diet(strings.ToLower(strings.TrimSpace(string(value))))
+		if diet == "" {
+			continue
+		}
+		if _, ok := dietForbidden[diet]; !ok {
+			return nil, ErrDietUnknown
+		}
+		if _, ok := seen[diet]; ok {
+			continue
+		}
+		seen[diet] = struct{}{}
+		result = append(result, diet)
+	}
+	return result, nil
+}
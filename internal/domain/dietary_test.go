@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckDietaryConflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  DietaryProfile
+		contains []Allergen
+		want     []DietaryConflict
+	}{
+		{
+			name:     "空档案",
+			profile:  DietaryProfile{},
+			contains: []Allergen{AllergenPeanut, AllergenPork},
+			want:     nil,
+		},
+		{
+			name:     "菜品不含任何成分",
+			profile:  DietaryProfile{Allergens: []Allergen{AllergenPeanut}, Diets: []Diet{DietVegan}},
+			contains: nil,
+			want:     nil,
+		},
+		{
+			name:     "过敏原命中",
+			profile:  DietaryProfile{Allergens: []Allergen{AllergenPeanut, AllergenEgg}},
+			contains: []Allergen{AllergenEgg, AllergenSoy},
+			want:     []DietaryConflict{{Allergen: AllergenEgg, Source: DietaryConflictSourceAllergen}},
+		},
+		{
+			name:     "过敏原未命中",
+			profile:  DietaryProfile{Allergens: []Allergen{AllergenPeanut}},
+			contains: []Allergen{AllergenSoy},
+			want:     nil,
+		},
+		{
+			name:     "饮食要求按禁止成分的顺序列出",
+			profile:  DietaryProfile{Diets: []Diet{DietVegan}},
+			contains: []Allergen{AllergenHoney, AllergenMilk, AllergenPork},
+			want: []DietaryConflict{
+				{Allergen: AllergenPork, Source: string(DietVegan)},
+				{Allergen: AllergenMilk, Source: string(DietVegan)},
+				{Allergen: AllergenHoney, Source: string(DietVegan)},
+			},
+		},
+		{
+			name:     "清真不允许酒精",
+			profile:  DietaryProfile{Diets: []Diet{DietHalal}},
+			contains: []Allergen{AllergenAlcohol, AllergenMeat},
+			want:     []DietaryConflict{{Allergen: AllergenAlcohol, Source: string(DietHalal)}},
+		},
+		{
+			name:     "同一成分同时触发过敏原与多个饮食要求",
+			profile:  DietaryProfile{Allergens: []Allergen{AllergenPork}, Diets: []Diet{DietHalal, DietVegetarian}},
+			contains: []Allergen{AllergenPork},
+			want: []DietaryConflict{
+				{Allergen: AllergenPork, Source: DietaryConflictSourceAllergen},
+				{Allergen: AllergenPork, Source: string(DietHalal)},
+				{Allergen: AllergenPork, Source: string(DietVegetarian)},
+			},
+		},
+		{
+			name:     "档案与菜品中的重复项只列出一次",
+			profile:  DietaryProfile{Allergens: []Allergen{AllergenFish, AllergenFish}, Diets: []Diet{DietPescatarian, DietPescatarian}},
+			contains: []Allergen{AllergenFish, AllergenFish, AllergenMeat, AllergenMeat},
+			want: []DietaryConflict{
+				{Allergen: AllergenFish, Source: DietaryConflictSourceAllergen},
+				{Allergen: AllergenMeat, Source: string(DietPescatarian)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CheckDietaryConflicts(tt.profile, tt.contains)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckDietaryConflicts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
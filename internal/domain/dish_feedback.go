@@ -0,0 +1,62 @@
+package domain
+
+import "errors"
+
+// 评分范围
+const (
+	MinDishRatingScore        = 1
+	MaxDishRatingScore        = 5
+	MaxDishRatingCommentBytes = 500
+)
+
+// DishRating 用户对菜品的评分
+type DishRating struct {
+	ID      int64  `json:"id"`
+	UserID  int64  `json:"user_id"`
+	DishID  int64  `json:"dish_id"`
+	Score   int64  `json:"score"`
+	Comment string `json:"comment"`
+	Ctime   int64  `json:"ctime"`
+	Utime   int64  `json:"utime"`
+}
+
+// RateDishesRequest 菜品评分请求
+type RateDishesRequest struct {
+	UserID  int64  `json:"-"`
+	DishID  int64  `json:"-"`
+	Score   int64  `json:"score" validate:"required,min=1,max=5"`
+	Comment string `json:"comment" validate:"max=500"`
+}
+
+// DishRatingListResponse 菜品评分列表响应
+type DishRatingListResponse struct {
+	List  []DishRating `json:"list"`
+	Total int64        `json:"total"`
+	Page  int          `json:"page"`
+	Size  int          `json:"size"`
+}
+
+// 错误定义
+var (
+	ErrDishRatingInvalid         = errors.New("评分需在1到5之间")
+	ErrDishRatingCommentTooLong  = errors.New("评价内容过长")
+	ErrDishRatingNotFound        = errors.New("评分不存在")
+	ErrDishFeedbackUserIDInvalid = errors.New("用户ID无效")
+)
+
+// Validate 验证评分请求
+func (req RateDishesRequest) Validate() error {
+	if req.UserID <= 0 {
+		return ErrDishFeedbackUserIDInvalid
+	}
+	if req.DishID <= 0 {
+		return ErrDishesNotFound
+	}
+	if req.Score < MinDishRatingScore || req.Score > MaxDishRatingScore {
+		return ErrDishRatingInvalid
+	}
+	if len(req.Comment) > MaxDishRatingCommentBytes {
+		return ErrDishRatingCommentTooLong
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// DishType 菜品种类领域模型
+type DishType struct {
+	ID          int64  `json:"id"`
+	UserID      int64  `json:"user_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+	Color       string `json:"color"`
+	Sort        int64  `json:"sort"`
+	Status      int64  `json:"status"`
+	Ctime       int64  `json:"ctime"`
+	Utime       int64  `json:"utime"`
+	// ParentID 上级种类，顶层种类为0
+	ParentID int64 `json:"parent_id"`
+	// Path 从顶层到自身的种类ID路径，如 /3/17/42/，查询子孙种类时按前缀匹配
+	Path string `json:"-"`
+}
+
+// DishTypeNode 种类树中的一个节点
+type DishTypeNode struct {
+	DishType
+	DishCount  int64           `json:"dish_count"`  // 直接属于该种类的菜品数
+	TotalCount int64           `json:"total_count"` // 包含全部子孙种类的菜品数
+	Children   []*DishTypeNode `json:"children"`
+}
+
+// CreateDishTypeRequest 创建菜品种类请求
+type CreateDishTypeRequest struct {
+	UserID      int64  `json:"-"`
+	Name        string `json:"name" validate:"required,max=50"`
+	Description string `json:"description" validate:"max=200"`
+	Icon        string `json:"icon" validate:"max=200"`
+	Color       string `json:"color" validate:"max=20"`
+	ParentID    int64  `json:"parent_id"` // 上级种类，不填表示顶层种类
+}
+
+// MoveDishTypeRequest 调整菜品种类的上级，子孙种类随之移动
+type MoveDishTypeRequest struct {
+	UserID   int64 `json:"-"`
+	ID       int64 `json:"-"`
+	ParentID int64 `json:"parent_id"` // 新的上级种类，0 表示移到顶层
+}
+
+// MaxDishTypeDepth 种类树的最大层数
+const MaxDishTypeDepth = 5
+
+// 菜品种类状态
+const (
+	DishTypeStatusDisabled int64 = 0
+	DishTypeStatusEnabled  int64 = 1
+)
+
+// 错误定义
+var (
+	ErrDishTypeNotFound      = errors.New("菜品种类不存在")
+	ErrDishTypeNameEmpty     = errors.New("菜品种类名称不能为空")
+	ErrDishTypeUserMismatch  = errors.New("无权操作该菜品种类")
+	ErrDishTypeParentInvalid = errors.New("上级种类不存在")
+	ErrDishTypeCycle         = errors.New("不能将种类移动到自身或其子种类下")
+	ErrDishTypeTooDeep       = errors.New("种类层级不能超过5层")
+	// ErrDishTypePathMissing 种类还没有补全路径，不能按路径前缀查询子树
+	ErrDishTypePathMissing = errors.New("菜品种类路径未初始化")
+)
+
+// NewDishType 创建新的菜品种类实例
+func NewDishType(userID int64, name string) (*DishType, error) {
+	if userID <= 0 {
+		return nil, errors.New("用户ID无效")
+	}
+	if name == "" {
+		return nil, ErrDishTypeNameEmpty
+	}
+	if len(name) > 50 {
+		return nil, errors.New("菜品种类名称过长")
+	}
+
+	now := time.Now().Unix()
+	return &DishType{
+		UserID: userID,
+		Name:   name,
+		Status: DishTypeStatusEnabled,
+		Ctime:  now,
+		Utime:  now,
+	}, nil
+}
+
+// Depth 种类所在的层数，顶层为1
+func (dt DishType) Depth() int {
+	return strings.Count(dt.Path, "/") - 1
+}
+
+// IsAncestorOf 判断 dt 是否为 other 自身或其祖先
+func (dt DishType) IsAncestorOf(other DishType) bool {
+	return dt.Path != "" && strings.HasPrefix(other.Path, dt.Path)
+}
@@ -7,16 +7,31 @@ import (
 
 // Dishes 菜品领域模型
 type Dishes struct {
-	ID      int64  `json:"id"`
-	UserID  int64  `json:"user_id"`
-	Name    string `json:"name"`
-	Desc    string `json:"desc"`
-	Price   int64  `json:"price"`
-	Img     string `json:"img"`
-	Type    int64  `json:"type"`
-	Calorie int64  `json:"calorie"`
-	Ctime   int64  `json:"ctime"`
-	Utime   int64  `json:"utime"`
+	ID          int64      `json:"id"`
+	UserID      int64      `json:"user_id"`
+	Name        string     `json:"name"`
+	Desc        string     `json:"desc"`
+	Price       int64      `json:"price"`
+	Img         string     `json:"img"`
+	Type        int64      `json:"type"`
+	Calorie     int64      `json:"calorie"`
+	Ingredients []string   `json:"ingredients"`
+	Allergens   []Allergen `json:"allergens"` // 菜品含有的过敏原与禁忌成分
+	Ctime       int64      `json:"ctime"`
+	Utime       int64      `json:"utime"`
+	// Sort 用户拖动排序的权重，越大越靠前，只能通过排序接口修改
+	Sort int64 `json:"sort"`
+	// 评分、收藏与烹饪记录的聚合值
+	RatingAvg     float64 `json:"rating_avg"`
+	RatingCount   int64   `json:"rating_count"`
+	FavoriteCount int64   `json:"favorite_count"`
+	TimesCooked   int64   `json:"times_cooked"`
+	LastCookedAt  int64   `json:"last_cooked_at"`
+	// 复制而来的菜品记录来源，自己创建的菜品为0
+	SourceDishID int64 `json:"source_dish_id,omitempty"`
+	SourceUserID int64 `json:"source_user_id,omitempty"`
+	// SourceDigest 复制时来源菜品的内容摘要，用于判断来源是否有改动。菜品缓存以 JSON 保存，不能省略
+	SourceDigest string `json:"source_digest,omitempty"`
 }
 
 // DishesWithType 包含种类信息的菜品
@@ -26,37 +41,88 @@ type DishesWithType struct {
 	TypeDescription string `json:"type_description"`
 	TypeIcon        string `json:"type_icon"`
 	TypeColor       string `json:"type_color"`
+	IsFavorite      bool   `json:"is_favorite"` // 当前用户是否已收藏
+	Tags            []Tag  `json:"tags"`
+	// Conflicts 与当前用户饮食档案冲突的成分，为空表示可以放心吃
+	Conflicts []DietaryConflict `json:"conflicts"`
 }
 
 // CreateDishesRequest 创建菜品请求
 type CreateDishesRequest struct {
-	UserID  int64  `json:"user_id" validate:"required"`
-	Name    string `json:"name" validate:"required,min=1,max=100"`
-	Desc    string `json:"desc" validate:"max=200"`
-	Price   int64  `json:"price" validate:"min=0"`
-	Img     string `json:"img" validate:"max=200"`
-	Type    int64  `json:"type" validate:"required"`
-	Calorie int64  `json:"calorie" validate:"min=0"`
+	UserID      int64      `json:"user_id" validate:"required"`
+	Name        string     `json:"name" validate:"required,min=1,max=100"`
+	Desc        string     `json:"desc" validate:"max=200"`
+	Price       int64      `json:"price" validate:"min=0"`
+	Img         string     `json:"img" validate:"max=200"`
+	Type        int64      `json:"type" validate:"required"`
+	Calorie     int64      `json:"calorie" validate:"min=0"`
+	Ingredients []string   `json:"ingredients" validate:"max=100,dive,max=100"`
+	Allergens   []Allergen `json:"allergens" validate:"max=20"`
+	// 复制菜品时由服务层填写
+	SourceDishID int64  `json:"-"`
+	SourceUserID int64  `json:"-"`
+	SourceDigest string `json:"-"`
 }
 
 // UpdateDishesRequest 更新菜品请求
 type UpdateDishesRequest struct {
-	ID      int64  `json:"id" validate:"required"`
-	UserID  int64  `json:"user_id" validate:"required"`
-	Name    string `json:"name" validate:"required,min=1,max=100"`
-	Desc    string `json:"desc" validate:"max=200"`
-	Price   int64  `json:"price" validate:"min=0"`
-	Img     string `json:"img" validate:"max=200"`
-	Type    int64  `json:"type" validate:"required"`
-	Calorie int64  `json:"calorie" validate:"min=0"`
+	ID          int64      `json:"id" validate:"required"`
+	UserID      int64      `json:"user_id" validate:"required"`
+	Name        string     `json:"name" validate:"required,min=1,max=100"`
+	Desc        string     `json:"desc" validate:"max=200"`
+	Price       int64      `json:"price" validate:"min=0"`
+	Img         string     `json:"img" validate:"max=200"`
+	Type        int64      `json:"type" validate:"required"`
+	Calorie     int64      `json:"calorie" validate:"min=0"`
+	Ingredients []string   `json:"ingredients" validate:"max=100,dive,max=100"`
+	Allergens   []Allergen `json:"allergens" validate:"max=20"`
 }
 
+// 食材清单限制
+const (
+	MaxDishesIngredients      = 100
+	MaxDishesIngredientLength = 100
+)
+
 // DishesQuery 菜品查询条件
 type DishesQuery struct {
-	UserID int64 `json:"user_id"`
-	Type   int64 `json:"type"`
-	Offset int   `json:"offset"`
-	Limit  int   `json:"limit"`
+	UserID   int64      `json:"user_id"`
+	Type     int64      `json:"type"`
+	Keyword  string     `json:"keyword"`
+	Favorite bool       `json:"favorite"` // 仅返回当前用户收藏的菜品
+	Sort     DishesSort `json:"sort"`
+	// ExcludeConflicts 排除与当前用户饮食档案冲突的菜品，否则仅在结果中标记
+	ExcludeConflicts bool `json:"exclude_conflicts"`
+	// IncludeDescendants 按种类过滤时同时包含其全部子孙种类
+	IncludeDescendants bool `json:"include_descendants"`
+	// ExcludeAllergens 由服务层根据饮食档案填充，排除含有其中任一成分的菜品
+	ExcludeAllergens []Allergen `json:"-"`
+	// 标签过滤：TagIDs 按 TagMatch 取交集或并集，ExcludeTagIDs 中的标签一个都不能有
+	TagIDs        []int64      `json:"tag_ids"`
+	TagMatch      TagMatchMode `json:"tag_match"`
+	ExcludeTagIDs []int64      `json:"exclude_tag_ids"`
+	Offset        int          `json:"offset"`
+	Limit         int          `json:"limit"`
+}
+
+// DishesSort 菜品列表排序方式
+type DishesSort string
+
+const (
+	DishesSortDefault   DishesSort = ""          // 按用户自定义的顺序，未排过序的按创建顺序排在后面
+	DishesSortRating    DishesSort = "rating"    // 按平均评分从高到低
+	DishesSortFavorites DishesSort = "favorites" // 按收藏数从多到少
+	DishesSortCooked    DishesSort = "cooked"    // 按最近烹饪时间从早到晚，最久没做的排在前面
+)
+
+// ParseDishesSort 解析排序方式
+func ParseDishesSort(s string) (DishesSort, error) {
+	switch sort := DishesSort(s); sort {
+	case DishesSortDefault, DishesSortRating, DishesSortFavorites, DishesSortCooked:
+		return sort, nil
+	default:
+		return "", ErrDishesSortInvalid
+	}
 }
 
 // DishesListResponse 菜品列表响应
@@ -74,6 +140,7 @@ var (
 	ErrDishesPriceInvalid = errors.New("菜品价格无效")
 	ErrDishesTypeInvalid  = errors.New("菜品类型无效")
 	ErrDishesUserMismatch = errors.New("菜品不属于该用户")
+	ErrDishesSortInvalid  = errors.New("不支持的排序方式")
 )
 
 // NewDishes 创建新的菜品实例
@@ -83,17 +150,25 @@ func NewDishes(req CreateDishesRequest) (*Dishes, error) {
 		return nil, err
 	}
 
+	allergens, _ := NormalizeAllergens(req.Allergens)
+
 	now := time.Now().Unix()
 	dishes := &Dishes{
-		UserID:  req.UserID,
-		Name:    req.Name,
-		Desc:    req.Desc,
-		Price:   req.Price,
-		Img:     req.Img,
-		Type:    req.Type,
-		Calorie: req.Calorie,
-		Ctime:   now,
-		Utime:   now,
+		UserID:      req.UserID,
+		Name:        req.Name,
+		Desc:        req.Desc,
+		Price:       req.Price,
+		Img:         req.Img,
+		Type:        req.Type,
+		Calorie:     req.Calorie,
+		Ingredients: req.Ingredients,
+		Allergens:   allergens,
+		Ctime:       now,
+		Utime:       now,
+
+		SourceDishID: req.SourceDishID,
+		SourceUserID: req.SourceUserID,
+		SourceDigest: req.SourceDigest,
 	}
 
 	return dishes, nil
@@ -125,7 +200,11 @@ func (req CreateDishesRequest) Validate() error {
 	if req.Calorie < 0 {
 		return errors.New("卡路里不能为负数")
 	}
-	return nil
+	if err := validateIngredients(req.Ingredients); err != nil {
+		return err
+	}
+	_, err := NormalizeAllergens(req.Allergens)
+	return err
 }
 
 // Validate 验证更新菜品请求
@@ -157,7 +236,11 @@ func (req UpdateDishesRequest) Validate() error {
 	if req.Calorie < 0 {
 		return errors.New("卡路里不能为负数")
 	}
-	return nil
+	if err := validateIngredients(req.Ingredients); err != nil {
+		return err
+	}
+	_, err := NormalizeAllergens(req.Allergens)
+	return err
 }
 
 // Update 更新菜品信息
@@ -179,11 +262,26 @@ func (d *Dishes) Update(req UpdateDishesRequest) error {
 	d.Img = req.Img
 	d.Type = req.Type
 	d.Calorie = req.Calorie
+	d.Ingredients = req.Ingredients
+	d.Allergens, _ = NormalizeAllergens(req.Allergens)
 	d.Utime = time.Now().Unix()
 
 	return nil
 }
 
+// validateIngredients 验证食材清单
+func validateIngredients(ingredients []string) error {
+	if len(ingredients) > MaxDishesIngredients {
+		return errors.New("食材数量过多")
+	}
+	for _, ingredient := range ingredients {
+		if len(ingredient) > MaxDishesIngredientLength {
+			return errors.New("食材名称过长")
+		}
+	}
+	return nil
+}
+
 // CanDelete 检查是否可以删除
 func (d *Dishes) CanDelete(userID int64) error {
 	if d.UserID != userID {
@@ -195,15 +293,16 @@ func (d *Dishes) CanDelete(userID int64) error {
 // ToResponse 转换为响应格式
 func (d *Dishes) ToResponse() map[string]interface{} {
 	return map[string]interface{}{
-		"id":      d.ID,
-		"user_id": d.UserID,
-		"name":    d.Name,
-		"desc":    d.Desc,
-		"price":   d.Price,
-		"img":     d.Img,
-		"type":    d.Type,
-		"calorie": d.Calorie,
-		"ctime":   d.Ctime,
-		"utime":   d.Utime,
+		"id":          d.ID,
+		"user_id":     d.UserID,
+		"name":        d.Name,
+		"desc":        d.Desc,
+		"price":       d.Price,
+		"img":         d.Img,
+		"type":        d.Type,
+		"calorie":     d.Calorie,
+		"ingredients": d.Ingredients,
+		"ctime":       d.Ctime,
+		"utime":       d.Utime,
 	}
 }
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+)
+
+// DishesSourceStatus 复制而来的菜品与来源的对比结果
+type DishesSourceStatus struct {
+	SourceDishID int64 `json:"source_dish_id"`
+	SourceUserID int64 `json:"source_user_id"`
+	SourceExists bool  `json:"source_exists"` // 来源菜品是否仍然存在且可读
+	Changed      bool  `json:"changed"`       // 复制后来源菜品的内容是否有改动，来源已删除或不可读时为 false
+	SourceUtime  int64 `json:"source_utime"`  // 来源菜品的最后更新时间
+}
+
+// ErrDishesNotCloned 菜品不是复制而来
+var ErrDishesNotCloned = errors.New("菜品不是复制而来")
+
+// ContentDigest 菜品内容的摘要，只包含复制时会带走的字段。
+// 种类在不同用户之间会重新映射，不参与比较；评分等聚合值不算内容改动
+func (d Dishes) ContentDigest() string {
+	// 空列表与 nil 视为相同，避免经过缓存序列化后摘要不一致
+	if len(d.Ingredients) == 0 {
+		d.Ingredients = nil
+	}
+	if len(d.Allergens) == 0 {
+		d.Allergens = nil
+	}
+	data, _ := json.Marshal(struct {
+		Name        string     `json:"name"`
+		Desc        string     `json:"desc"`
+		Price       int64      `json:"price"`
+		Img         string     `json:"img"`
+		Calorie     int64      `json:"calorie"`
+		Ingredients []string   `json:"ingredients"`
+		Allergens   []Allergen `json:"allergens"`
+	}{d.Name, d.Desc, d.Price, d.Img, d.Calorie, d.Ingredients, d.Allergens})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// 重复菜品检测与合并
+const (
+	// DefaultDuplicateSimilarity 默认的名称相似度阈值
+	DefaultDuplicateSimilarity = 0.75
+	// MinDuplicateSimilarity 允许设置的最低相似度，再低几乎所有名称都会被判为相近
+	MinDuplicateSimilarity = 0.5
+	// MaxMergeDishes 一次最多合并的菜品数
+	MaxMergeDishes = 50
+)
+
+var (
+	ErrDuplicateSimilarityInvalid = errors.New("相似度需在0.5到1之间")
+	ErrDishesMergeInvalid         = errors.New("请选择保留菜品以外的其他菜品进行合并")
+	ErrDishesMergeTooMany         = errors.New("一次最多合并50道菜品")
+)
+
+// DishesDuplicateItem 疑似重复的菜品
+type DishesDuplicateItem struct {
+	Dishes
+	// Similarity 与保留菜品名称的相似度，1 表示归一化后名称相同
+	Similarity float64 `json:"similarity"`
+}
+
+// DishesDuplicateGroup 一组疑似重复的菜品及合并建议
+type DishesDuplicateGroup struct {
+	KeepID   int64                 `json:"keep_id"`   // 建议保留的菜品：烹饪、评分与收藏最多，其次创建最早
+	MergeIDs []int64               `json:"merge_ids"` // 建议合并到保留菜品的其余菜品
+	Dishes   []DishesDuplicateItem `json:"dishes"`    // 保留的菜品在第一位，其余按相似度从高到低
+}
+
+// MergeDishesRequest 合并菜品请求，MergeIDs 中的菜品合并到 KeepID 后删除
+type MergeDishesRequest struct {
+	UserID   int64   `json:"-"`
+	KeepID   int64   `json:"-"`
+	MergeIDs []int64 `json:"merge_ids" validate:"required,min=1,max=50"`
+}
+
+// CreateDishesResponse 创建菜品的响应，请求检查重复时附带名称相近的已有菜品
+type CreateDishesResponse struct {
+	Dishes
+	Duplicates []DishesDuplicateItem `json:"duplicates,omitempty"`
+}
+
+// 名称中的备注括号，括号内的内容不参与比较，例如 "红烧肉 (new)"
+const (
+	nameNoteOpen  = "([{【〔"
+	nameNoteClose = ")]}】〕"
+)
+
+// DishNameKey 查找重复菜品使用的名称键：NFKC 规范化并折叠大小写，繁体折叠为简体，
+// 去掉括号内的备注以及空白与标点。名称只有括号内的文字时保留这些文字
+func DishNameKey(name string) string {
+	folded := caseFolder.String(norm.NFKC.String(name))
+	if key := dishNameKey(folded, true); key != "" {
+		return key
+	}
+	return dishNameKey(folded, false)
+}
+
+func dishNameKey(name string, stripNotes bool) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range name {
+		switch {
+		case strings.ContainsRune(nameNoteOpen, r):
+			depth++
+			continue
+		case strings.ContainsRune(nameNoteClose, r):
+			if depth > 0 {
+				depth--
+			}
+			continue
+		}
+		if (stripNotes && depth > 0) || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			continue
+		}
+		if s, ok := traditionalToSimplified[r]; ok {
+			r = s
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// DishNameSimilarity 两个名称键的相似度：1 减去编辑距离与较长键长度之比
+func DishNameSimilarity(a string, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(editDistance(ra, rb))/float64(longest)
+}
+
+// editDistance 按字符计算的 Levenshtein 距离，只保留两行
+func editDistance(a []rune, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			best := prev[j-1] + cost
+			if prev[j]+1 < best {
+				best = prev[j] + 1
+			}
+			if curr[j-1]+1 < best {
+				best = curr[j-1] + 1
+			}
+			curr[j] = best
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// traditionalToSimplified 菜名中常见的繁体字到简体字的映射，只用于比较名称，不改写菜品
+var traditionalToSimplified = func() map[rune]rune {
+	pairs := []rune("" +
+		// 烹饪方式与调味
+		"紅红燒烧燉炖燜焖燴烩滷卤鹵卤醃腌燻熏釀酿醬酱鹽盐鹹咸濃浓嗆呛熗炝燙烫涼凉熱热乾干濕湿鬆松軟软撈捞飪饪" +
+		// 禽畜与水产
+		"雞鸡鴨鸭鵝鹅鴿鸽鵪鹌鶉鹑豬猪驢驴馬马腸肠腎肾魚鱼蝦虾蠔蚝蠣蛎蟶蛏鮑鲍鰻鳗鱈鳕鯉鲤鱸鲈鯽鲫鱖鳜鰱鲢" +
+		"鱔鳝魷鱿鱉鳖鮭鲑鯛鲷鱒鳟鮪鲔鯧鲳鯰鲶鯖鲭鱘鲟鯊鲨鰭鳍鮮鲜貝贝烏乌賊贼" +
+		// 主食与点心
+		"麵面麪面飯饭湯汤餃饺餛馄飩饨饅馒饃馍餅饼餑饽餡馅糉粽糰团團团圓圆雲云貼贴蓋盖澆浇擔担壽寿" +
+		// 蔬果与杂粮
+		"蔥葱薑姜蘿萝蔔卜筍笋蓮莲蕎荞薺荠莧苋萵莴蘆芦蒓莼蘋苹檸柠葉叶蘭兰蠶蚕棗枣櫻樱鈴铃豐丰穀谷糧粮" +
+		// 器具与量词
+		"鍋锅爐炉籠笼盤盘鐵铁絲丝塊块條条頭头腳脚雙双點点邊边顆颗雜杂" +
+		// 菜名中常见的其他字
+		"宮宫獅狮鳳凤龍龙麥麦羅罗漢汉螞蚂蟻蚁樹树貴贵鴛鸳鴦鸯過过橋桥線线燈灯銀银聖圣誕诞節节臘腊鬱郁葷荤齋斋" +
+		"餚肴饌馔飲饮東东華华廣广門门閩闽蘇苏揚扬粵粤滬沪晉晋臺台灣湾黃黄綠绿藍蓝" +
+		"開开見见這这會会來来時时後后裡里裏里夠够買买賣卖錢钱體体實实為为無无與与從从間间氣气當当" +
+		"經经長长還还發发樣样種种現现國国說说對对麼么們们個个")
+	m := make(map[rune]rune, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		m[pairs[i]] = pairs[i+1]
+	}
+	return m
+}()
@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// DishesExchangeFormat 菜品导入导出格式
+type DishesExchangeFormat string
+
+const (
+	DishesExchangeJSON     DishesExchangeFormat = "json"     // 无损格式，包含全部字段及种类信息
+	DishesExchangeCSV      DishesExchangeFormat = "csv"      // 扁平格式，便于电子表格编辑
+	DishesExchangeMarkdown DishesExchangeFormat = "markdown" // 可读格式，仅支持导出
+)
+
+// DishesExportVersion 导出文件格式版本
+const DishesExportVersion = 1
+
+// 错误定义
+var (
+	ErrDishesExchangeFormatInvalid = errors.New("不支持的导入导出格式")
+	ErrDishesImportEmpty           = errors.New("导入内容为空")
+	ErrDishesImportTooLarge        = errors.New("导入条数超过上限")
+	ErrDishesImportMalformed       = errors.New("导入内容格式错误")
+)
+
+// ParseDishesExchangeFormat 解析导入导出格式，支持常见的别名与文件扩展名
+func ParseDishesExchangeFormat(s string) (DishesExchangeFormat, error) {
+	switch strings.ToLower(strings.TrimPrefix(strings.TrimSpace(s), ".")) {
+	case "json":
+		return DishesExchangeJSON, nil
+	case "csv":
+		return DishesExchangeCSV, nil
+	case "markdown", "md":
+		return DishesExchangeMarkdown, nil
+	default:
+		return "", ErrDishesExchangeFormatInvalid
+	}
+}
+
+// DishesExport 菜品导出数据
+type DishesExport struct {
+	Version    int        `json:"version"`
+	ExportedAt int64      `json:"exported_at"`
+	DishTypes  []DishType `json:"dish_types"`
+	Dishes     []Dishes   `json:"dishes"`
+}
+
+// DishesExportFile 导出文件
+type DishesExportFile struct {
+	Name        string
+	ContentType string
+	Content     []byte
+}
+
+// DishesImportRow 待导入的一行菜品数据，种类按名称关联
+type DishesImportRow struct {
+	Row             int        `json:"row"`
+	Name            string     `json:"name"`
+	Desc            string     `json:"desc"`
+	Price           int64      `json:"price"`
+	Img             string     `json:"img"`
+	Calorie         int64      `json:"calorie"`
+	Ingredients     []string   `json:"ingredients"`
+	Allergens       []Allergen `json:"allergens"`
+	TypeName        string     `json:"type_name"`
+	TypeDescription string     `json:"type_description"`
+	TypeIcon        string     `json:"type_icon"`
+	TypeColor       string     `json:"type_color"`
+}
+
+// ToCreateRequest 转换为创建菜品请求
+func (r DishesImportRow) ToCreateRequest(userID int64, typeID int64) CreateDishesRequest {
+	return CreateDishesRequest{
+		UserID:      userID,
+		Name:        r.Name,
+		Desc:        r.Desc,
+		Price:       r.Price,
+		Img:         r.Img,
+		Type:        typeID,
+		Calorie:     r.Calorie,
+		Ingredients: r.Ingredients,
+		Allergens:   r.Allergens,
+	}
+}
+
+// 导入行处理结果
+const (
+	DishesImportCreated = "created"
+	DishesImportSkipped = "skipped"
+	DishesImportFailed  = "failed"
+)
+
+// DishesImportRowResult 单行导入结果
+type DishesImportRowResult struct {
+	Row    int    `json:"row"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	DishID int64  `json:"dish_id,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// DishesImportResult 导入结果报告
+type DishesImportResult struct {
+	Total        int                     `json:"total"`
+	Created      int                     `json:"created"`
+	Skipped      int                     `json:"skipped"`
+	Failed       int                     `json:"failed"`
+	CreatedTypes []string                `json:"created_types"`
+	Rows         []DishesImportRowResult `json:"rows"`
+}
+
+// AddRow 记录单行导入结果
+func (r *DishesImportResult) AddRow(row DishesImportRowResult) {
+	switch row.Status {
+	case DishesImportCreated:
+		r.Created++
+	case DishesImportSkipped:
+		r.Skipped++
+	case DishesImportFailed:
+		r.Failed++
+	}
+	r.Rows = append(r.Rows, row)
+}
+
+// NormalizeDishName 归一化菜品名称，用于按名称去重
+func NormalizeDishName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// RecipeImportRequest 从 schema.org Recipe 导入菜品的请求，URL 与 Content 二选一
+type RecipeImportRequest struct {
+	UserID  int64  `json:"-"`
+	URL     string `json:"url"`     // 菜谱网页地址
+	Content string `json:"content"` // HTML 文档或 JSON-LD 文本
+}
+
+// ErrRecipeImportSourceEmpty 未提供菜谱来源
+var ErrRecipeImportSourceEmpty = errors.New("请提供菜谱链接或内容")
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+)
+
+// maxEmailLength 邮箱的最大长度，对应 users.email 列宽
+const maxEmailLength = 100
+
+// EmailTokenPurpose 邮件令牌的用途，不同用途的令牌不能混用
+type EmailTokenPurpose string
+
+const (
+	EmailTokenVerify EmailTokenPurpose = "verify"
+	EmailTokenReset  EmailTokenPurpose = "reset"
+)
+
+// EmailTokenClaims 邮件令牌在 Redis 中保存的内容
+type EmailTokenClaims struct {
+	UserID int64  `json:"user_id"`
+	Email  string `json:"email"`
+	// TokenVersion 签发时的用户令牌版本，重置密码后之前发出的重置链接失效
+	TokenVersion int64 `json:"token_version"`
+}
+
+// UpdateEmailRequest 设置邮箱请求，需要输入密码确认，邮箱为空表示解除绑定
+type UpdateEmailRequest struct {
+	UserID   int64  `json:"-"`
+	Email    string `json:"email"`
+	Password string `json:"password" validate:"required"`
+}
+
+// VerifyEmailRequest 验证邮箱请求
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// ForgotPasswordRequest 忘记密码请求
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required"`
+}
+
+// ResetPasswordRequest 通过邮件中的令牌重置密码
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=6,max=72"`
+}
+
+var (
+	ErrEmailInvalid         = errors.New("邮箱格式不正确")
+	ErrEmailAlreadyUsed     = errors.New("邮箱已被其他账号使用")
+	ErrEmailNotSet          = errors.New("尚未设置邮箱")
+	ErrEmailAlreadyVerified = errors.New("邮箱已验证")
+	ErrEmailSendTooFrequent = errors.New("邮件发送过于频繁，请稍后再试")
+	// ErrEmailTokenInvalid 邮件令牌无效、已使用或已过期
+	ErrEmailTokenInvalid = errors.New("链接无效或已过期")
+)
+
+// NormalizeEmail 去除首尾空白并转为小写
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// ValidateEmail 校验规范化后的邮箱，只接受不带显示名称的地址
+func ValidateEmail(email string) error {
+	if len(email) > maxEmailLength {
+		return ErrEmailInvalid
+	}
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return ErrEmailInvalid
+	}
+	return nil
+}
@@ -1,6 +1,53 @@
 package domain
 
+import (
+	"errors"
+	"time"
+)
+
 type LoginReq struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username string `json:"username" validate:"required,max=50"`
+	Password string `json:"password" validate:"required"`
+	IP       string `json:"-"` // 客户端IP，由控制器填充
+}
+
+type LoginOutput struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	// RetryAfter 登录被锁定时距离解锁的时间，仅在返回 ErrLoginLocked 时有值
+	RetryAfter time.Duration `json:"-"`
+}
+
+var (
+	// ErrInvalidCredentials 用户名不存在与密码错误返回同一个错误，避免泄露用户名是否存在
+	ErrInvalidCredentials  = errors.New("用户名或密码错误")
+	ErrLoginLocked         = errors.New("登录失败次数过多，请稍后再试")
+	ErrLockoutScopeInvalid = errors.New("锁定维度无效")
+)
+
+// LockoutScope 登录失败的计数维度
+type LockoutScope string
+
+const (
+	LockoutScopeUsername LockoutScope = "username"
+	LockoutScopeIP       LockoutScope = "ip"
+)
+
+// ParseLockoutScope 解析锁定维度，空字符串表示全部维度
+func ParseLockoutScope(s string) (LockoutScope, error) {
+	switch LockoutScope(s) {
+	case "", LockoutScopeUsername, LockoutScopeIP:
+		return LockoutScope(s), nil
+	default:
+		return "", ErrLockoutScopeInvalid
+	}
+}
+
+// LoginLockout 一条登录锁定记录
+type LoginLockout struct {
+	Scope      LockoutScope `json:"scope"`
+	Subject    string       `json:"subject"`     // 用户名或IP
+	Failures   int64        `json:"failures"`    // 当前计数窗口内的失败次数
+	RetryAfter int64        `json:"retry_after"` // 距离解锁的秒数
+	LockedTill int64        `json:"locked_till"` // 解锁时间（Unix 秒）
 }
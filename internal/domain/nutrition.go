@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// NutritionDayLayout 饮食记录按天统计使用的日期格式
+const NutritionDayLayout = "2006-01-02"
+
+// 饮食记录限制
+const (
+	MaxMealRecordServings     = 20
+	MaxNutritionTrendDays     = 366
+	DefaultNutritionTrendDays = 30
+)
+
+// NutritionGoal 用户的卡路里目标与花费预算，0 表示未设置
+type NutritionGoal struct {
+	UserID        int64 `json:"user_id"`
+	DailyCalorie  int64 `json:"daily_calorie"`
+	DailyBudget   int64 `json:"daily_budget"`
+	WeeklyCalorie int64 `json:"weekly_calorie"`
+	WeeklyBudget  int64 `json:"weekly_budget"`
+	Utime         int64 `json:"utime"`
+}
+
+// SetNutritionGoalRequest 设置目标请求，整体覆盖
+type SetNutritionGoalRequest struct {
+	UserID        int64 `json:"-"`
+	DailyCalorie  int64 `json:"daily_calorie" validate:"min=0"`
+	DailyBudget   int64 `json:"daily_budget" validate:"min=0"`
+	WeeklyCalorie int64 `json:"weekly_calorie" validate:"min=0"`
+	WeeklyBudget  int64 `json:"weekly_budget" validate:"min=0"`
+}
+
+// MealRecord 某天吃了某道菜的记录，卡路里与花费按记录时的菜品数据乘以份数保存
+type MealRecord struct {
+	ID       int64  `json:"id"`
+	UserID   int64  `json:"user_id"`
+	DishID   int64  `json:"dish_id"`
+	DishName string `json:"dish_name"`
+	Day      string `json:"day"`
+	Servings int64  `json:"servings"`
+	Calorie  int64  `json:"calorie"`
+	Spend    int64  `json:"spend"`
+	Ctime    int64  `json:"ctime"`
+}
+
+// CreateMealRecordRequest 记录吃了某道菜的请求
+type CreateMealRecordRequest struct {
+	UserID   int64  `json:"-"`
+	DishID   int64  `json:"dish_id" validate:"required"`
+	Day      string `json:"day"`      // 格式 2006-01-02，为空时取今天
+	Servings int64  `json:"servings"` // 为空时按1份计算
+}
+
+// NutritionTotals 一段时间内的饮食合计
+type NutritionTotals struct {
+	Meals   int64 `json:"meals"`
+	Calorie int64 `json:"calorie"`
+	Spend   int64 `json:"spend"`
+}
+
+// GoalProgressItem 单项目标的完成情况
+type GoalProgressItem struct {
+	Target    int64   `json:"target"` // 0 表示未设置目标
+	Actual    int64   `json:"actual"`
+	Remaining int64   `json:"remaining"` // 超出时为负数
+	Percent   float64 `json:"percent"`
+	Over      bool    `json:"over"`
+}
+
+// GoalProgress 某个周期内的目标完成情况
+type GoalProgress struct {
+	From    string           `json:"from"`
+	To      string           `json:"to"`
+	Meals   int64            `json:"meals"`
+	Calorie GoalProgressItem `json:"calorie"`
+	Budget  GoalProgressItem `json:"budget"`
+}
+
+// NutritionProgress 指定日期当天与所在周的目标完成情况
+type NutritionProgress struct {
+	Day        string        `json:"day"`
+	Goal       NutritionGoal `json:"goal"`
+	Daily      GoalProgress  `json:"daily"`
+	Weekly     GoalProgress  `json:"weekly"`
+	OverBudget bool          `json:"over_budget"` // 当天或本周花费超出预算
+}
+
+// DailyNutritionSnapshot 每日饮食合计的快照，用于趋势图
+type DailyNutritionSnapshot struct {
+	UserID        int64  `json:"user_id"`
+	Day           string `json:"day"`
+	Meals         int64  `json:"meals"`
+	Calorie       int64  `json:"calorie"`
+	Spend         int64  `json:"spend"`
+	CalorieTarget int64  `json:"calorie_target"`
+	BudgetTarget  int64  `json:"budget_target"`
+	Ctime         int64  `json:"ctime"`
+}
+
+// 错误定义
+var (
+	ErrNutritionGoalInvalid      = errors.New("目标不能为负数")
+	ErrNutritionDayInvalid       = errors.New("日期格式无效，应为 YYYY-MM-DD")
+	ErrNutritionDayInFuture      = errors.New("不能记录未来的饮食")
+	ErrNutritionRangeInvalid     = errors.New("日期范围无效")
+	ErrMealRecordNotFound        = errors.New("饮食记录不存在")
+	ErrMealRecordUserMismatch    = errors.New("饮食记录不属于该用户")
+	ErrMealRecordServingsInvalid = errors.New("份数无效")
+)
+
+// NewNutritionGoal 校验并创建目标
+func NewNutritionGoal(req SetNutritionGoalRequest) (*NutritionGoal, error) {
+	if req.DailyCalorie < 0 || req.DailyBudget < 0 || req.WeeklyCalorie < 0 || req.WeeklyBudget < 0 {
+		return nil, ErrNutritionGoalInvalid
+	}
+	return &NutritionGoal{
+		UserID:        req.UserID,
+		DailyCalorie:  req.DailyCalorie,
+		DailyBudget:   req.DailyBudget,
+		WeeklyCalorie: req.WeeklyCalorie,
+		WeeklyBudget:  req.WeeklyBudget,
+		Utime:         time.Now().Unix(),
+	}, nil
+}
+
+// NewMealRecord 根据菜品创建饮食记录，调用方负责校验菜品属于该用户
+func NewMealRecord(req CreateMealRecordRequest, dish Dishes, now time.Time) (*MealRecord, error) {
+	if req.UserID <= 0 {
+		return nil, errors.New("用户ID无效")
+	}
+
+	day := now
+	if req.Day != "" {
+		var err error
+		if day, err = ParseNutritionDay(req.Day, now.Location()); err != nil {
+			return nil, err
+		}
+		if FormatNutritionDay(day) > FormatNutritionDay(now) {
+			return nil, ErrNutritionDayInFuture
+		}
+	}
+
+	servings := req.Servings
+	if servings == 0 {
+		servings = 1
+	}
+	if servings < 0 || servings > MaxMealRecordServings {
+		return nil, ErrMealRecordServingsInvalid
+	}
+
+	return &MealRecord{
+		UserID:   req.UserID,
+		DishID:   dish.ID,
+		DishName: dish.Name,
+		Day:      FormatNutritionDay(day),
+		Servings: servings,
+		Calorie:  dish.Calorie * servings,
+		Spend:    dish.Price * servings,
+		Ctime:    now.Unix(),
+	}, nil
+}
+
+// NewGoalProgressItem 计算单项目标的完成情况
+func NewGoalProgressItem(target, actual int64) GoalProgressItem {
+	item := GoalProgressItem{Target: target, Actual: actual}
+	if target <= 0 {
+		return item
+	}
+	item.Remaining = target - actual
+	item.Percent = float64(actual) * 100 / float64(target)
+	item.Over = actual > target
+	return item
+}
+
+// NewGoalProgress 计算一个周期内的目标完成情况
+func NewGoalProgress(from, to time.Time, totals NutritionTotals, calorieTarget, budgetTarget int64) GoalProgress {
+	return GoalProgress{
+		From:    FormatNutritionDay(from),
+		To:      FormatNutritionDay(to),
+		Meals:   totals.Meals,
+		Calorie: NewGoalProgressItem(calorieTarget, totals.Calorie),
+		Budget:  NewGoalProgressItem(budgetTarget, totals.Spend),
+	}
+}
+
+// ParseNutritionDay 解析 YYYY-MM-DD 格式的日期
+func ParseNutritionDay(s string, loc *time.Location) (time.Time, error) {
+	day, err := time.ParseInLocation(NutritionDayLayout, s, loc)
+	if err != nil {
+		return time.Time{}, ErrNutritionDayInvalid
+	}
+	return day, nil
+}
+
+// FormatNutritionDay 格式化为 YYYY-MM-DD
+func FormatNutritionDay(t time.Time) string {
+	return t.Format(NutritionDayLayout)
+}
+
+// NutritionWeek 返回日期所在周（周一至周日）的起止日期
+func NutritionWeek(day time.Time) (time.Time, time.Time) {
+	monday := StatisticsPeriodWeek.Since(day, 1)
+	return monday, monday.AddDate(0, 0, 6)
+}
@@ -0,0 +1,156 @@
+package domain
+
+import "errors"
+
+// 自定义排序。菜品与种类按排序权重从大到小排列，权重相同或未排序（0）的按创建顺序排在后面
+const (
+	// ReorderGap 重新分配权重时相邻两项的间隔，留出的空隙使之后移动一项通常只需改写这一项
+	ReorderGap int64 = 1024
+	// MaxReorderItems 一次最多排序的项数
+	MaxReorderItems = 1000
+)
+
+var (
+	ErrReorderEmpty     = errors.New("排序列表不能为空")
+	ErrReorderTooMany   = errors.New("一次最多排序1000项")
+	ErrReorderDuplicate = errors.New("排序列表中有重复的ID")
+	// ErrReorderIncomplete 只排列其中一段时，段外相邻项的权重不参与计算，新权重可能与它们相同或越过它们
+	ErrReorderIncomplete = errors.New("排序列表必须包含全部项")
+)
+
+// ReorderRequest 按给定顺序排列菜品或种类，IDs 为拖动后的完整列表：菜品为用户的全部菜品，种类为同一上级下的全部子种类
+type ReorderRequest struct {
+	UserID int64   `json:"-"`
+	IDs    []int64 `json:"ids" validate:"required,max=1000"`
+}
+
+// Validate 校验排序列表非空、不超过上限且没有重复
+func (r ReorderRequest) Validate() error {
+	if len(r.IDs) == 0 {
+		return ErrReorderEmpty
+	}
+	if len(r.IDs) > MaxReorderItems {
+		return ErrReorderTooMany
+	}
+	seen := make(map[int64]bool, len(r.IDs))
+	for _, id := range r.IDs {
+		if seen[id] {
+			return ErrReorderDuplicate
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// CheckComplete 校验 IDs 恰好包含 all 中的全部项，调用方先用 Validate 排除重复
+func (r ReorderRequest) CheckComplete(all []int64) error {
+	if len(all) != len(r.IDs) {
+		return ErrReorderIncomplete
+	}
+	included := make(map[int64]bool, len(r.IDs))
+	for _, id := range r.IDs {
+		included[id] = true
+	}
+	for _, id := range all {
+		if !included[id] {
+			return ErrReorderIncomplete
+		}
+	}
+	return nil
+}
+
+// PlanReorder 计算使 ids 按顺序排列所需的新权重，只返回需要改写的项。ids 必须是参与排序的全部项，见 CheckComplete。
+// 先找出当前权重已经严格递减的最长子序列，这些项保持不动，其余项插入相邻保留项之间的空隙；
+// 空隙不够或还没有排过序时，按 ReorderGap 的间隔重新分配全部项的权重
+func PlanReorder(ids []int64, weights map[int64]int64) map[int64]int64 {
+	n := len(ids)
+	w := make([]int64, n)
+	for i, id := range ids {
+		w[i] = weights[id]
+	}
+
+	// 最长严格递减子序列，只考虑已排过序（权重大于0）的项
+	length := make([]int, n)
+	prev := make([]int, n)
+	end := -1
+	for i := range w {
+		prev[i] = -1
+		if w[i] <= 0 {
+			continue
+		}
+		length[i] = 1
+		for j := 0; j < i; j++ {
+			if length[j] > 0 && w[j] > w[i] && length[j]+1 > length[i] {
+				length[i] = length[j] + 1
+				prev[i] = j
+			}
+		}
+		if end < 0 || length[i] > length[end] {
+			end = i
+		}
+	}
+	if end < 0 {
+		return rebalance(ids, w)
+	}
+	var anchors []int
+	for i := end; i >= 0; i = prev[i] {
+		anchors = append([]int{i}, anchors...)
+	}
+
+	next := make([]int64, n)
+	copy(next, w)
+	// 第一个保留项之前的项排在它上面
+	first := anchors[0]
+	for k := 0; k < first; k++ {
+		next[k] = w[first] + ReorderGap*int64(first-k)
+	}
+	// 相邻两个保留项之间的项平分空隙
+	for a := 0; a+1 < len(anchors); a++ {
+		i, j := anchors[a], anchors[a+1]
+		if j-i == 1 {
+			continue
+		}
+		step := (w[i] - w[j]) / int64(j-i)
+		if step < 1 {
+			return rebalance(ids, w)
+		}
+		for k := i + 1; k < j; k++ {
+			next[k] = w[i] - step*int64(k-i)
+		}
+	}
+	// 最后一个保留项之后的项排在它下面，权重保持大于0，排在未排序的项之前
+	last := anchors[len(anchors)-1]
+	if rest := n - 1 - last; rest > 0 {
+		step := w[last] / int64(rest+1)
+		if step > ReorderGap {
+			step = ReorderGap
+		}
+		if step < 1 {
+			return rebalance(ids, w)
+		}
+		for k := last + 1; k < n; k++ {
+			next[k] = w[last] - step*int64(k-last)
+		}
+	}
+
+	return changedWeights(ids, w, next)
+}
+
+// rebalance 按 ReorderGap 的间隔重新分配全部项的权重
+func rebalance(ids []int64, w []int64) map[int64]int64 {
+	next := make([]int64, len(ids))
+	for i := range ids {
+		next[i] = ReorderGap * int64(len(ids)-i)
+	}
+	return changedWeights(ids, w, next)
+}
+
+func changedWeights(ids []int64, before []int64, after []int64) map[int64]int64 {
+	changed := make(map[int64]int64)
+	for i, id := range ids {
+		if after[i] != before[i] {
+			changed[id] = after[i]
+		}
+	}
+	return changed
+}
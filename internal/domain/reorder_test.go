@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlanReorder(t *testing.T) {
+	tests := []struct {
+		name    string
+		ids     []int64
+		weights map[int64]int64
+		want    map[int64]int64
+	}{
+		{
+			name:    "都没有排过序时按间隔重新分配",
+			ids:     []int64{1, 2, 3},
+			weights: map[int64]int64{},
+			want:    map[int64]int64{1: 3 * ReorderGap, 2: 2 * ReorderGap, 3: ReorderGap},
+		},
+		{
+			name:    "顺序没有变化",
+			ids:     []int64{1, 2, 3},
+			weights: map[int64]int64{1: 3072, 2: 2048, 3: 1024},
+			want:    map[int64]int64{},
+		},
+		{
+			name:    "移到最前只改写移动的项",
+			ids:     []int64{3, 1, 2},
+			weights: map[int64]int64{1: 3072, 2: 2048, 3: 1024},
+			want:    map[int64]int64{3: 4096},
+		},
+		{
+			name:    "移到最后取最后保留项与0之间的值",
+			ids:     []int64{1, 3, 2},
+			weights: map[int64]int64{1: 3072, 2: 2048, 3: 1024},
+			want:    map[int64]int64{2: 512},
+		},
+		{
+			name:    "插入两个保留项之间",
+			ids:     []int64{1, 4, 2, 3},
+			weights: map[int64]int64{1: 4096, 2: 3072, 3: 2048, 4: 1024},
+			want:    map[int64]int64{4: 3584},
+		},
+		{
+			name:    "未排序的新项排在已排序的项后面",
+			ids:     []int64{1, 2, 3},
+			weights: map[int64]int64{1: 2048, 2: 1024},
+			want:    map[int64]int64{3: 512},
+		},
+		{
+			name:    "空隙不够时重新分配全部项",
+			ids:     []int64{1, 3, 2},
+			weights: map[int64]int64{1: 2, 2: 1, 3: 100},
+			want:    map[int64]int64{1: 3 * ReorderGap, 3: 2 * ReorderGap, 2: ReorderGap},
+		},
+		{
+			name:    "完整列表中段外的项也参与计算",
+			ids:     []int64{4, 2, 3, 1},
+			weights: map[int64]int64{1: 5120, 2: 4096, 3: 3072, 4: 2048},
+			want:    map[int64]int64{4: 5120, 1: 2048},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PlanReorder(tt.ids, tt.weights)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PlanReorder() = %v, want %v", got, tt.want)
+			}
+
+			// 改写后按 ids 的顺序权重严格递减且大于0
+			prev := int64(-1)
+			for i, id := range tt.ids {
+				w, ok := got[id]
+				if !ok {
+					w = tt.weights[id]
+				}
+				if w <= 0 || (i > 0 && w >= prev) {
+					t.Fatalf("weight of %d = %d after %d, want strictly decreasing positive weights", id, w, prev)
+				}
+				prev = w
+			}
+		})
+	}
+}
+
+func TestReorderRequestCheckComplete(t *testing.T) {
+	tests := []struct {
+		name string
+		ids  []int64
+		all  []int64
+		want error
+	}{
+		{name: "顺序不同的完整列表", ids: []int64{3, 1, 2}, all: []int64{1, 2, 3}, want: nil},
+		{name: "缺少项", ids: []int64{3, 1}, all: []int64{1, 2, 3}, want: ErrReorderIncomplete},
+		{name: "包含不在范围内的项", ids: []int64{3, 1, 4}, all: []int64{1, 2, 3}, want: ErrReorderIncomplete},
+		{name: "多出项", ids: []int64{1, 2, 3, 4}, all: []int64{1, 2, 3}, want: ErrReorderIncomplete},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (ReorderRequest{IDs: tt.ids}).CheckComplete(tt.all); got != tt.want {
+				t.Errorf("CheckComplete() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package domain
+
+import "errors"
+
+// ShareTargetType 分享对象类型
+type ShareTargetType string
+
+const (
+	ShareTargetDish     ShareTargetType = "dish"      // 单个菜品
+	ShareTargetDishType ShareTargetType = "dish_type" // 一个种类下的全部菜品
+)
+
+// MaxSharesPerUser 每个用户最多持有的分享链接数量
+const MaxSharesPerUser = 100
+
+// Share 只读分享链接，持有令牌的任何人都可以查看，吊销时删除
+type Share struct {
+	ID           int64           `json:"id"`
+	UserID       int64           `json:"-"`
+	Token        string          `json:"token"`
+	TargetType   ShareTargetType `json:"target_type"`
+	TargetID     int64           `json:"target_id"`
+	ExpiresAt    int64           `json:"expires_at"` // 过期时间，0 表示永不过期
+	Views        int64           `json:"views"`      // 访问次数
+	LastViewedAt int64           `json:"last_viewed_at"`
+	Ctime        int64           `json:"ctime"`
+}
+
+// Expired 判断分享在 now 时刻是否已过期
+func (s Share) Expired(now int64) bool {
+	return s.ExpiresAt > 0 && now >= s.ExpiresAt
+}
+
+// CreateShareRequest 创建分享链接请求
+type CreateShareRequest struct {
+	UserID     int64           `json:"-"`
+	TargetType ShareTargetType `json:"target_type" validate:"required,oneof=dish dish_type"`
+	TargetID   int64           `json:"target_id" validate:"required"`
+	ExpiresAt  int64           `json:"expires_at"` // 过期时间（Unix 秒），不填表示永不过期
+}
+
+// SharedDish 分享页中的菜品，不包含所有者等私有信息
+type SharedDish struct {
+	ID              int64      `json:"id"`
+	Name            string     `json:"name"`
+	Desc            string     `json:"desc"`
+	Price           int64      `json:"price"`
+	Img             string     `json:"img"`
+	Calorie         int64      `json:"calorie"`
+	Ingredients     []string   `json:"ingredients"`
+	Allergens       []Allergen `json:"allergens"`
+	RatingAvg       float64    `json:"rating_avg"`
+	RatingCount     int64      `json:"rating_count"`
+	TypeName        string     `json:"type_name"`
+	TypeDescription string     `json:"type_description"`
+	TypeIcon        string     `json:"type_icon"`
+	TypeColor       string     `json:"type_color"`
+}
+
+// SharedMenu 分享页中的种类及其菜品
+type SharedMenu struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Icon        string       `json:"icon"`
+	Color       string       `json:"color"`
+	Dishes      []SharedDish `json:"dishes"`
+}
+
+// SharedContent 公开分享页内容，按 target_type 返回 dish 或 menu 之一
+type SharedContent struct {
+	TargetType ShareTargetType `json:"target_type"`
+	Dish       *SharedDish     `json:"dish,omitempty"`
+	Menu       *SharedMenu     `json:"menu,omitempty"`
+	ExpiresAt  int64           `json:"expires_at"`
+}
+
+var (
+	// ErrShareNotFound 分享不存在、已吊销、已过期，或分享的内容已被删除
+	ErrShareNotFound       = errors.New("分享不存在或已失效")
+	ErrShareExpiryInvalid  = errors.New("过期时间必须晚于当前时间")
+	ErrShareLimitExceeded  = errors.New("分享链接数量已达上限")
+	ErrShareTargetNotOwned = errors.New("只能分享自己的菜品或种类")
+)
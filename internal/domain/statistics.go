@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// StatisticsPeriod 统计周期
+type StatisticsPeriod string
+
+const (
+	StatisticsPeriodWeek  StatisticsPeriod = "week"
+	StatisticsPeriodMonth StatisticsPeriod = "month"
+)
+
+// 统计周期数量限制
+const (
+	DefaultStatisticsPeriods = 12
+	MaxStatisticsPeriods     = 104
+)
+
+// ErrStatisticsPeriodInvalid 统计周期无效
+var ErrStatisticsPeriodInvalid = errors.New("不支持的统计周期")
+
+// ParseStatisticsPeriod 解析统计周期，默认按月
+func ParseStatisticsPeriod(s string) (StatisticsPeriod, error) {
+	switch period := StatisticsPeriod(s); period {
+	case "":
+		return StatisticsPeriodMonth, nil
+	case StatisticsPeriodWeek, StatisticsPeriodMonth:
+		return period, nil
+	default:
+		return "", ErrStatisticsPeriodInvalid
+	}
+}
+
+// Since 返回包含当前周期在内、往前 periods 个周期的起始时间
+func (p StatisticsPeriod) Since(now time.Time, periods int) time.Time {
+	year, month, day := now.Date()
+	if p == StatisticsPeriodWeek {
+		// 以周一作为一周的开始，与 ISO 周保持一致
+		weekday := (int(now.Weekday()) + 6) % 7
+		return time.Date(year, month, day-weekday-7*(periods-1), 0, 0, 0, 0, now.Location())
+	}
+	return time.Date(year, month-time.Month(periods-1), 1, 0, 0, 0, 0, now.Location())
+}
+
+// DishesStatisticsQuery 菜品统计查询条件
+type DishesStatisticsQuery struct {
+	UserID  int64            `json:"user_id"`
+	Period  StatisticsPeriod `json:"period"`
+	Periods int              `json:"periods"`
+	GroupBy DishesGroupBy    `json:"group_by"`
+	Weeks   int              `json:"weeks"` // 新建菜品周序列的周数
+}
+
+// CookingPeriodStat 按周期汇总的烹饪记录，卡路里与花费按份数累计
+type CookingPeriodStat struct {
+	Period   string `json:"period"` // 周期标识，如 2024-05 或 2024-W19
+	Times    int64  `json:"times"`
+	Servings int64  `json:"servings"`
+	Calorie  int64  `json:"calorie"`
+	Spend    int64  `json:"spend"`
+}
+
+// Key 返回时间所在周期的标识，与 SQL 中按 %Y-%m 或 %x-W%v 格式化的结果一致
+func (p StatisticsPeriod) Key(t time.Time) string {
+	if p == StatisticsPeriodWeek {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	}
+	return t.Format("2006-01")
+}
+
+// PeriodCount 按周期计数
+type PeriodCount struct {
+	Period string `json:"period"`
+	Count  int64  `json:"count"`
+}
+
+// FillPeriodCounts 生成从 since 所在周期到 now 所在周期的连续序列，缺失的周期计数为0
+func FillPeriodCounts(period StatisticsPeriod, since, now time.Time, counts []PeriodCount) []PeriodCount {
+	byPeriod := make(map[string]int64, len(counts))
+	for _, c := range counts {
+		byPeriod[c.Period] = c.Count
+	}
+
+	var result []PeriodCount
+	last := period.Key(now)
+	for t := since; ; {
+		key := period.Key(t)
+		result = append(result, PeriodCount{Period: key, Count: byPeriod[key]})
+		if key == last || t.After(now) {
+			break
+		}
+		if period == StatisticsPeriodWeek {
+			t = t.AddDate(0, 0, 7)
+		} else {
+			t = t.AddDate(0, 1, 0)
+		}
+	}
+	return result
+}
+
+// DishesGroupBy 菜品统计的分组方式
+type DishesGroupBy string
+
+const (
+	DishesGroupByNone  DishesGroupBy = ""
+	DishesGroupByType  DishesGroupBy = "type"  // 按菜品种类
+	DishesGroupByMonth DishesGroupBy = "month" // 按创建月份
+)
+
+// ErrStatisticsGroupByInvalid 统计分组方式无效
+var ErrStatisticsGroupByInvalid = errors.New("不支持的统计分组方式")
+
+// ParseDishesGroupBy 解析统计分组方式，默认不分组
+func ParseDishesGroupBy(s string) (DishesGroupBy, error) {
+	switch groupBy := DishesGroupBy(s); groupBy {
+	case DishesGroupByNone, DishesGroupByType, DishesGroupByMonth:
+		return groupBy, nil
+	default:
+		return "", ErrStatisticsGroupByInvalid
+	}
+}
+
+// DistributionStat 数值分布，百分位按线性插值计算，与 PERCENTILE_CONT 一致
+type DistributionStat struct {
+	Total  int64   `json:"total"`
+	Min    int64   `json:"min"`
+	Max    int64   `json:"max"`
+	Avg    float64 `json:"avg"`
+	Median float64 `json:"median"`
+	P25    float64 `json:"p25"`
+	P75    float64 `json:"p75"`
+	P90    float64 `json:"p90"`
+	P95    float64 `json:"p95"`
+}
+
+// DishesAggregate 一组菜品的价格与卡路里分布
+type DishesAggregate struct {
+	Key     string           `json:"key,omitempty"`   // 分组标识：种类ID或月份 2024-05，整体汇总时为空
+	Label   string           `json:"label,omitempty"` // 分组名称：种类名称或月份
+	Count   int64            `json:"count"`
+	Price   DistributionStat `json:"price"`
+	Calorie DistributionStat `json:"calorie"`
+}
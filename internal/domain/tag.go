@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Tag 用户自定义标签，一道菜可以打多个标签
+type Tag struct {
+	ID        int64  `json:"id"`
+	UserID    int64  `json:"user_id"`
+	Name      string `json:"name"`
+	Color     string `json:"color"`
+	DishCount int64  `json:"dish_count"` // 打了该标签的菜品数
+	Ctime     int64  `json:"ctime"`
+	Utime     int64  `json:"utime"`
+}
+
+// CreateTagRequest 创建标签请求
+type CreateTagRequest struct {
+	UserID int64  `json:"-"`
+	Name   string `json:"name" validate:"required,max=30"`
+	Color  string `json:"color" validate:"max=20"`
+}
+
+// UpdateTagRequest 更新标签请求
+type UpdateTagRequest struct {
+	ID     int64  `json:"-"`
+	UserID int64  `json:"-"`
+	Name   string `json:"name" validate:"required,max=30"`
+	Color  string `json:"color" validate:"max=20"`
+}
+
+// TagDishesRequest 为标签批量关联菜品请求
+type TagDishesRequest struct {
+	DishIDs []int64 `json:"dish_ids" validate:"required,max=100"`
+}
+
+// TagMatchMode 多个标签过滤时的匹配方式
+type TagMatchMode string
+
+const (
+	TagMatchAny TagMatchMode = "any" // 命中任意一个标签即可（OR）
+	TagMatchAll TagMatchMode = "all" // 需同时具有所有标签（AND）
+)
+
+// 标签字段限制
+const (
+	MaxTagNameLength  = 30
+	MaxTagColorLength = 20
+	MaxTagFilterIDs   = 20
+	MaxTagDishesBatch = 100
+)
+
+// 错误定义
+var (
+	ErrTagNotFound         = errors.New("标签不存在")
+	ErrTagNameEmpty        = errors.New("标签名称不能为空")
+	ErrTagNameTooLong      = errors.New("标签名称过长")
+	ErrTagNameExists       = errors.New("标签名称已存在")
+	ErrTagUserMismatch     = errors.New("标签不属于该用户")
+	ErrTagDishesEmpty      = errors.New("未指定菜品")
+	ErrTagDishesTooMany    = errors.New("单次关联的菜品过多")
+	ErrTagFilterInvalid    = errors.New("标签过滤条件无效")
+	ErrTagMatchModeInvalid = errors.New("不支持的标签匹配方式")
+)
+
+// NewTag 创建新的标签实例
+func NewTag(req CreateTagRequest) (*Tag, error) {
+	if req.UserID <= 0 {
+		return nil, errors.New("用户ID无效")
+	}
+	name, err := normalizeTagName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Color) > MaxTagColorLength {
+		return nil, errors.New("标签颜色过长")
+	}
+
+	now := time.Now().Unix()
+	return &Tag{
+		UserID: req.UserID,
+		Name:   name,
+		Color:  req.Color,
+		Ctime:  now,
+		Utime:  now,
+	}, nil
+}
+
+// Update 更新标签
+func (t *Tag) Update(req UpdateTagRequest) error {
+	if t.UserID != req.UserID {
+		return ErrTagUserMismatch
+	}
+	name, err := normalizeTagName(req.Name)
+	if err != nil {
+		return err
+	}
+	if len(req.Color) > MaxTagColorLength {
+		return errors.New("标签颜色过长")
+	}
+
+	t.Name = name
+	t.Color = req.Color
+	t.Utime = time.Now().Unix()
+	return nil
+}
+
+// normalizeTagName 去除首尾空白并校验标签名称
+func normalizeTagName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrTagNameEmpty
+	}
+	if utf8.RuneCountInString(name) > MaxTagNameLength {
+		return "", ErrTagNameTooLong
+	}
+	return name, nil
+}
+
+// ParseTagMatchMode 解析标签匹配方式，默认任意匹配
+func ParseTagMatchMode(s string) (TagMatchMode, error) {
+	switch mode := TagMatchMode(s); mode {
+	case "":
+		return TagMatchAny, nil
+	case TagMatchAny, TagMatchAll:
+		return mode, nil
+	default:
+		return "", ErrTagMatchModeInvalid
+	}
+}
+
+// ParseTagIDs 解析逗号分隔的标签ID列表
+func ParseTagIDs(s string) ([]int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(s, ",")
+	ids := make([]int64, 0, len(parts))
+	for _, part := range parts {
+		id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil {
+			return nil, ErrTagFilterInvalid
+		}
+		ids = append(ids, id)
+	}
+	return NormalizeTagFilter(ids)
+}
+
+// NormalizeTagFilter 校验标签过滤ID并去重，保持原有顺序
+func NormalizeTagFilter(ids []int64) ([]int64, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	result := make([]int64, 0, len(ids))
+	seen := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, ErrTagFilterInvalid
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+	if len(result) > MaxTagFilterIDs {
+		return nil, ErrTagFilterInvalid
+	}
+	return result, nil
+}
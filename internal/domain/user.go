@@ -1,13 +1,121 @@
 package domain
 
+import "errors"
+
 type CreateUserInput struct {
 	ID       uint64 `json:"id"`
 	Name     string `json:"name" validate:"required,min=2,max=50"`
 	Password string `json:"password" validate:"required"`
 	Avatar   string `json:"avatar"`
-	Status   int64  `json:"status" validate:"required,oneof=0 1"`
 }
 
 type CreateUserOutput struct {
 	Token string `json:"token"`
 }
+
+// User 用户领域模型
+type User struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	Nickname      string `json:"nickname"` // 显示名称，为空时显示用户名
+	Password      string `json:"-"`        // 加盐哈希后的密码
+	Avatar        string `json:"avatar"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"` // 只有已验证的邮箱可以用来重置密码
+	PendingEmail  string `json:"pending_email"`  // 已设置但还没有验证的邮箱，验证后替换 Email
+	Role          Role   `json:"role"`
+	Status        int64  `json:"status"`
+	LastLogin     int64  `json:"last_login"`
+	Ctime         int64  `json:"ctime"`
+	Utime         int64  `json:"utime"`
+	// TokenVersion 令牌版本，修改密码时递增，签发时版本不一致的刷新令牌失效
+	TokenVersion int64 `json:"-"`
+}
+
+// Role 用户角色
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
+// 用户状态，对应 users.status
+const (
+	UserStatusDisabled int64 = 0
+	UserStatusNormal   int64 = 1
+)
+
+// UserAuthState 鉴权时读取的账号状态，禁用、修改角色与吊销令牌后需要立即生效
+type UserAuthState struct {
+	Status       int64 `json:"status"`
+	Role         Role  `json:"role"`
+	TokenVersion int64 `json:"tokenVersion"`
+}
+
+// UserQuery 用户列表查询条件
+type UserQuery struct {
+	Keyword string `json:"keyword"` // 按用户名与显示名称模糊匹配
+	Offset  int    `json:"offset"`
+	Limit   int    `json:"limit"`
+}
+
+// UserListResponse 用户列表响应
+type UserListResponse struct {
+	List  []User `json:"list"`
+	Total int64  `json:"total"`
+	Page  int    `json:"page"`
+	Size  int    `json:"size"`
+}
+
+// UpdateUserStatusRequest 管理员启用或禁用账号
+type UpdateUserStatusRequest struct {
+	Status *int64 `json:"status" validate:"required,oneof=0 1"`
+}
+
+// UpdateUserRoleRequest 管理员修改用户角色
+type UpdateUserRoleRequest struct {
+	Role Role `json:"role" validate:"required,oneof=user admin"`
+}
+
+// AdminOverview 系统概览
+type AdminOverview struct {
+	Users  int64 `json:"users"`
+	Dishes int64 `json:"dishes"`
+}
+
+// UpdateProfileRequest 更新个人资料请求，未传的字段保持不变
+type UpdateProfileRequest struct {
+	UserID   int64   `json:"-"`
+	Nickname *string `json:"nickname" validate:"omitempty,max=50"`
+	Avatar   *string `json:"avatar" validate:"omitempty,max=200"`
+}
+
+// ChangePasswordRequest 修改密码请求
+type ChangePasswordRequest struct {
+	UserID      int64  `json:"-"`
+	OldPassword string `json:"old_password" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=6,max=72"`
+}
+
+// DeleteAccountRequest 注销账号请求，需要再次输入密码确认
+type DeleteAccountRequest struct {
+	UserID   int64  `json:"-"`
+	Password string `json:"password" validate:"required"`
+}
+
+// RefreshTokenRequest 刷新令牌请求
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+var (
+	ErrUserNotFound      = errors.New("用户不存在")
+	ErrPasswordIncorrect = errors.New("密码错误")
+	ErrUserDisabled      = errors.New("账号已禁用")
+	ErrPasswordUnchanged = errors.New("新密码不能与原密码相同")
+	// ErrAdminSelfModify 管理员不能禁用自己或取消自己的管理员角色，避免系统中没有可用的管理员
+	ErrAdminSelfModify = errors.New("不能修改自己的状态或角色")
+	// ErrRefreshTokenInvalid 刷新令牌无效、过期或已被吊销
+	ErrRefreshTokenInvalid = errors.New("登录已失效，请重新登录")
+)
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// 用户名长度限制，按规范化后的字符数计算
+const (
+	UsernameMinLength = 2
+	UsernameMaxLength = 50
+)
+
+var (
+	ErrUsernameInvalid   = errors.New("用户名需为2到50个字符，且不能包含空白或控制字符")
+	ErrUsernameReserved  = errors.New("该用户名为系统保留，请换一个")
+	ErrUserAlreadyExists = errors.New("用户名已被注册")
+)
+
+// reservedUsernames 系统保留的用户名，按规范化后的形式比较
+var reservedUsernames = map[string]struct{}{
+	"admin":         {},
+	"administrator": {},
+	"root":          {},
+	"system":        {},
+	"sysadmin":      {},
+	"superuser":     {},
+	"support":       {},
+	"help":          {},
+	"official":      {},
+	"moderator":     {},
+	"staff":         {},
+	"api":           {},
+	"me":            {},
+	"login":         {},
+	"logout":        {},
+	"register":      {},
+	"null":          {},
+	"undefined":     {},
+	"anonymous":     {},
+	"guest":         {},
+	"loverrecipe":   {},
+	"管理员":           {},
+	"系统":            {},
+	"客服":            {},
+	"官方":            {},
+}
+
+// caseFolder 大小写折叠，不区分语言
+var caseFolder = cases.Fold()
+
+// NormalizeUsername 规范化用户名：去除首尾空白，做 Unicode NFKC 规范化并折叠大小写，
+// 使全角与半角、大小写不同的写法视为同一个用户名
+func NormalizeUsername(name string) string {
+	name = strings.TrimSpace(name)
+	name = norm.NFKC.String(name)
+	name = caseFolder.String(name)
+	// 折叠后可能产生新的组合字符，再规范化一次
+	return norm.NFKC.String(name)
+}
+
+// ValidateUsername 校验规范化后的用户名：长度、字符与保留名
+func ValidateUsername(name string) error {
+	length := utf8.RuneCountInString(name)
+	if length < UsernameMinLength || length > UsernameMaxLength {
+		return ErrUsernameInvalid
+	}
+	for _, r := range name {
+		if unicode.IsSpace(r) || unicode.IsControl(r) || unicode.Is(unicode.Cf, r) {
+			return ErrUsernameInvalid
+		}
+	}
+	if _, ok := reservedUsernames[name]; ok {
+		return ErrUsernameReserved
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package ioc
 
 import (
 	"context"
+	"sync"
+
 	"github.com/gotomicro/ego/server/egin"
 	"github.com/gotomicro/ego/task/ecron"
 )
@@ -14,12 +16,22 @@ type App struct {
 	HttpServer *egin.Component
 	Tasks      []Task
 	Crons      []ecron.Ecron
+
+	tasks sync.WaitGroup `wire:"-"`
 }
 
+// StartTasks 在后台启动任务，ctx 取消后任务自行退出
 func (a *App) StartTasks(ctx context.Context) {
 	for _, t := range a.Tasks {
+		a.tasks.Add(1)
 		go func(t Task) {
+			defer a.tasks.Done()
 			t.Start(ctx)
 		}(t)
 	}
 }
+
+// WaitTasks 等待全部任务退出
+func (a *App) WaitTasks() {
+	a.tasks.Wait()
+}
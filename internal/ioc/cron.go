@@ -1,7 +1,40 @@
 package ioc
 
-import "github.com/gotomicro/ego/task/ecron"
+import (
+	"context"
+	"time"
 
-func Crons() []ecron.Ecron {
-	return []ecron.Ecron{}
+	"github.com/gotomicro/ego/core/econf"
+	"github.com/gotomicro/ego/core/elog"
+	"github.com/gotomicro/ego/task/ecron"
+
+	"loverrecipe/internal/services/nutrition"
+)
+
+// defaultNutritionSnapshotSpec 每天 00:05 生成前一天的饮食快照
+const defaultNutritionSnapshotSpec = "5 0 * * *"
+
+func Crons(nutritionService nutrition.Service) []ecron.Ecron {
+	return []ecron.Ecron{
+		initNutritionSnapshotCron(nutritionService),
+	}
+}
+
+// initNutritionSnapshotCron 每日饮食快照任务，配置 cron.nutritionSnapshot.spec 可覆盖执行时间
+func initNutritionSnapshotCron(nutritionService nutrition.Service) ecron.Ecron {
+	opts := []ecron.Option{
+		ecron.WithJob(func(ctx context.Context) error {
+			day := time.Now().AddDate(0, 0, -1)
+			count, err := nutritionService.SnapshotDay(ctx, day)
+			if err != nil {
+				return err
+			}
+			elog.Info("nutrition snapshot done", elog.String("day", day.Format("2006-01-02")), elog.Int("count", count))
+			return nil
+		}),
+	}
+	if econf.GetString("cron.nutritionSnapshot.spec") == "" {
+		opts = append(opts, ecron.WithSpec(defaultNutritionSnapshotSpec))
+	}
+	return ecron.Load("cron.nutritionSnapshot").Build(opts...)
 }
@@ -0,0 +1,38 @@
+package ioc
+
+import (
+	"github.com/ego-component/egorm"
+	"github.com/gotomicro/ego/core/econf"
+	"github.com/gotomicro/ego/core/elog"
+	"github.com/redis/go-redis/v9"
+
+	"loverrecipe/internal/repository"
+	"loverrecipe/internal/repository/cache"
+)
+
+// InitDishesCache 初始化菜品缓存，读取配置 cache.dishes。
+// 未开启缓存时同样返回实例，注销账号等绕过菜品仓储的写操作仍可以清除残留的条目
+func InitDishesCache(cmd redis.Cmdable) cache.DishesCache {
+	return cache.NewDishesRedisCache(cmd, dishesCacheConfig())
+}
+
+// InitDishesRepository 初始化菜品仓储，配置 cache.dishes.enabled 开启 Redis 缓存
+func InitDishesRepository(db *egorm.Component, dishesCache cache.DishesCache) repository.DishesRepository {
+	repo := repository.NewDishesRepository(db)
+
+	cfg := dishesCacheConfig()
+	if !cfg.Enabled {
+		return repo
+	}
+
+	elog.Info("dishes cache enabled", elog.String("ttl", cfg.TTL.String()))
+	return repository.NewCachedDishesRepository(repo, dishesCache)
+}
+
+func dishesCacheConfig() cache.Config {
+	var cfg cache.Config
+	if err := econf.UnmarshalKey("cache.dishes", &cfg); err != nil {
+		panic(err)
+	}
+	return cfg
+}
@@ -1,52 +1,245 @@
 package ioc
 
 import (
+	"github.com/gin-gonic/gin"
+	"github.com/gotomicro/ego/core/econf"
 	"github.com/gotomicro/ego/server/egin"
+	"github.com/redis/go-redis/v9"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"loverrecipe/internal/controller"
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/middleware"
+	"loverrecipe/internal/services/apitoken"
+	"loverrecipe/internal/services/user"
+	"loverrecipe/internal/token"
 )
 
-func InitHTTP(d *controller.DishController, user *controller.UserController) *egin.Component {
+func InitHTTP(d *controller.DishController, dishType *controller.DishTypeController, cooking *controller.CookingLogController, tag *controller.TagController,
+	nutrition *controller.NutritionController, user *controller.UserController, admin *controller.AdminController,
+	apiToken *controller.APITokenController, share *controller.ShareController, cmd redis.Cmdable, jwt *token.JwtTokenHandler, users user.Service,
+	apiTokens apitoken.Service) *egin.Component {
 	server := egin.Load("server.http").Build()
+	// gin 默认信任所有代理，任何人都能用 X-Forwarded-For 伪造IP绕过按IP的限流与登录锁定。
+	// 只信任 server.http.trustedProxies 中的代理，未配置时取连接的对端地址
+	if err := server.SetTrustedProxies(econf.GetStringSlice("server.http.trustedProxies")); err != nil {
+		panic(err)
+	}
+	// 记录请求来源的IP、User-Agent 与链路ID，供审计日志使用
+	server.Use(middleware.RequestMeta())
+	// 需要登录的接口从 Authorization 请求头解析用户，并拒绝已禁用的账号
+	authBuilder := middleware.NewAuthBuilder(jwt, users, apiTokens)
+	auth := authBuilder.Build()
+	// 菜品、种类、标签与烹饪记录接口同时接受个人访问令牌，按路由检查令牌的读写权限
+	apiAuth := authBuilder.AllowAPIToken().Build()
+	read := middleware.RequireScope(domain.ScopeDishesRead)
+	write := middleware.RequireScope(domain.ScopeDishesWrite)
+	// 创建类接口支持 Idempotency-Key，客户端超时重试时不会重复创建
+	idempotent := middleware.NewIdempotencyBuilder(cmd).Build()
 	// 添加 Swagger 路由
 	server.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	dishesGroup := server.Group("/api/v1/dishes")
+	// 限流挂在鉴权之后，按用户计数的规则才能取到 user_id
+	dishesGroup := server.Group("/api/v1/dishes", apiAuth, rateLimit(cmd, "dishes"))
 	{
 		// 创建菜品
-		dishesGroup.POST("", d.CreateDishes)
+		dishesGroup.POST("", write, idempotent, d.CreateDishes)
 
 		// 获取菜品详情
-		dishesGroup.GET("/:id", d.GetDishesByID)
+		dishesGroup.GET("/:id", read, d.GetDishesByID)
 
 		// 更新菜品
-		dishesGroup.PUT("/:id", d.UpdateDishes)
+		dishesGroup.PUT("/:id", write, d.UpdateDishes)
 
 		// 删除菜品
-		dishesGroup.DELETE("/:id", d.DeleteDishes)
+		dishesGroup.DELETE("/:id", write, d.DeleteDishes)
 
 		// 获取菜品列表
-		dishesGroup.GET("", d.ListDishes)
+		dishesGroup.GET("", read, d.ListDishes)
 
 		// 搜索菜品
-		dishesGroup.GET("/search", d.SearchDishes)
+		dishesGroup.GET("/search", read, d.SearchDishes)
+
+		// 随机挑选菜品
+		dishesGroup.GET("/random", read, d.RandomDishes)
 
 		// 获取菜品统计
-		dishesGroup.GET("/statistics", d.GetDishesStatistics)
+		dishesGroup.GET("/statistics", read, d.GetDishesStatistics)
 
 		// 按种类获取菜品
-		dishesGroup.GET("/type/:typeId", d.GetDishesByType)
+		dishesGroup.GET("/type/:typeId", read, d.GetDishesByType)
 
 		// 获取带种类信息的菜品
-		dishesGroup.GET("/with-type", d.GetDishesWithTypeInfo)
+		dishesGroup.GET("/with-type", read, d.GetDishesWithTypeInfo)
+
+		// 导出菜品
+		dishesGroup.GET("/export", read, d.ExportDishes)
+
+		// 导入菜品
+		dishesGroup.POST("/import", write, d.ImportDishes)
+
+		// 导入网页菜谱
+		dishesGroup.POST("/import/recipe", write, d.ImportRecipe)
+
+		// 复制菜品到自己的菜品中，查看复制来源是否有改动
+		dishesGroup.POST("/:id/clone", write, idempotent, d.CloneDishes)
+		dishesGroup.GET("/:id/source", read, d.GetDishesSourceStatus)
+
+		// 查找名称相同或相近的菜品，合并重复菜品
+		dishesGroup.GET("/duplicates", read, d.FindDuplicateDishes)
+		dishesGroup.POST("/:id/merge", write, d.MergeDishes)
+
+		// 拖动调整菜品顺序
+		dishesGroup.PUT("/order", write, d.ReorderDishes)
+
+		// 收藏与取消收藏菜品
+		dishesGroup.POST("/:id/favorite", write, d.FavoriteDishes)
+		dishesGroup.DELETE("/:id/favorite", write, d.UnfavoriteDishes)
+
+		// 菜品评分
+		dishesGroup.PUT("/:id/rating", write, d.RateDishes)
+		dishesGroup.DELETE("/:id/rating", write, d.DeleteDishesRating)
+		dishesGroup.GET("/:id/ratings", read, d.ListDishesRatings)
+	}
+
+	// 烹饪记录会修改菜品的烹饪次数，与菜品使用相同的访问令牌读写权限
+	cookingGroup := server.Group("/api/v1/cooking-logs", apiAuth, rateLimit(cmd, "cookingLogs"))
+	{
+		// 记录一次烹饪
+		cookingGroup.POST("", write, cooking.CreateCookingLog)
+
+		// 获取烹饪记录列表
+		cookingGroup.GET("", read, cooking.ListCookingLogs)
+
+		// 获取、更新与删除烹饪记录
+		cookingGroup.GET("/:id", read, cooking.GetCookingLog)
+		cookingGroup.PUT("/:id", write, cooking.UpdateCookingLog)
+		cookingGroup.DELETE("/:id", write, cooking.DeleteCookingLog)
+	}
+
+	// 菜品种类与菜品共用限流与访问令牌的读写权限
+	dishTypesGroup := server.Group("/api/v1/dish-types", apiAuth, rateLimit(cmd, "dishes"))
+	{
+		// 创建种类，可指定上级种类
+		dishTypesGroup.POST("", write, dishType.CreateDishType)
+
+		// 获取完整的种类树及菜品数
+		dishTypesGroup.GET("/tree", read, dishType.GetDishTypeTree)
+
+		// 调整种类的上级
+		dishTypesGroup.PUT("/:id/parent", write, dishType.MoveDishType)
+
+		// 拖动调整种类顺序
+		dishTypesGroup.PUT("/order", write, dishType.ReorderDishTypes)
+	}
+
+	// 标签用于整理菜品，关联与取消关联会修改菜品数据，与菜品使用相同的访问令牌读写权限
+	tagsGroup := server.Group("/api/v1/tags", apiAuth, rateLimit(cmd, "tags"))
+	{
+		// 标签的增删改查
+		tagsGroup.POST("", write, tag.CreateTag)
+		tagsGroup.GET("", read, tag.ListTags)
+		tagsGroup.PUT("/:id", write, tag.UpdateTag)
+		tagsGroup.DELETE("/:id", write, tag.DeleteTag)
+
+		// 为标签关联与取消关联菜品
+		tagsGroup.POST("/:id/dishes", write, tag.AttachDishes)
+		tagsGroup.DELETE("/:id/dishes/:dishId", write, tag.DetachDish)
+	}
+
+	nutritionGroup := server.Group("/api/v1/nutrition", auth, rateLimit(cmd, "nutrition"))
+	{
+		// 卡路里目标与花费预算
+		nutritionGroup.PUT("/goals", nutrition.SetGoal)
+		nutritionGroup.GET("/goals", nutrition.GetGoal)
+
+		// 饮食记录
+		nutritionGroup.POST("/meals", nutrition.RecordMeal)
+		nutritionGroup.GET("/meals", nutrition.ListMeals)
+		nutritionGroup.DELETE("/meals/:id", nutrition.DeleteMeal)
+
+		// 目标完成情况与每日趋势
+		nutritionGroup.GET("/progress", nutrition.GetProgress)
+		nutritionGroup.GET("/trend", nutrition.Trend)
 	}
 
 	{
 		// 用户注册
-		usersGroup := server.Group("/api/v1/user")
-		usersGroup.POST("/register", user.Register)
+		usersGroup := server.Group("/api/v1/user", rateLimit(cmd, "user"))
+		usersGroup.POST("/register", rateLimit(cmd, "register"), idempotent, user.Register)
+
+		// 用户登录，失败次数过多时按用户名与IP锁定
+		usersGroup.POST("/login", user.Login)
+		usersGroup.POST("/refresh", user.RefreshToken)
+		usersGroup.POST("/logout", auth, user.Logout)
+
+		// 验证邮箱，以及通过已验证的邮箱找回密码
+		usersGroup.POST("/email/verify", user.VerifyEmail)
+		usersGroup.POST("/password/forgot", rateLimit(cmd, "password"), user.ForgotPassword)
+		usersGroup.POST("/password/reset", rateLimit(cmd, "password"), user.ResetPassword)
+
+		// 个人资料、修改密码与注销账号，需要登录
+		meGroup := usersGroup.Group("/me", auth)
+		meGroup.GET("", user.GetProfile)
+		meGroup.PUT("", user.UpdateProfile)
+		meGroup.PUT("/password", user.ChangePassword)
+		meGroup.DELETE("", user.DeleteAccount)
+		meGroup.PUT("/email", user.UpdateEmail)
+		meGroup.POST("/email/verification", user.ResendVerification)
+
+		// 个人访问令牌，只能使用登录令牌管理
+		meGroup.POST("/tokens", apiToken.CreateAPIToken)
+		meGroup.GET("/tokens", apiToken.ListAPITokens)
+		meGroup.DELETE("/tokens/:id", apiToken.RevokeAPIToken)
+
+		// 饮食档案，需要登录
+		usersGroup.GET("/dietary-profile", auth, user.GetDietaryProfile)
+		usersGroup.PUT("/dietary-profile", auth, user.UpdateDietaryProfile)
+	}
+
+	sharesGroup := server.Group("/api/v1/shares", auth, rateLimit(cmd, "shares"))
+	{
+		// 创建、查看与吊销分享链接
+		sharesGroup.POST("", share.CreateShare)
+		sharesGroup.GET("", share.ListShares)
+		sharesGroup.DELETE("/:id", share.RevokeShare)
+	}
+
+	// 公开的分享页，无需登录
+	server.GET("/s/:token", rateLimit(cmd, "shareView"), share.ViewShare)
+
+	{
+		// 管理后台，需要管理员角色
+		adminGroup := server.Group("/api/v1/admin", auth, middleware.RequireRoles(string(domain.RoleAdmin)))
+
+		// 用户管理：列表与搜索、启用与禁用、修改角色、强制下线
+		adminGroup.GET("/users", admin.ListUsers)
+		adminGroup.PUT("/users/:id/status", admin.UpdateUserStatus)
+		adminGroup.PUT("/users/:id/role", admin.UpdateUserRole)
+		adminGroup.POST("/users/:id/logout", admin.ForceLogout)
+
+		// 系统概览
+		adminGroup.GET("/overview", admin.Overview)
+
+		// 查看与解除登录锁定
+		adminGroup.GET("/login-lockouts", admin.ListLoginLockouts)
+		adminGroup.DELETE("/login-lockouts", admin.ClearLoginLockout)
+
+		// 审计日志
+		adminGroup.GET("/audit-logs", admin.ListAuditLogs)
 	}
 
 	return server
 }
+
+// rateLimit 按配置 ratelimit.<name> 构造路由分组的限流中间件，未配置时不限流
+func rateLimit(cmd redis.Cmdable, name string) gin.HandlerFunc {
+	var rule middleware.RateLimitRule
+	if err := econf.UnmarshalKey("ratelimit."+name, &rule); err != nil {
+		panic(err)
+	}
+	if rule.KeyBy == "" {
+		rule.KeyBy = middleware.RateLimitByIP
+	}
+	return middleware.NewRateLimitBuilder(cmd, name, rule).Build()
+}
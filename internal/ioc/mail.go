@@ -0,0 +1,63 @@
+package ioc
+
+import (
+	"time"
+
+	"github.com/gotomicro/ego/core/econf"
+	"github.com/gotomicro/ego/core/elog"
+	"github.com/redis/go-redis/v9"
+
+	"loverrecipe/internal/pkg/mailer"
+	"loverrecipe/internal/repository"
+	"loverrecipe/internal/services/user"
+)
+
+// InitMailer 初始化邮件发送器，读取配置 email.mailer 与 email.smtp。
+// mailer 为 smtp 时通过 SMTP 发送，否则只打印日志
+func InitMailer() mailer.Mailer {
+	type Config struct {
+		Mailer string
+		SMTP   struct {
+			Host     string
+			Port     int
+			Username string
+			Password string
+			From     string
+			Timeout  time.Duration
+		}
+	}
+	var cfg Config
+	if err := econf.UnmarshalKey("email", &cfg); err != nil {
+		panic(err)
+	}
+	if cfg.Mailer != "smtp" {
+		elog.Warn("邮件只打印到日志，不会真正发送", elog.String("mailer", cfg.Mailer))
+		return mailer.NewLogMailer()
+	}
+	return mailer.NewSMTPMailer(mailer.SMTPConfig{
+		Host:     cfg.SMTP.Host,
+		Port:     cfg.SMTP.Port,
+		Username: cfg.SMTP.Username,
+		Password: cfg.SMTP.Password,
+		From:     cfg.SMTP.From,
+		Timeout:  cfg.SMTP.Timeout,
+	})
+}
+
+// InitEmailTokenRepository 初始化邮件令牌存储，读取配置 email.token
+func InitEmailTokenRepository(cmd redis.Cmdable) repository.EmailTokenRepository {
+	var cfg repository.EmailTokenConfig
+	if err := econf.UnmarshalKey("email.token", &cfg); err != nil {
+		panic(err)
+	}
+	return repository.NewEmailTokenRepository(cmd, cfg)
+}
+
+// InitEmailLinks 读取邮件中的链接模板，配置 email.links
+func InitEmailLinks() user.EmailLinks {
+	var links user.EmailLinks
+	if err := econf.UnmarshalKey("email.links", &links); err != nil {
+		panic(err)
+	}
+	return links
+}
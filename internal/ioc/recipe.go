@@ -0,0 +1,39 @@
+package ioc
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"loverrecipe/internal/pkg/schemaorg"
+)
+
+// InitRecipeFetcher 初始化菜谱网页抓取器
+func InitRecipeFetcher() *schemaorg.Fetcher {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		// 抓取地址由用户提供，禁止访问内网地址
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+				ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() {
+				return errors.New("禁止访问内网地址")
+			}
+			return nil
+		},
+	}
+
+	return schemaorg.NewFetcher(&http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+		},
+	})
+}
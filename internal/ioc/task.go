@@ -1,5 +1,7 @@
 package ioc
 
-func InitTasks() []Task {
-	return []Task{}
+import "loverrecipe/internal/services/audit"
+
+func InitTasks(auditService audit.Service) []Task {
+	return []Task{auditService}
 }
@@ -0,0 +1,42 @@
+package ioc
+
+import (
+	"time"
+
+	"github.com/gotomicro/ego/core/econf"
+	"github.com/redis/go-redis/v9"
+
+	"loverrecipe/internal/repository"
+	"loverrecipe/internal/repository/cache"
+	"loverrecipe/internal/services/audit"
+)
+
+// InitLoginAttemptRepository 初始化登录失败记录，读取配置 login.lockout
+func InitLoginAttemptRepository(cmd redis.Cmdable) repository.LoginAttemptRepository {
+	var cfg repository.LoginLockoutConfig
+	if err := econf.UnmarshalKey("login.lockout", &cfg); err != nil {
+		panic(err)
+	}
+	return repository.NewLoginAttemptRepository(cmd, cfg)
+}
+
+// InitUserStatusCache 初始化用户状态缓存，读取配置 cache.userStatus.ttl
+func InitUserStatusCache(cmd redis.Cmdable) cache.UserStatusCache {
+	type Config struct {
+		TTL time.Duration
+	}
+	var cfg Config
+	if err := econf.UnmarshalKey("cache.userStatus", &cfg); err != nil {
+		panic(err)
+	}
+	return cache.NewUserStatusRedisCache(cmd, cfg.TTL)
+}
+
+// InitAuditService 初始化审计服务，读取配置 audit
+func InitAuditService(repo repository.AuditLogRepository) audit.Service {
+	var cfg audit.Config
+	if err := econf.UnmarshalKey("audit", &cfg); err != nil {
+		panic(err)
+	}
+	return audit.NewService(repo, cfg)
+}
@@ -0,0 +1,245 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gotomicro/ego/core/elog"
+	"github.com/redis/go-redis/v9"
+
+	"loverrecipe/internal/response"
+)
+
+// IdempotencyKeyHeader 客户端传入幂等键的请求头
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader 响应为重放结果时设置的响应头
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// 幂等记录默认配置
+const (
+	defaultIdempotencyTTL       = 24 * time.Hour
+	defaultIdempotencyLockTTL   = time.Minute
+	maxIdempotencyKeyLength     = 255
+	idempotencyStatusProcessing = "processing"
+	idempotencyStatusCompleted  = "completed"
+	idempotencyWriteTimeout     = time.Second
+)
+
+// idempotencyRecord 保存在 Redis 中的幂等记录
+type idempotencyRecord struct {
+	Fingerprint string `json:"fingerprint"`
+	Status      string `json:"status"`
+	HTTPStatus  int    `json:"http_status,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// IdempotencyBuilder 幂等中间件构造器
+type IdempotencyBuilder struct {
+	cmd     redis.Cmdable
+	ttl     time.Duration
+	lockTTL time.Duration
+}
+
+// NewIdempotencyBuilder 创建幂等中间件构造器，记录默认保留24小时
+func NewIdempotencyBuilder(cmd redis.Cmdable) *IdempotencyBuilder {
+	return &IdempotencyBuilder{
+		cmd:     cmd,
+		ttl:     defaultIdempotencyTTL,
+		lockTTL: defaultIdempotencyLockTTL,
+	}
+}
+
+// TTL 设置已完成请求的响应保留时间
+func (b *IdempotencyBuilder) TTL(ttl time.Duration) *IdempotencyBuilder {
+	b.ttl = ttl
+	return b
+}
+
+// LockTTL 设置处理中状态的最长保留时间，处理进程异常退出后该键在此时间后可以重试
+func (b *IdempotencyBuilder) LockTTL(ttl time.Duration) *IdempotencyBuilder {
+	b.lockTTL = ttl
+	return b
+}
+
+// Build 构造中间件。
+// 请求带 Idempotency-Key 时，以用户、方法、路径和键定位记录，以方法、路径和请求体计算指纹：
+// 首次请求正常处理并保存响应；指纹相同的重试直接重放保存的响应；
+// 指纹不同返回 422，前一个请求仍在处理返回 409。
+// 服务端错误不保存，客户端可以用同一个键重试。Redis 不可用时跳过幂等检查
+func (b *IdempotencyBuilder) Build() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			ctx.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			response.BadRequest(ctx, "Idempotency-Key 过长")
+			ctx.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			response.BadRequest(ctx, "读取请求体失败")
+			ctx.Abort()
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		redisKey := b.redisKey(ctx, key)
+		fingerprint := b.fingerprint(ctx, body)
+
+		acquired, err := b.acquire(ctx.Request.Context(), redisKey, fingerprint)
+		if err != nil {
+			elog.Warn("幂等检查失败，跳过", elog.FieldErr(err), elog.String("key", redisKey))
+			ctx.Next()
+			return
+		}
+		if !acquired {
+			b.handleExisting(ctx, redisKey, fingerprint)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = recorder
+		ctx.Next()
+
+		b.complete(ctx, redisKey, fingerprint, recorder)
+	}
+}
+
+// acquire 尝试占用幂等键，返回是否为首次请求
+func (b *IdempotencyBuilder) acquire(ctx context.Context, redisKey string, fingerprint string) (bool, error) {
+	data, err := json.Marshal(idempotencyRecord{Fingerprint: fingerprint, Status: idempotencyStatusProcessing})
+	if err != nil {
+		return false, err
+	}
+	return b.cmd.SetNX(ctx, redisKey, data, b.lockTTL).Result()
+}
+
+// handleExisting 处理已有记录的请求：重放、冲突或指纹不匹配
+func (b *IdempotencyBuilder) handleExisting(ctx *gin.Context, redisKey string, fingerprint string) {
+	data, err := b.cmd.Get(ctx.Request.Context(), redisKey).Bytes()
+	if errors.Is(err, redis.Nil) {
+		// 前一个请求刚好失败并释放了键，请客户端重试
+		response.ErrorWithMsg(ctx, response.CodeConflict, "相同 Idempotency-Key 的请求状态已变化，请重试")
+		ctx.Abort()
+		return
+	}
+	if err != nil {
+		elog.Warn("读取幂等记录失败，跳过", elog.FieldErr(err), elog.String("key", redisKey))
+		ctx.Next()
+		return
+	}
+
+	var record idempotencyRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		elog.Warn("解析幂等记录失败，跳过", elog.FieldErr(err), elog.String("key", redisKey))
+		ctx.Next()
+		return
+	}
+
+	switch {
+	case record.Fingerprint != fingerprint:
+		response.ErrorWithMsg(ctx, response.CodeUnprocessableEntity, "Idempotency-Key 已用于不同的请求")
+	case record.Status != idempotencyStatusCompleted:
+		response.ErrorWithMsg(ctx, response.CodeConflict, "相同 Idempotency-Key 的请求正在处理")
+	default:
+		ctx.Header(IdempotentReplayedHeader, "true")
+		ctx.Data(record.HTTPStatus, record.ContentType, record.Body)
+	}
+	ctx.Abort()
+}
+
+// complete 保存首次请求的响应，服务端错误时释放幂等键以便重试
+func (b *IdempotencyBuilder) complete(ctx *gin.Context, redisKey string, fingerprint string, recorder *responseRecorder) {
+	// 请求上下文可能已被取消，写回记录使用独立的上下文
+	redisCtx, cancel := context.WithTimeout(context.Background(), idempotencyWriteTimeout)
+	defer cancel()
+
+	if !storable(recorder.Status(), recorder.body.Bytes()) {
+		if err := b.cmd.Del(redisCtx, redisKey).Err(); err != nil {
+			elog.Warn("释放幂等键失败", elog.FieldErr(err), elog.String("key", redisKey))
+		}
+		return
+	}
+
+	data, err := json.Marshal(idempotencyRecord{
+		Fingerprint: fingerprint,
+		Status:      idempotencyStatusCompleted,
+		HTTPStatus:  recorder.Status(),
+		ContentType: recorder.Header().Get("Content-Type"),
+		Body:        recorder.body.Bytes(),
+	})
+	if err == nil {
+		err = b.cmd.Set(redisCtx, redisKey, data, b.ttl).Err()
+	}
+	if err != nil {
+		elog.Warn("保存幂等记录失败", elog.FieldErr(err), elog.String("key", redisKey))
+	}
+}
+
+// redisKey 幂等键按用户、方法与路径隔离，避免不同用户或接口之间互相影响
+func (b *IdempotencyBuilder) redisKey(ctx *gin.Context, key string) string {
+	var userID int64
+	if value, exists := ctx.Get("user_id"); exists {
+		userID, _ = value.(int64)
+	}
+	sum := sha256.Sum256([]byte(key))
+	return fmt.Sprintf("idempotency:%d:%s:%s:%s", userID, ctx.Request.Method, ctx.FullPath(), hex.EncodeToString(sum[:]))
+}
+
+// fingerprint 请求指纹，由方法、路径与请求体计算
+func (b *IdempotencyBuilder) fingerprint(ctx *gin.Context, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(ctx.Request.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(ctx.Request.URL.RequestURI()))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// storable 判断响应是否需要保存。接口总是返回 HTTP 200，错误码在响应体中，
+// 服务端错误（5xx 以及数据库、缓存、第三方服务错误）视为可重试，不保存
+func storable(httpStatus int, body []byte) bool {
+	if httpStatus >= http.StatusInternalServerError {
+		return false
+	}
+	var resp response.Response
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return true
+	}
+	if resp.Code >= response.CodeInternalServerError && resp.Code < 600 {
+		return false
+	}
+	return resp.Code < response.CodeDatabaseError
+}
+
+// responseRecorder 记录写出的响应体
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
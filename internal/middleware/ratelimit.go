@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gotomicro/ego/core/elog"
+	"github.com/redis/go-redis/v9"
+
+	"loverrecipe/internal/response"
+)
+
+// RateLimitKeyBy 限流的计数维度
+type RateLimitKeyBy string
+
+const (
+	RateLimitByIP   RateLimitKeyBy = "ip"   // 按客户端IP
+	RateLimitByUser RateLimitKeyBy = "user" // 按用户ID，未登录时退回按IP
+	RateLimitByBoth RateLimitKeyBy = "both" // 按用户ID与IP的组合
+)
+
+// RateLimitRule 一个路由分组的限流规则：Window 时间内最多 Limit 次请求
+type RateLimitRule struct {
+	Limit  int
+	Window time.Duration
+	KeyBy  RateLimitKeyBy
+}
+
+// 限流响应头，参考 IETF RateLimit header fields 草案
+const (
+	rateLimitLimitHeader     = "RateLimit-Limit"
+	rateLimitRemainingHeader = "RateLimit-Remaining"
+	rateLimitResetHeader     = "RateLimit-Reset"
+	retryAfterHeader         = "Retry-After"
+)
+
+// slidingWindowScript 滑动窗口限流。
+// KEYS[1] 计数键；ARGV 依次为窗口毫秒数、上限、当前毫秒时间戳、本次请求的唯一标识。
+// 返回 {是否放行, 剩余次数, 窗口内最早一次请求过期还需的毫秒数}
+var slidingWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+redis.call("ZREMRANGEBYSCORE", key, "-inf", now - window)
+local count = redis.call("ZCARD", key)
+local allowed = 0
+if count < limit then
+	redis.call("ZADD", key, now, ARGV[4])
+	redis.call("PEXPIRE", key, window)
+	count = count + 1
+	allowed = 1
+end
+
+local reset = window
+local oldest = redis.call("ZRANGE", key, 0, 0, "WITHSCORES")
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+return {allowed, limit - count, reset}
+`)
+
+// RateLimitBuilder 限流中间件构造器
+type RateLimitBuilder struct {
+	cmd  redis.Cmdable
+	name string
+	rule RateLimitRule
+}
+
+// NewRateLimitBuilder 创建限流中间件构造器，name 用于区分不同路由分组的计数
+func NewRateLimitBuilder(cmd redis.Cmdable, name string, rule RateLimitRule) *RateLimitBuilder {
+	return &RateLimitBuilder{cmd: cmd, name: name, rule: rule}
+}
+
+// Build 构造中间件。规则未配置上限或窗口时不限流；Redis 不可用时放行并记录日志。
+// 按用户计数的规则需要挂在鉴权中间件之后，否则取不到 user_id 会退回按IP计数
+func (b *RateLimitBuilder) Build() gin.HandlerFunc {
+	if b.rule.Limit <= 0 || b.rule.Window <= 0 {
+		return func(ctx *gin.Context) {
+			ctx.Next()
+		}
+	}
+
+	window := b.rule.Window.Milliseconds()
+	return func(ctx *gin.Context) {
+		key := b.key(ctx)
+		result, err := slidingWindowScript.Run(ctx.Request.Context(), b.cmd, []string{key},
+			window, b.rule.Limit, time.Now().UnixMilli(), requestMember()).Int64Slice()
+		if err != nil || len(result) != 3 {
+			elog.Error("限流检查失败，放行请求", elog.FieldErr(err), elog.String("key", key))
+			ctx.Next()
+			return
+		}
+
+		allowed, remaining, resetMillis := result[0] == 1, result[1], result[2]
+		if remaining < 0 {
+			remaining = 0
+		}
+		resetSeconds := strconv.FormatInt(int64(math.Ceil(float64(resetMillis)/1000)), 10)
+		ctx.Header(rateLimitLimitHeader, strconv.Itoa(b.rule.Limit))
+		ctx.Header(rateLimitRemainingHeader, strconv.FormatInt(remaining, 10))
+		ctx.Header(rateLimitResetHeader, resetSeconds)
+
+		if !allowed {
+			ctx.Header(retryAfterHeader, resetSeconds)
+			response.TooManyRequests(ctx)
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// key 按规则的计数维度生成计数键
+func (b *RateLimitBuilder) key(ctx *gin.Context) string {
+	var userID int64
+	if value, exists := ctx.Get("user_id"); exists {
+		userID, _ = value.(int64)
+	}
+
+	switch {
+	case b.rule.KeyBy == RateLimitByUser && userID > 0:
+		return fmt.Sprintf("ratelimit:%s:user:%d", b.name, userID)
+	case b.rule.KeyBy == RateLimitByBoth && userID > 0:
+		return fmt.Sprintf("ratelimit:%s:user:%d:ip:%s", b.name, userID, ctx.ClientIP())
+	default:
+		return fmt.Sprintf("ratelimit:%s:ip:%s", b.name, ctx.ClientIP())
+	}
+}
+
+// requestMember 生成请求在滑动窗口中的唯一标识，避免同一毫秒内的请求互相覆盖
+func requestMember() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(buf)
+}
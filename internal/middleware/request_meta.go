@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+
+	"loverrecipe/internal/domain"
+)
+
+// RequestMeta 将客户端IP、User-Agent 与链路追踪ID放入请求上下文，供服务层记录审计日志
+func RequestMeta() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		meta := domain.RequestMeta{
+			IP:        ctx.ClientIP(),
+			UserAgent: ctx.Request.UserAgent(),
+		}
+		if span := trace.SpanContextFromContext(ctx.Request.Context()); span.HasTraceID() {
+			meta.TraceID = span.TraceID().String()
+		}
+		ctx.Request = ctx.Request.WithContext(domain.WithRequestMeta(ctx.Request.Context(), meta))
+		ctx.Next()
+	}
+}
@@ -0,0 +1,22 @@
+package mailer
+
+import (
+	"context"
+
+	"github.com/gotomicro/ego/core/elog"
+)
+
+// logMailer 只打印日志不发送，开发环境使用。日志中包含邮件正文里的令牌，不能用于生产环境
+type logMailer struct{}
+
+// NewLogMailer 创建只打印日志的邮件发送器
+func NewLogMailer() Mailer {
+	return logMailer{}
+}
+
+// Send 打印邮件内容
+func (logMailer) Send(_ context.Context, msg Message) error {
+	elog.Info("发送邮件", elog.String("to", msg.To), elog.String("subject", msg.Subject),
+		elog.String("body", msg.Body))
+	return nil
+}
@@ -0,0 +1,16 @@
+// Package mailer 发送邮件，业务代码只依赖 Mailer 接口，便于替换实现与测试
+package mailer
+
+import "context"
+
+// Message 一封纯文本邮件
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer 邮件发送接口
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,151 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSMTPSession 假 SMTP 服务收到的一次会话
+type fakeSMTPSession struct {
+	commands []string
+	data     string
+}
+
+// startFakeSMTP 启动只接受一个连接的假 SMTP 服务，不支持 STARTTLS 与认证
+func startFakeSMTP(t *testing.T) (string, int, <-chan fakeSMTPSession) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	sessions := make(chan fakeSMTPSession, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+		var session fakeSMTPSession
+		defer func() { sessions <- session }()
+		r := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+		reply("220 localhost fake smtp")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			session.commands = append(session.commands, line)
+			switch verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); verb {
+			case "EHLO", "HELO":
+				reply("250 localhost")
+			case "DATA":
+				reply("354 end with <CRLF>.<CRLF>")
+				var data strings.Builder
+				for {
+					dl, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if dl == ".\r\n" {
+						break
+					}
+					data.WriteString(dl)
+				}
+				session.data = data.String()
+				reply("250 queued")
+			case "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return host, p, sessions
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	host, port, sessions := startFakeSMTP(t)
+	m := NewSMTPMailer(SMTPConfig{Host: host, Port: port, From: "LoverRecipe <no-reply@localhost>", Timeout: 5 * time.Second})
+
+	msg := Message{To: "user@example.com", Subject: "验证邮箱", Body: "点击链接完成验证"}
+	if err := m.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	session := <-sessions
+	wantCommands := map[string]bool{
+		"MAIL FROM:<no-reply@localhost>": false,
+		"RCPT TO:<user@example.com>":     false,
+	}
+	for _, cmd := range session.commands {
+		if _, ok := wantCommands[cmd]; ok {
+			wantCommands[cmd] = true
+		}
+	}
+	for cmd, seen := range wantCommands {
+		if !seen {
+			t.Errorf("server did not receive %q, got %q", cmd, session.commands)
+		}
+	}
+
+	if !strings.Contains(session.data, "From: \"LoverRecipe\" <no-reply@localhost>\r\n") {
+		t.Errorf("From header missing display name, data:\n%s", session.data)
+	}
+	if !strings.Contains(session.data, "To: user@example.com\r\n") {
+		t.Errorf("To header missing, data:\n%s", session.data)
+	}
+	parts := strings.SplitN(session.data, "\r\n\r\n", 2)
+	if len(parts) != 2 {
+		t.Fatalf("message has no body, data:\n%s", session.data)
+	}
+	body, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(parts[1], "\r\n", ""))
+	if err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if string(body) != msg.Body {
+		t.Errorf("body = %q, want %q", body, msg.Body)
+	}
+}
+
+func TestSMTPMailerSendRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		msg  Message
+	}{
+		{name: "发件人地址无效", from: "LoverRecipe", msg: Message{To: "user@example.com", Subject: "主题"}},
+		{name: "收件人含换行", from: "no-reply@localhost", msg: Message{To: "user@example.com\r\nBcc: x@example.com", Subject: "主题"}},
+		{name: "主题含换行", from: "no-reply@localhost", msg: Message{To: "user@example.com", Subject: "主题\nBcc: x@example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 端口 1 不会有服务监听，校验失败时不应该发起连接
+			m := NewSMTPMailer(SMTPConfig{Host: "127.0.0.1", Port: 1, From: tt.from, Timeout: time.Second})
+			err := m.Send(context.Background(), tt.msg)
+			if err == nil {
+				t.Fatalf("Send() error = nil, want error")
+			}
+			if strings.Contains(err.Error(), "连接 SMTP 服务器失败") {
+				t.Errorf("Send() dialed the server before validating: %v", err)
+			}
+		})
+	}
+}
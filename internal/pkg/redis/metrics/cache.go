@@ -0,0 +1,70 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	// 缓存命中计数器
+	cacheHitCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "redis_cache_hits_total",
+			Help: "Total number of cache hits, including negative and stale entries",
+		},
+		[]string{"cache"},
+	)
+
+	// 缓存未命中计数器
+	cacheMissCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "redis_cache_misses_total",
+			Help: "Total number of cache misses",
+		},
+		[]string{"cache"},
+	)
+
+	// 命中已过期旧值的计数器
+	cacheStaleCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "redis_cache_stale_total",
+			Help: "Total number of stale cache entries served while revalidating",
+		},
+		[]string{"cache"},
+	)
+
+	// 与其他请求合并回源的计数器
+	cacheCoalescedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "redis_cache_coalesced_total",
+			Help: "Total number of cache misses coalesced into an in-flight load",
+		},
+		[]string{"cache"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		cacheHitCounter,
+		cacheMissCounter,
+		cacheStaleCounter,
+		cacheCoalescedCounter,
+	)
+}
+
+// CacheHit 记录一次缓存命中
+func CacheHit(cache string) {
+	cacheHitCounter.WithLabelValues(cache).Inc()
+}
+
+// CacheMiss 记录一次缓存未命中
+func CacheMiss(cache string) {
+	cacheMissCounter.WithLabelValues(cache).Inc()
+}
+
+// CacheStale 记录一次返回已过期旧值
+func CacheStale(cache string) {
+	cacheStaleCounter.WithLabelValues(cache).Inc()
+}
+
+// CacheCoalesced 记录一次合并回源
+func CacheCoalesced(cache string) {
+	cacheCoalescedCounter.WithLabelValues(cache).Inc()
+}
@@ -0,0 +1,75 @@
+package schemaorg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// maxDocumentSize 抓取网页的最大字节数
+const maxDocumentSize = 5 << 20
+
+var (
+	ErrInvalidURL    = errors.New("无效的菜谱链接")
+	ErrFetchFailed   = errors.New("抓取菜谱页面失败")
+	ErrDocumentLarge = errors.New("菜谱页面过大")
+)
+
+// Fetcher 抓取菜谱网页，HTTP 客户端由外部注入以便控制超时、代理与测试
+type Fetcher struct {
+	client *http.Client
+}
+
+// NewFetcher 创建菜谱抓取器
+func NewFetcher(client *http.Client) *Fetcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Fetcher{client: client}
+}
+
+// Fetch 抓取网页内容
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, ErrInvalidURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, ErrInvalidURL
+	}
+	req.Header.Set("Accept", "text/html,application/ld+json,application/json;q=0.9,*/*;q=0.8")
+	req.Header.Set("User-Agent", "loverrecipe-importer/1.0")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrFetchFailed, err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: HTTP %d", ErrFetchFailed, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDocumentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrFetchFailed, err.Error())
+	}
+	if len(data) > maxDocumentSize {
+		return nil, ErrDocumentLarge
+	}
+	return data, nil
+}
+
+// FetchRecipe 抓取网页并解析其中的 Recipe
+func (f *Fetcher) FetchRecipe(ctx context.Context, rawURL string) (*Recipe, error) {
+	data, err := f.Fetch(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRecipe(data)
+}
@@ -0,0 +1,92 @@
+package schemaorg
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const recipePage = `<html><head>
+<script type="application/ld+json">{"@context":"https://schema.org","@graph":[{"@type":"Recipe","name":"宫保鸡丁","recipeCategory":["川菜"]}]}</script>
+</head></html>`
+
+func newRecipeServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/recipe", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(recipePage))
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/recipe", http.StatusFound)
+	})
+	mux.HandleFunc("/article", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<script type="application/ld+json">{"@type":"Article"}</script>`))
+	})
+	mux.HandleFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("a", maxDocumentSize+1)))
+	})
+	mux.HandleFunc("/exact", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("a", maxDocumentSize)))
+	})
+	mux.HandleFunc("/missing", http.NotFound)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetcherFetchRecipe(t *testing.T) {
+	server := newRecipeServer(t)
+	fetcher := NewFetcher(server.Client())
+
+	tests := []struct {
+		name     string
+		path     string
+		wantName string
+		wantErr  error
+	}{
+		{name: "Recipe 页面", path: "/recipe", wantName: "宫保鸡丁"},
+		{name: "跟随重定向", path: "/redirect", wantName: "宫保鸡丁"},
+		{name: "不是 Recipe 的页面", path: "/article", wantErr: ErrRecipeNotFound},
+		{name: "非200状态码", path: "/missing", wantErr: ErrFetchFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recipe, err := fetcher.FetchRecipe(context.Background(), server.URL+tt.path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FetchRecipe() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && recipe.Name != tt.wantName {
+				t.Errorf("FetchRecipe() name = %q, want %q", recipe.Name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestFetcherSizeLimit(t *testing.T) {
+	server := newRecipeServer(t)
+	fetcher := NewFetcher(server.Client())
+
+	if _, err := fetcher.Fetch(context.Background(), server.URL+"/large"); !errors.Is(err, ErrDocumentLarge) {
+		t.Errorf("Fetch() over the limit error = %v, want %v", err, ErrDocumentLarge)
+	}
+	data, err := fetcher.Fetch(context.Background(), server.URL+"/exact")
+	if err != nil {
+		t.Fatalf("Fetch() at the limit error = %v", err)
+	}
+	if len(data) != maxDocumentSize {
+		t.Errorf("Fetch() at the limit read %d bytes, want %d", len(data), maxDocumentSize)
+	}
+}
+
+func TestFetcherInvalidURL(t *testing.T) {
+	fetcher := NewFetcher(nil)
+	for _, rawURL := range []string{"", "ftp://example.com/a", "https://", "://bad"} {
+		if _, err := fetcher.Fetch(context.Background(), rawURL); !errors.Is(err, ErrInvalidURL) {
+			t.Errorf("Fetch(%q) error = %v, want %v", rawURL, err, ErrInvalidURL)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+// Package schemaorg 解析网页中嵌入的 schema.org Recipe 结构化数据（JSON-LD）
+package schemaorg
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	ErrRecipeNotFound = errors.New("未找到 schema.org Recipe 数据")
+	ErrInvalidJSONLD  = errors.New("JSON-LD 格式错误")
+)
+
+// Recipe 从 JSON-LD 中提取的菜谱信息
+type Recipe struct {
+	Name        string
+	Description string
+	Image       string
+	Calories    int64
+	Categories  []string
+	Ingredients []string
+}
+
+// ParseRecipe 解析 HTML 文档或 JSON-LD 文本中的第一个 Recipe 对象
+func ParseRecipe(data []byte) (*Recipe, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, ErrRecipeNotFound
+	}
+
+	// 直接粘贴的 JSON-LD
+	if trimmed[0] == '{' || trimmed[0] == '[' {
+		var node any
+		if err := json.Unmarshal(trimmed, &node); err != nil {
+			return nil, ErrInvalidJSONLD
+		}
+		if recipe := findRecipe(node); recipe != nil {
+			return toRecipe(recipe), nil
+		}
+		return nil, ErrRecipeNotFound
+	}
+
+	// HTML 文档，遍历所有 JSON-LD 脚本，跳过无法解析的块
+	for _, script := range extractJSONLDScripts(trimmed) {
+		var node any
+		if err := json.Unmarshal(script, &node); err != nil {
+			continue
+		}
+		if recipe := findRecipe(node); recipe != nil {
+			return toRecipe(recipe), nil
+		}
+	}
+	return nil, ErrRecipeNotFound
+}
+
+// extractJSONLDScripts 提取 HTML 中所有 type="application/ld+json" 的 script 内容
+func extractJSONLDScripts(data []byte) [][]byte {
+	var scripts [][]byte
+	tokenizer := html.NewTokenizer(bytes.NewReader(data))
+	inJSONLD := false
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return scripts
+		case html.StartTagToken:
+			token := tokenizer.Token()
+			if token.DataAtom != atom.Script {
+				continue
+			}
+			for _, attr := range token.Attr {
+				if strings.EqualFold(attr.Key, "type") &&
+					strings.EqualFold(strings.TrimSpace(attr.Val), "application/ld+json") {
+					inJSONLD = true
+				}
+			}
+		case html.TextToken:
+			if inJSONLD {
+				scripts = append(scripts, append([]byte(nil), tokenizer.Text()...))
+			}
+		case html.EndTagToken:
+			inJSONLD = false
+		}
+	}
+}
+
+// findRecipe 在 JSON-LD 节点中查找 @type 为 Recipe 的对象，兼容数组与 @graph
+func findRecipe(node any) map[string]any {
+	switch v := node.(type) {
+	case []any:
+		for _, item := range v {
+			if recipe := findRecipe(item); recipe != nil {
+				return recipe
+			}
+		}
+	case map[string]any:
+		if isRecipe(v["@type"]) {
+			return v
+		}
+		if graph, ok := v["@graph"]; ok {
+			return findRecipe(graph)
+		}
+		// 部分站点把 Recipe 放在 WebPage 的 mainEntity 中
+		if entity, ok := v["mainEntity"]; ok {
+			return findRecipe(entity)
+		}
+	}
+	return nil
+}
+
+// isRecipe 判断 @type 是否包含 Recipe
+func isRecipe(t any) bool {
+	for _, name := range stringValues(t) {
+		name = strings.TrimPrefix(strings.TrimPrefix(name, "http://schema.org/"), "https://schema.org/")
+		if name == "Recipe" {
+			return true
+		}
+	}
+	return false
+}
+
+// toRecipe 映射需要的字段
+func toRecipe(node map[string]any) *Recipe {
+	recipe := &Recipe{
+		Name:        cleanText(firstString(node["name"])),
+		Description: cleanText(firstString(node["description"])),
+		Image:       imageURL(node["image"]),
+		Categories:  cleanList(stringValues(node["recipeCategory"])),
+		Ingredients: cleanList(stringValues(node["recipeIngredient"])),
+	}
+	// 旧版本词汇表使用 ingredients
+	if len(recipe.Ingredients) == 0 {
+		recipe.Ingredients = cleanList(stringValues(node["ingredients"]))
+	}
+	if nutrition, ok := node["nutrition"].(map[string]any); ok {
+		recipe.Calories = parseCalories(firstString(nutrition["calories"]))
+	}
+	return recipe
+}
+
+// imageURL 解析 image 字段，可能是字符串、ImageObject 或二者的数组
+func imageURL(v any) string {
+	switch img := v.(type) {
+	case string:
+		return strings.TrimSpace(img)
+	case []any:
+		for _, item := range img {
+			if url := imageURL(item); url != "" {
+				return url
+			}
+		}
+	case map[string]any:
+		if url := firstString(img["url"]); url != "" {
+			return strings.TrimSpace(url)
+		}
+		return strings.TrimSpace(firstString(img["contentUrl"]))
+	}
+	return ""
+}
+
+// parseCalories 从 "320 kcal"、"1,200 calories" 等文本中取出卡路里数值
+func parseCalories(s string) int64 {
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", "")
+	end := 0
+	for end < len(s) && (s[end] >= '0' && s[end] <= '9' || s[end] == '.') {
+		end++
+	}
+	if end == 0 {
+		return 0
+	}
+	f, err := strconv.ParseFloat(s[:end], 64)
+	if err != nil {
+		return 0
+	}
+	return int64(math.Round(f))
+}
+
+// stringValues 将字符串或字符串数组统一为切片，数字等标量按文本处理
+func stringValues(v any) []string {
+	switch val := v.(type) {
+	case string:
+		return []string{val}
+	case float64:
+		return []string{strconv.FormatFloat(val, 'f', -1, 64)}
+	case []any:
+		var result []string
+		for _, item := range val {
+			result = append(result, stringValues(item)...)
+		}
+		return result
+	}
+	return nil
+}
+
+// firstString 返回第一个字符串值
+func firstString(v any) string {
+	if values := stringValues(v); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// cleanList 清理列表中的文本并去掉空项
+func cleanList(values []string) []string {
+	var result []string
+	for _, v := range values {
+		if v = cleanText(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// cleanText 反转义 HTML 实体并压缩空白
+func cleanText(s string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(s)), " ")
+}
@@ -0,0 +1,106 @@
+package schemaorg
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseRecipe(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    *Recipe
+		wantErr error
+	}{
+		{
+			name: "直接粘贴的 JSON-LD",
+			data: `{"@context":"https://schema.org","@type":"Recipe","name":"番茄炒蛋","recipeIngredient":["番茄","鸡蛋"]}`,
+			want: &Recipe{Name: "番茄炒蛋", Ingredients: []string{"番茄", "鸡蛋"}},
+		},
+		{
+			name: "@graph 中的 Recipe",
+			data: `<html><head><script type="application/ld+json">
+				{"@context":"https://schema.org","@graph":[
+					{"@type":"WebSite","name":"站点"},
+					{"@type":["Recipe","NewsArticle"],"name":"红烧肉","description":"  肥而 &amp; 不腻 ",
+					 "image":{"@type":"ImageObject","url":"https://example.com/a.jpg"},
+					 "recipeCategory":"热菜","nutrition":{"calories":"1,200 kcal"}}
+				]}
+			</script></head><body></body></html>`,
+			want: &Recipe{
+				Name:        "红烧肉",
+				Description: "肥而 & 不腻",
+				Image:       "https://example.com/a.jpg",
+				Calories:    1200,
+				Categories:  []string{"热菜"},
+			},
+		},
+		{
+			name: "数组与多个脚本，跳过无法解析的块",
+			data: `<script type="application/ld+json">{broken</script>
+				<script type="application/ld+json">[{"@type":"Person"},{"@type":"http://schema.org/Recipe","name":"凉拌黄瓜",
+				"image":["", "https://example.com/b.jpg"],"ingredients":["黄瓜"]}]</script>`,
+			want: &Recipe{
+				Name:        "凉拌黄瓜",
+				Image:       "https://example.com/b.jpg",
+				Ingredients: []string{"黄瓜"},
+			},
+		},
+		{
+			name: "WebPage 的 mainEntity",
+			data: `{"@type":"WebPage","mainEntity":{"@type":"Recipe","name":"清蒸鱼"}}`,
+			want: &Recipe{Name: "清蒸鱼"},
+		},
+		{
+			name:    "不是 Recipe 的页面",
+			data:    `<html><script type="application/ld+json">{"@type":"Article","name":"新闻"}</script></html>`,
+			wantErr: ErrRecipeNotFound,
+		},
+		{
+			name:    "没有 JSON-LD 的页面",
+			data:    `<html><body>hello</body></html>`,
+			wantErr: ErrRecipeNotFound,
+		},
+		{
+			name:    "空内容",
+			data:    "  ",
+			wantErr: ErrRecipeNotFound,
+		},
+		{
+			name:    "JSON-LD 格式错误",
+			data:    `{"@type":`,
+			wantErr: ErrInvalidJSONLD,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRecipe([]byte(tt.data))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseRecipe() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRecipe() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCalories(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"320 kcal", 320},
+		{"1,200 calories", 1200},
+		{"99.6", 100},
+		{"about 300", 0},
+		{"", 0},
+	}
+	for _, tt := range tests {
+		if got := parseCalories(tt.in); got != tt.want {
+			t.Errorf("parseCalories(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/repository/dao"
+	"strings"
+	"time"
+
+	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
+)
+
+// lastUsedInterval 最近使用时间的更新间隔，避免频繁调用的脚本每次请求都写库
+const lastUsedInterval = time.Minute
+
+type APITokenRepository interface {
+	Create(ctx context.Context, token domain.APIToken, hash string) (domain.APIToken, error)
+	// GetByHash 根据令牌哈希获取访问令牌，不存在返回 ErrAPITokenNotFound
+	GetByHash(ctx context.Context, hash string) (domain.APIToken, error)
+	ListByUser(ctx context.Context, userID int64) ([]domain.APIToken, error)
+	CountByUser(ctx context.Context, userID int64) (int64, error)
+	// Delete 吊销用户的访问令牌，不存在或不属于该用户返回 ErrAPITokenNotFound
+	Delete(ctx context.Context, id int64, userID int64) error
+	TouchLastUsed(ctx context.Context, id int64, now int64) error
+}
+
+type apiTokenRepository struct {
+	apiTokenDao dao.APITokenDao
+}
+
+func NewAPITokenRepository(db *egorm.Component) APITokenRepository {
+	return &apiTokenRepository{
+		apiTokenDao: dao.NewAPITokenDao(db),
+	}
+}
+
+// Create 保存访问令牌，明文令牌不入库
+func (r *apiTokenRepository) Create(ctx context.Context, token domain.APIToken, hash string) (domain.APIToken, error) {
+	created, err := r.apiTokenDao.Create(ctx, dao.APIToken{
+		UserID:    token.UserID,
+		Name:      token.Name,
+		TokenHash: hash,
+		Hint:      token.Hint,
+		Scopes:    strings.Join(token.Scopes, ","),
+		ExpiresAt: token.ExpiresAt,
+	})
+	if err != nil {
+		return domain.APIToken{}, err
+	}
+	return r.toDomain(created), nil
+}
+
+// GetByHash 根据令牌哈希获取访问令牌
+func (r *apiTokenRepository) GetByHash(ctx context.Context, hash string) (domain.APIToken, error) {
+	token, err := r.apiTokenDao.GetByHash(ctx, hash)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.APIToken{}, domain.ErrAPITokenNotFound
+	}
+	if err != nil {
+		return domain.APIToken{}, err
+	}
+	return r.toDomain(token), nil
+}
+
+// ListByUser 获取用户的访问令牌
+func (r *apiTokenRepository) ListByUser(ctx context.Context, userID int64) ([]domain.APIToken, error) {
+	tokens, err := r.apiTokenDao.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.APIToken, 0, len(tokens))
+	for _, token := range tokens {
+		result = append(result, r.toDomain(token))
+	}
+	return result, nil
+}
+
+// CountByUser 统计用户的访问令牌数量
+func (r *apiTokenRepository) CountByUser(ctx context.Context, userID int64) (int64, error) {
+	return r.apiTokenDao.CountByUser(ctx, userID)
+}
+
+// Delete 吊销用户的访问令牌
+func (r *apiTokenRepository) Delete(ctx context.Context, id int64, userID int64) error {
+	rows, err := r.apiTokenDao.Delete(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrAPITokenNotFound
+	}
+	return nil
+}
+
+// TouchLastUsed 更新最近使用时间，一分钟内重复调用不写库
+func (r *apiTokenRepository) TouchLastUsed(ctx context.Context, id int64, now int64) error {
+	return r.apiTokenDao.TouchLastUsed(ctx, id, now, lastUsedInterval)
+}
+
+func (r *apiTokenRepository) toDomain(token dao.APIToken) domain.APIToken {
+	var scopes []string
+	if token.Scopes != "" {
+		scopes = strings.Split(token.Scopes, ",")
+	}
+	return domain.APIToken{
+		ID:         token.ID,
+		UserID:     token.UserID,
+		Name:       token.Name,
+		Hint:       token.Hint,
+		Scopes:     scopes,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		Ctime:      token.Ctime,
+	}
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/repository/dao"
+
+	"github.com/ego-component/egorm"
+)
+
+type AuditLogRepository interface {
+	BatchCreate(ctx context.Context, logs []domain.AuditLog) error
+	List(ctx context.Context, query domain.AuditLogQuery) ([]domain.AuditLog, int64, error)
+}
+
+type auditLogRepository struct {
+	auditLogDao dao.AuditLogDao
+}
+
+func NewAuditLogRepository(db *egorm.Component) AuditLogRepository {
+	return &auditLogRepository{
+		auditLogDao: dao.NewAuditLogDao(db),
+	}
+}
+
+// BatchCreate 批量写入审计记录
+func (r *auditLogRepository) BatchCreate(ctx context.Context, logs []domain.AuditLog) error {
+	daoLogs := make([]dao.AuditLog, 0, len(logs))
+	for _, log := range logs {
+		daoLogs = append(daoLogs, dao.AuditLog{
+			ActorID:    log.ActorID,
+			Action:     string(log.Action),
+			TargetType: log.TargetType,
+			TargetID:   log.TargetID,
+			Diff:       string(log.Diff),
+			IP:         log.IP,
+			UserAgent:  log.UserAgent,
+			TraceID:    log.TraceID,
+			Ctime:      log.Ctime,
+		})
+	}
+	return r.auditLogDao.BatchCreate(ctx, daoLogs)
+}
+
+// List 分页查询审计记录，同时返回满足条件的总数
+func (r *auditLogRepository) List(ctx context.Context, query domain.AuditLogQuery) ([]domain.AuditLog, int64, error) {
+	filter := dao.AuditLogFilter{
+		ActorID:    query.ActorID,
+		Action:     string(query.Action),
+		TargetType: query.TargetType,
+		TargetID:   query.TargetID,
+		From:       query.From,
+		To:         query.To,
+		Offset:     query.Offset,
+		Limit:      query.Limit,
+	}
+	total, err := r.auditLogDao.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	daoLogs, err := r.auditLogDao.Find(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	logs := make([]domain.AuditLog, 0, len(daoLogs))
+	for _, log := range daoLogs {
+		var diff []byte
+		if log.Diff != "" {
+			diff = []byte(log.Diff)
+		}
+		logs = append(logs, domain.AuditLog{
+			ID:         log.ID,
+			ActorID:    log.ActorID,
+			Action:     domain.AuditAction(log.Action),
+			TargetType: log.TargetType,
+			TargetID:   log.TargetID,
+			Diff:       diff,
+			IP:         log.IP,
+			UserAgent:  log.UserAgent,
+			TraceID:    log.TraceID,
+			Ctime:      log.Ctime,
+		})
+	}
+	return logs, total, nil
+}
@@ -0,0 +1,241 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"loverrecipe/internal/domain"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrKeyNotExist 缓存未命中
+var ErrKeyNotExist = redis.Nil
+
+// Config 菜品缓存配置
+type Config struct {
+	Enabled     bool
+	TTL         time.Duration // 正常条目的过期时间
+	Jitter      time.Duration // 过期时间的随机浮动上限，避免同时失效，负数表示不浮动
+	NegativeTTL time.Duration // 空结果条目的过期时间
+	// StaleTTL 条目过期后继续保留的时间，期间读到的旧值会直接返回并在后台刷新，0 表示关闭
+	StaleTTL time.Duration
+	// Lock 缓存未命中时是否用 Redis 锁保证多个实例只有一个回源
+	Lock     bool
+	LockTTL  time.Duration // 锁的过期时间
+	LockWait time.Duration // 未抢到锁时等待其他实例回填缓存的最长时间
+}
+
+// 默认缓存时间
+const (
+	defaultTTL         = 10 * time.Minute
+	defaultJitter      = 2 * time.Minute
+	defaultNegativeTTL = time.Minute
+	defaultLockTTL     = 5 * time.Second
+	defaultLockWait    = 200 * time.Millisecond
+)
+
+// Entry 缓存条目
+type Entry[T any] struct {
+	Value    T
+	NotFound bool // 负缓存，表示数据库中不存在
+	Stale    bool // 已过期的旧值，仅在开启 StaleTTL 时返回
+}
+
+// envelope 缓存中保存的结构，ExpireAt 为逻辑过期时间（Unix 毫秒）
+type envelope struct {
+	Value    json.RawMessage `json:"v,omitempty"`
+	NotFound bool            `json:"n,omitempty"`
+	ExpireAt int64           `json:"e"`
+}
+
+type DishesCache interface {
+	// GetDishes 获取菜品详情，未命中返回 ErrKeyNotExist
+	GetDishes(ctx context.Context, id int64) (Entry[domain.Dishes], error)
+	SetDishes(ctx context.Context, dish domain.Dishes) error
+	SetDishesNotFound(ctx context.Context, id int64) error
+	// GetDishesWithType 获取用户带种类信息的菜品列表，未命中返回 ErrKeyNotExist
+	GetDishesWithType(ctx context.Context, userID int64) (Entry[[]domain.DishesWithType], error)
+	SetDishesWithType(ctx context.Context, userID int64, dishes []domain.DishesWithType) error
+	// Invalidate 删除菜品详情与其所属用户的列表缓存
+	Invalidate(ctx context.Context, id int64, userID int64) error
+	// Lock 获取回源锁，未开启锁时总是成功；ok 为 false 表示锁被其他实例持有
+	Lock(ctx context.Context, name string) (unlock func(), ok bool, err error)
+	// LockWait 未抢到锁时的最长等待时间
+	LockWait() time.Duration
+}
+
+type dishesRedisCache struct {
+	cmd redis.Cmdable
+	cfg Config
+}
+
+// NewDishesRedisCache 创建基于 Redis 的菜品缓存，未配置的时间使用默认值
+func NewDishesRedisCache(cmd redis.Cmdable, cfg Config) DishesCache {
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultTTL
+	}
+	if cfg.Jitter == 0 {
+		cfg.Jitter = defaultJitter
+	}
+	if cfg.NegativeTTL <= 0 {
+		cfg.NegativeTTL = defaultNegativeTTL
+	}
+	if cfg.StaleTTL < 0 {
+		cfg.StaleTTL = 0
+	}
+	if cfg.LockTTL <= 0 {
+		cfg.LockTTL = defaultLockTTL
+	}
+	if cfg.LockWait <= 0 {
+		cfg.LockWait = defaultLockWait
+	}
+	return &dishesRedisCache{cmd: cmd, cfg: cfg}
+}
+
+// GetDishes 获取菜品详情
+func (c *dishesRedisCache) GetDishes(ctx context.Context, id int64) (Entry[domain.Dishes], error) {
+	return get[domain.Dishes](ctx, c, c.dishesKey(id))
+}
+
+// SetDishes 缓存菜品详情
+func (c *dishesRedisCache) SetDishes(ctx context.Context, dish domain.Dishes) error {
+	return c.set(ctx, c.dishesKey(dish.ID), dish, c.ttl())
+}
+
+// SetDishesNotFound 缓存菜品不存在，防止缓存穿透
+func (c *dishesRedisCache) SetDishesNotFound(ctx context.Context, id int64) error {
+	data, err := json.Marshal(envelope{
+		NotFound: true,
+		ExpireAt: time.Now().Add(c.cfg.NegativeTTL).UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+	return c.cmd.Set(ctx, c.dishesKey(id), data, c.cfg.NegativeTTL).Err()
+}
+
+// GetDishesWithType 获取用户带种类信息的菜品列表
+func (c *dishesRedisCache) GetDishesWithType(ctx context.Context, userID int64) (Entry[[]domain.DishesWithType], error) {
+	return get[[]domain.DishesWithType](ctx, c, c.withTypeKey(userID))
+}
+
+// SetDishesWithType 缓存用户带种类信息的菜品列表，空列表按负缓存的时间过期
+func (c *dishesRedisCache) SetDishesWithType(ctx context.Context, userID int64, dishes []domain.DishesWithType) error {
+	ttl := c.ttl()
+	if len(dishes) == 0 {
+		ttl = c.cfg.NegativeTTL
+	}
+	return c.set(ctx, c.withTypeKey(userID), dishes, ttl)
+}
+
+// Invalidate 删除菜品详情与其所属用户的列表缓存
+func (c *dishesRedisCache) Invalidate(ctx context.Context, id int64, userID int64) error {
+	keys := []string{c.withTypeKey(userID)}
+	if id > 0 {
+		keys = append(keys, c.dishesKey(id))
+	}
+	return c.cmd.Del(ctx, keys...).Err()
+}
+
+// unlockScript 只释放自己持有的锁
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Lock 获取回源锁
+func (c *dishesRedisCache) Lock(ctx context.Context, name string) (func(), bool, error) {
+	if !c.cfg.Lock {
+		return func() {}, true, nil
+	}
+
+	key := "dishes:lock:" + name
+	token := strconv.FormatInt(rand.Int63(), 36)
+	ok, err := c.cmd.SetNX(ctx, key, token, c.cfg.LockTTL).Result()
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return func() {
+		// 请求可能已被取消，释放锁使用独立的上下文
+		unlockCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = unlockScript.Run(unlockCtx, c.cmd, []string{key}, token).Err()
+	}, true, nil
+}
+
+// LockWait 未抢到锁时的最长等待时间
+func (c *dishesRedisCache) LockWait() time.Duration {
+	return c.cfg.LockWait
+}
+
+// get 读取并解析缓存条目，逻辑过期的条目在开启 StaleTTL 时标记为旧值返回，否则视为未命中
+func get[T any](ctx context.Context, c *dishesRedisCache, key string) (Entry[T], error) {
+	var entry Entry[T]
+	data, err := c.cmd.Get(ctx, key).Bytes()
+	if err != nil {
+		return entry, err
+	}
+
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return entry, fmt.Errorf("解析缓存 %s 失败: %w", key, err)
+	}
+	if time.Now().UnixMilli() >= env.ExpireAt {
+		if env.NotFound || c.cfg.StaleTTL == 0 {
+			return entry, ErrKeyNotExist
+		}
+		entry.Stale = true
+	}
+	if env.NotFound {
+		entry.NotFound = true
+		return entry, nil
+	}
+	if err := json.Unmarshal(env.Value, &entry.Value); err != nil {
+		return entry, fmt.Errorf("解析缓存 %s 失败: %w", key, err)
+	}
+	return entry, nil
+}
+
+// set 写入缓存条目，实际过期时间在逻辑过期时间之后再保留 StaleTTL
+func (c *dishesRedisCache) set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(envelope{
+		Value:    raw,
+		ExpireAt: time.Now().Add(ttl).UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+	return c.cmd.Set(ctx, key, data, ttl+c.cfg.StaleTTL).Err()
+}
+
+// ttl 返回带随机浮动的过期时间
+func (c *dishesRedisCache) ttl() time.Duration {
+	if c.cfg.Jitter <= 0 {
+		return c.cfg.TTL
+	}
+	return c.cfg.TTL + time.Duration(rand.Int63n(int64(c.cfg.Jitter)))
+}
+
+func (c *dishesRedisCache) dishesKey(id int64) string {
+	return fmt.Sprintf("dishes:detail:%d", id)
+}
+
+func (c *dishesRedisCache) withTypeKey(userID int64) string {
+	return fmt.Sprintf("dishes:with_type:%d", userID)
+}
+
+// IsMiss 判断是否为缓存未命中
+func IsMiss(err error) bool {
+	return errors.Is(err, ErrKeyNotExist)
+}
@@ -0,0 +1,185 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"loverrecipe/internal/domain"
+)
+
+func newTestCache(t *testing.T, cfg Config) (*miniredis.Miniredis, DishesCache) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return mr, NewDishesRedisCache(client, cfg)
+}
+
+func TestDishesCacheHitAndMiss(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestCache(t, Config{Enabled: true})
+
+	if _, err := c.GetDishes(ctx, 1); !IsMiss(err) {
+		t.Fatalf("GetDishes() on empty cache error = %v, want miss", err)
+	}
+
+	dish := domain.Dishes{ID: 1, UserID: 7, Name: "番茄炒蛋", Ingredients: []string{"番茄", "鸡蛋"}}
+	if err := c.SetDishes(ctx, dish); err != nil {
+		t.Fatalf("SetDishes() error = %v", err)
+	}
+	entry, err := c.GetDishes(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetDishes() error = %v", err)
+	}
+	if entry.NotFound || entry.Stale || entry.Value.Name != dish.Name || len(entry.Value.Ingredients) != 2 {
+		t.Errorf("GetDishes() = %+v, want %+v", entry, dish)
+	}
+}
+
+func TestDishesCacheNegative(t *testing.T) {
+	ctx := context.Background()
+	mr, c := newTestCache(t, Config{Enabled: true, NegativeTTL: 30 * time.Second})
+
+	if err := c.SetDishesNotFound(ctx, 2); err != nil {
+		t.Fatalf("SetDishesNotFound() error = %v", err)
+	}
+	entry, err := c.GetDishes(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetDishes() error = %v", err)
+	}
+	if !entry.NotFound {
+		t.Errorf("GetDishes() NotFound = false, want true")
+	}
+	if ttl := mr.TTL("dishes:detail:2"); ttl != 30*time.Second {
+		t.Errorf("negative entry TTL = %v, want %v", ttl, 30*time.Second)
+	}
+
+	mr.FastForward(31 * time.Second)
+	if _, err := c.GetDishes(ctx, 2); !IsMiss(err) {
+		t.Errorf("GetDishes() after negative TTL error = %v, want miss", err)
+	}
+}
+
+func TestDishesCacheEmptyListUsesNegativeTTL(t *testing.T) {
+	ctx := context.Background()
+	mr, c := newTestCache(t, Config{Enabled: true, TTL: 10 * time.Minute, Jitter: -1, NegativeTTL: time.Minute})
+
+	if err := c.SetDishesWithType(ctx, 7, nil); err != nil {
+		t.Fatalf("SetDishesWithType() error = %v", err)
+	}
+	if ttl := mr.TTL("dishes:with_type:7"); ttl != time.Minute {
+		t.Errorf("empty list TTL = %v, want %v", ttl, time.Minute)
+	}
+	entry, err := c.GetDishesWithType(ctx, 7)
+	if err != nil || len(entry.Value) != 0 {
+		t.Errorf("GetDishesWithType() = %+v, %v, want empty list", entry, err)
+	}
+}
+
+func TestDishesCacheTTLJitter(t *testing.T) {
+	ctx := context.Background()
+	ttl, jitter := 10*time.Minute, 2*time.Minute
+	mr, c := newTestCache(t, Config{Enabled: true, TTL: ttl, Jitter: jitter})
+
+	distinct := make(map[time.Duration]struct{})
+	for i := int64(1); i <= 20; i++ {
+		if err := c.SetDishes(ctx, domain.Dishes{ID: i}); err != nil {
+			t.Fatalf("SetDishes() error = %v", err)
+		}
+		got := mr.TTL("dishes:detail:" + strconv.FormatInt(i, 10))
+		if got < ttl || got >= ttl+jitter {
+			t.Errorf("TTL = %v, want in [%v, %v)", got, ttl, ttl+jitter)
+		}
+		distinct[got] = struct{}{}
+	}
+	if len(distinct) < 2 {
+		t.Errorf("20 entries got %d distinct TTLs, want jittered TTLs", len(distinct))
+	}
+
+	mr, c = newTestCache(t, Config{Enabled: true, TTL: ttl, Jitter: -1})
+	if err := c.SetDishes(ctx, domain.Dishes{ID: 1}); err != nil {
+		t.Fatalf("SetDishes() error = %v", err)
+	}
+	if got := mr.TTL("dishes:detail:1"); got != ttl {
+		t.Errorf("TTL without jitter = %v, want %v", got, ttl)
+	}
+}
+
+func TestDishesCacheStale(t *testing.T) {
+	ctx := context.Background()
+	mr, c := newTestCache(t, Config{Enabled: true, TTL: time.Second, Jitter: -1, StaleTTL: time.Minute})
+
+	if err := c.SetDishes(ctx, domain.Dishes{ID: 3, Name: "旧值"}); err != nil {
+		t.Fatalf("SetDishes() error = %v", err)
+	}
+	if got := mr.TTL("dishes:detail:3"); got != time.Second+time.Minute {
+		t.Errorf("TTL with stale window = %v, want %v", got, time.Second+time.Minute)
+	}
+	// 逻辑过期时间按本机时钟判断
+	time.Sleep(1100 * time.Millisecond)
+	entry, err := c.GetDishes(ctx, 3)
+	if err != nil {
+		t.Fatalf("GetDishes() error = %v", err)
+	}
+	if !entry.Stale || entry.Value.Name != "旧值" {
+		t.Errorf("GetDishes() = %+v, want stale entry", entry)
+	}
+}
+
+func TestDishesCacheInvalidate(t *testing.T) {
+	ctx := context.Background()
+	mr, c := newTestCache(t, Config{Enabled: true})
+
+	if err := c.SetDishes(ctx, domain.Dishes{ID: 4, UserID: 7}); err != nil {
+		t.Fatalf("SetDishes() error = %v", err)
+	}
+	if err := c.SetDishesWithType(ctx, 7, []domain.DishesWithType{{Dishes: domain.Dishes{ID: 4, UserID: 7}}}); err != nil {
+		t.Fatalf("SetDishesWithType() error = %v", err)
+	}
+	if err := c.SetDishes(ctx, domain.Dishes{ID: 5, UserID: 7}); err != nil {
+		t.Fatalf("SetDishes() error = %v", err)
+	}
+
+	if err := c.Invalidate(ctx, 4, 7); err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+	if mr.Exists("dishes:detail:4") || mr.Exists("dishes:with_type:7") {
+		t.Errorf("Invalidate() left the dish or list entry in place")
+	}
+	if !mr.Exists("dishes:detail:5") {
+		t.Errorf("Invalidate() removed an unrelated dish")
+	}
+
+	// id 为 0 时只清除列表
+	if err := c.SetDishesWithType(ctx, 7, nil); err != nil {
+		t.Fatalf("SetDishesWithType() error = %v", err)
+	}
+	if err := c.Invalidate(ctx, 0, 7); err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+	if mr.Exists("dishes:with_type:7") || !mr.Exists("dishes:detail:5") {
+		t.Errorf("Invalidate(0, userID) should only remove the list entry")
+	}
+}
+
+func TestDishesCacheLock(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestCache(t, Config{Enabled: true, Lock: true})
+
+	unlock, ok, err := c.Lock(ctx, "detail:1")
+	if err != nil || !ok {
+		t.Fatalf("Lock() = %v, %v, want acquired", ok, err)
+	}
+	if _, ok, err := c.Lock(ctx, "detail:1"); err != nil || ok {
+		t.Fatalf("second Lock() = %v, %v, want held by the first", ok, err)
+	}
+	unlock()
+	if _, ok, err := c.Lock(ctx, "detail:1"); err != nil || !ok {
+		t.Fatalf("Lock() after unlock = %v, %v, want acquired", ok, err)
+	}
+}
@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"loverrecipe/internal/domain"
+)
+
+// StatusNotFound 缓存中表示用户不存在的状态值
+const StatusNotFound int64 = -1
+
+// defaultUserStatusTTL 用户状态默认缓存时间，决定禁用账号、修改角色与吊销令牌在其他实例上生效的最长延迟
+const defaultUserStatusTTL = 10 * time.Second
+
+type UserStatusCache interface {
+	// Get 获取用户状态、角色与令牌版本，未命中返回 ErrKeyNotExist，用户不存在时 Status 为 StatusNotFound
+	Get(ctx context.Context, userID int64) (domain.UserAuthState, error)
+	Set(ctx context.Context, userID int64, state domain.UserAuthState) error
+	Del(ctx context.Context, userID int64) error
+}
+
+type userStatusRedisCache struct {
+	cmd redis.Cmdable
+	ttl time.Duration
+}
+
+// NewUserStatusRedisCache 创建基于 Redis 的用户状态缓存，ttl 不大于0时使用默认值
+func NewUserStatusRedisCache(cmd redis.Cmdable, ttl time.Duration) UserStatusCache {
+	if ttl <= 0 {
+		ttl = defaultUserStatusTTL
+	}
+	return &userStatusRedisCache{cmd: cmd, ttl: ttl}
+}
+
+// Get 获取用户状态
+func (c *userStatusRedisCache) Get(ctx context.Context, userID int64) (domain.UserAuthState, error) {
+	var state domain.UserAuthState
+	data, err := c.cmd.Get(ctx, c.key(userID)).Bytes()
+	if err != nil {
+		return state, err
+	}
+	err = json.Unmarshal(data, &state)
+	return state, err
+}
+
+// Set 缓存用户状态
+func (c *userStatusRedisCache) Set(ctx context.Context, userID int64, state domain.UserAuthState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return c.cmd.Set(ctx, c.key(userID), data, c.ttl).Err()
+}
+
+// Del 删除用户状态缓存
+func (c *userStatusRedisCache) Del(ctx context.Context, userID int64) error {
+	return c.cmd.Del(ctx, c.key(userID)).Err()
+}
+
+func (c *userStatusRedisCache) key(userID int64) string {
+	return fmt.Sprintf("user:auth:%d", userID)
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/repository/cache"
+	"loverrecipe/internal/repository/dao"
+
+	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
+)
+
+type CookingLogRepository interface {
+	Create(ctx context.Context, log domain.CookingLog) (*domain.CookingLog, error)
+	GetByID(ctx context.Context, id int64) (*domain.CookingLog, error)
+	Update(ctx context.Context, log domain.CookingLog, previousDishID int64) error
+	Delete(ctx context.Context, log domain.CookingLog) error
+	List(ctx context.Context, query domain.CookingLogQuery) (*domain.CookingLogListResponse, error)
+	SumByPeriod(ctx context.Context, userID int64, period domain.StatisticsPeriod, since int64) ([]domain.CookingPeriodStat, error)
+}
+
+// cookingLogRepository 烹饪记录仓储，写入会改变菜品的烹饪次数与最近烹饪时间，写入后清除菜品缓存。
+// 烹饪记录只能关联自己的菜品，记录的用户即菜品的所属用户
+type cookingLogRepository struct {
+	cookingLogDao dao.CookingLogDao
+	dishesCache   cache.DishesCache
+}
+
+func NewCookingLogRepository(db *egorm.Component, dishesCache cache.DishesCache) CookingLogRepository {
+	return &cookingLogRepository{
+		cookingLogDao: dao.NewCookingLogDao(db),
+		dishesCache:   dishesCache,
+	}
+}
+
+// Create 创建烹饪记录
+func (r *cookingLogRepository) Create(ctx context.Context, log domain.CookingLog) (*domain.CookingLog, error) {
+	saved, err := r.cookingLogDao.Create(ctx, r.domainToDao(log))
+	if err != nil {
+		return nil, err
+	}
+
+	invalidateDishesCache(ctx, r.dishesCache, saved.DishID, saved.UserID)
+	return r.daoToDomain(saved), nil
+}
+
+// GetByID 根据ID获取烹饪记录
+func (r *cookingLogRepository) GetByID(ctx context.Context, id int64) (*domain.CookingLog, error) {
+	log, err := r.cookingLogDao.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrCookingLogNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.daoToDomain(log), nil
+}
+
+// Update 更新烹饪记录，更换了菜品时新旧两道菜的缓存都要清除
+func (r *cookingLogRepository) Update(ctx context.Context, log domain.CookingLog, previousDishID int64) error {
+	if err := r.cookingLogDao.Update(ctx, r.domainToDao(log), previousDishID); err != nil {
+		return err
+	}
+
+	invalidateDishesCache(ctx, r.dishesCache, log.DishID, log.UserID)
+	if previousDishID != log.DishID {
+		invalidateDishesCache(ctx, r.dishesCache, previousDishID, log.UserID)
+	}
+	return nil
+}
+
+// Delete 删除烹饪记录
+func (r *cookingLogRepository) Delete(ctx context.Context, log domain.CookingLog) error {
+	if err := r.cookingLogDao.Delete(ctx, r.domainToDao(log)); err != nil {
+		return err
+	}
+
+	invalidateDishesCache(ctx, r.dishesCache, log.DishID, log.UserID)
+	return nil
+}
+
+// List 分页查询烹饪记录
+func (r *cookingLogRepository) List(ctx context.Context, query domain.CookingLogQuery) (*domain.CookingLogListResponse, error) {
+	filter := dao.CookingLogFilter{
+		UserID: query.UserID,
+		DishID: query.DishID,
+		From:   query.From,
+		To:     query.To,
+		Offset: query.Offset,
+		Limit:  query.Limit,
+	}
+
+	logs, err := r.cookingLogDao.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	total, err := r.cookingLogDao.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]domain.CookingLog, 0, len(logs))
+	for _, log := range logs {
+		list = append(list, *r.daoToDomain(log))
+	}
+	return &domain.CookingLogListResponse{
+		List:  list,
+		Total: total,
+		Page:  query.Offset/query.Limit + 1,
+		Size:  query.Limit,
+	}, nil
+}
+
+// SumByPeriod 按周期汇总烹饪记录
+func (r *cookingLogRepository) SumByPeriod(ctx context.Context, userID int64, period domain.StatisticsPeriod, since int64) ([]domain.CookingPeriodStat, error) {
+	// ISO 周的年份与周数分别对应 %x 与 %v
+	format := "%Y-%m"
+	if period == domain.StatisticsPeriodWeek {
+		format = "%x-W%v"
+	}
+
+	stats, err := r.cookingLogDao.SumByPeriod(ctx, userID, format, since)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.CookingPeriodStat, 0, len(stats))
+	for _, stat := range stats {
+		result = append(result, domain.CookingPeriodStat{
+			Period:   stat.Period,
+			Times:    stat.Times,
+			Servings: stat.Servings,
+			Calorie:  stat.Calorie,
+			Spend:    stat.Spend,
+		})
+	}
+	return result, nil
+}
+
+// daoToDomain 将DAO对象转换为领域对象
+func (r *cookingLogRepository) daoToDomain(log dao.CookingLog) *domain.CookingLog {
+	return &domain.CookingLog{
+		ID:       log.ID,
+		UserID:   log.UserID,
+		DishID:   log.DishID,
+		CookedAt: log.CookedAt,
+		CookedBy: log.CookedBy,
+		Servings: log.Servings,
+		Notes:    log.Notes,
+		Photo:    log.Photo,
+		Ctime:    log.Ctime,
+		Utime:    log.Utime,
+	}
+}
+
+// domainToDao 将领域对象转换为DAO对象
+func (r *cookingLogRepository) domainToDao(log domain.CookingLog) dao.CookingLog {
+	return dao.CookingLog{
+		ID:       log.ID,
+		UserID:   log.UserID,
+		DishID:   log.DishID,
+		CookedAt: log.CookedAt,
+		CookedBy: log.CookedBy,
+		Servings: log.Servings,
+		Notes:    log.Notes,
+		Photo:    log.Photo,
+		Ctime:    log.Ctime,
+		Utime:    log.Utime,
+	}
+}
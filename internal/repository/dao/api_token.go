@@ -0,0 +1,88 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/ego-component/egorm"
+)
+
+// APIToken 个人访问令牌，吊销时直接删除
+type APIToken struct {
+	ID         int64  `gorm:"primaryKey;autoIncrement;type:BIGINT;comment:'令牌ID'"`
+	UserID     int64  `gorm:"type:BIGINT;index:idx_api_tokens_user;comment:'用户ID'"`
+	Name       string `gorm:"type:VARCHAR(50);comment:'令牌名称'"`
+	TokenHash  string `gorm:"type:CHAR(64);uniqueIndex:uni_api_tokens_hash;comment:'令牌的 SHA-256 哈希'"`
+	Hint       string `gorm:"type:VARCHAR(16);comment:'令牌开头的几位'"`
+	Scopes     string `gorm:"type:VARCHAR(255);comment:'权限范围(逗号分隔)'"`
+	ExpiresAt  int64  `gorm:"type:BIGINT;default:0;comment:'过期时间 0:永不过期'"`
+	LastUsedAt int64  `gorm:"type:BIGINT;default:0;comment:'最近使用时间'"`
+	Ctime      int64  `gorm:"comment:'创建时间'"`
+}
+
+// TableName 重命名表
+func (APIToken) TableName() string {
+	return "api_tokens"
+}
+
+type APITokenDao interface {
+	Create(ctx context.Context, token APIToken) (APIToken, error)
+	GetByHash(ctx context.Context, hash string) (APIToken, error)
+	ListByUser(ctx context.Context, userID int64) ([]APIToken, error)
+	CountByUser(ctx context.Context, userID int64) (int64, error)
+	// Delete 删除用户的令牌，返回删除的行数
+	Delete(ctx context.Context, id int64, userID int64) (int64, error)
+	// TouchLastUsed 更新最近使用时间，距上次更新不足 interval 时跳过
+	TouchLastUsed(ctx context.Context, id int64, now int64, interval time.Duration) error
+}
+
+// Implementation of the APITokenDao interface
+type apiTokenDAO struct {
+	db *egorm.Component
+}
+
+// NewAPITokenDao creates a new instance of APITokenDao
+func NewAPITokenDao(db *egorm.Component) APITokenDao {
+	return &apiTokenDAO{db: db}
+}
+
+// Create 创建访问令牌
+func (d *apiTokenDAO) Create(ctx context.Context, token APIToken) (APIToken, error) {
+	token.Ctime = time.Now().Unix()
+	err := d.db.WithContext(ctx).Create(&token).Error
+	return token, err
+}
+
+// GetByHash 根据令牌哈希获取访问令牌
+func (d *apiTokenDAO) GetByHash(ctx context.Context, hash string) (APIToken, error) {
+	var token APIToken
+	err := d.db.WithContext(ctx).Where("token_hash = ?", hash).First(&token).Error
+	return token, err
+}
+
+// ListByUser 按创建时间倒序获取用户的访问令牌
+func (d *apiTokenDAO) ListByUser(ctx context.Context, userID int64) ([]APIToken, error) {
+	var tokens []APIToken
+	err := d.db.WithContext(ctx).Where("user_id = ?", userID).Order("ctime DESC, id DESC").Find(&tokens).Error
+	return tokens, err
+}
+
+// CountByUser 统计用户的访问令牌数量
+func (d *apiTokenDAO) CountByUser(ctx context.Context, userID int64) (int64, error) {
+	var count int64
+	err := d.db.WithContext(ctx).Model(&APIToken{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// Delete 删除用户的访问令牌
+func (d *apiTokenDAO) Delete(ctx context.Context, id int64, userID int64) (int64, error) {
+	res := d.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&APIToken{})
+	return res.RowsAffected, res.Error
+}
+
+// TouchLastUsed 更新最近使用时间。条件更新避免每次请求都写库
+func (d *apiTokenDAO) TouchLastUsed(ctx context.Context, id int64, now int64, interval time.Duration) error {
+	return d.db.WithContext(ctx).Model(&APIToken{}).
+		Where("id = ? AND last_used_at <= ?", id, now-int64(interval/time.Second)).
+		Update("last_used_at", now).Error
+}
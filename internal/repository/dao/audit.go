@@ -0,0 +1,100 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
+)
+
+// AuditLog 审计记录，只追加不修改
+type AuditLog struct {
+	ID         int64  `gorm:"primaryKey;autoIncrement;type:BIGINT;comment:'记录ID'"`
+	ActorID    int64  `gorm:"type:BIGINT;index:idx_audit_logs_actor_ctime,priority:1;comment:'操作人ID'"`
+	Action     string `gorm:"type:VARCHAR(32);index:idx_audit_logs_action_ctime,priority:1;comment:'动作'"`
+	TargetType string `gorm:"type:VARCHAR(32);index:idx_audit_logs_target,priority:1;comment:'对象类型'"`
+	TargetID   string `gorm:"type:VARCHAR(64);index:idx_audit_logs_target,priority:2;comment:'对象ID'"`
+	Diff       string `gorm:"type:TEXT;comment:'变更前后的差异(JSON)'"`
+	IP         string `gorm:"type:VARCHAR(64);comment:'客户端IP'"`
+	UserAgent  string `gorm:"type:VARCHAR(255);comment:'User-Agent'"`
+	TraceID    string `gorm:"type:VARCHAR(64);comment:'链路追踪ID'"`
+	Ctime      int64  `gorm:"index:idx_audit_logs_actor_ctime,priority:2;index:idx_audit_logs_action_ctime,priority:2;index:idx_audit_logs_ctime;comment:'创建时间'"`
+}
+
+// TableName 重命名表
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+// AuditLogFilter 审计记录查询条件
+type AuditLogFilter struct {
+	ActorID    int64
+	Action     string
+	TargetType string
+	TargetID   string
+	From       int64
+	To         int64
+	Offset     int
+	Limit      int
+}
+
+type AuditLogDao interface {
+	BatchCreate(ctx context.Context, logs []AuditLog) error
+	Find(ctx context.Context, filter AuditLogFilter) ([]AuditLog, error)
+	Count(ctx context.Context, filter AuditLogFilter) (int64, error)
+}
+
+// Implementation of the AuditLogDao interface
+type auditLogDAO struct {
+	db *egorm.Component
+}
+
+// NewAuditLogDao creates a new instance of AuditLogDao
+func NewAuditLogDao(db *egorm.Component) AuditLogDao {
+	return &auditLogDAO{db: db}
+}
+
+// BatchCreate 批量写入审计记录
+func (d *auditLogDAO) BatchCreate(ctx context.Context, logs []AuditLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	return d.db.WithContext(ctx).Create(&logs).Error
+}
+
+// Find 按创建时间倒序查询审计记录
+func (d *auditLogDAO) Find(ctx context.Context, filter AuditLogFilter) ([]AuditLog, error) {
+	var logs []AuditLog
+	err := d.filter(ctx, filter).Order("ctime DESC, id DESC").Offset(filter.Offset).Limit(filter.Limit).Find(&logs).Error
+	return logs, err
+}
+
+// Count 统计满足条件的审计记录数
+func (d *auditLogDAO) Count(ctx context.Context, filter AuditLogFilter) (int64, error) {
+	var count int64
+	err := d.filter(ctx, filter).Count(&count).Error
+	return count, err
+}
+
+func (d *auditLogDAO) filter(ctx context.Context, filter AuditLogFilter) *gorm.DB {
+	query := d.db.WithContext(ctx).Model(&AuditLog{})
+	if filter.ActorID > 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+	if filter.From > 0 {
+		query = query.Where("ctime >= ?", filter.From)
+	}
+	if filter.To > 0 {
+		query = query.Where("ctime < ?", filter.To)
+	}
+	return query
+}
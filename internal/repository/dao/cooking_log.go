@@ -0,0 +1,163 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
+)
+
+type CookingLog struct {
+	ID       int64  `gorm:"primaryKey;autoIncrement;type:BIGINT;comment:'记录ID'"`
+	UserID   int64  `gorm:"type:BIGINT;index:idx_cooking_logs_user_cooked_at,priority:1;comment:'用户ID'"`
+	DishID   int64  `gorm:"type:BIGINT;index:idx_cooking_logs_dish;comment:'菜品ID'"`
+	CookedAt int64  `gorm:"type:BIGINT;index:idx_cooking_logs_user_cooked_at,priority:2;comment:'烹饪时间'"`
+	CookedBy string `gorm:"type:VARCHAR(50);comment:'烹饪人'"`
+	Servings int64  `gorm:"type:BIGINT;default:1;comment:'份数'"`
+	Notes    string `gorm:"type:VARCHAR(500);comment:'备注'"`
+	Photo    string `gorm:"type:VARCHAR(200);comment:'照片URL'"`
+	Ctime    int64  `gorm:"comment:'创建时间'"`
+	Utime    int64  `gorm:"comment:'更新时间'"`
+}
+
+// TableName 重命名表
+func (CookingLog) TableName() string {
+	return "cooking_logs"
+}
+
+// CookingLogFilter 烹饪记录查询条件
+type CookingLogFilter struct {
+	UserID int64
+	DishID int64
+	From   int64
+	To     int64
+	Offset int
+	Limit  int
+}
+
+// CookingPeriodStat 按周期聚合的烹饪记录
+type CookingPeriodStat struct {
+	Period   string
+	Times    int64
+	Servings int64
+	Calorie  int64
+	Spend    int64
+}
+
+type CookingLogDao interface {
+	Create(ctx context.Context, log CookingLog) (CookingLog, error)
+	GetByID(ctx context.Context, id int64) (CookingLog, error)
+	Update(ctx context.Context, log CookingLog, previousDishID int64) error
+	Delete(ctx context.Context, log CookingLog) error
+	Find(ctx context.Context, filter CookingLogFilter) ([]CookingLog, error)
+	Count(ctx context.Context, filter CookingLogFilter) (int64, error)
+	SumByPeriod(ctx context.Context, userID int64, periodFormat string, since int64) ([]CookingPeriodStat, error)
+}
+
+// Implementation of the CookingLogDao interface
+type cookingLogDAO struct {
+	db *egorm.Component
+}
+
+// NewCookingLogDao creates a new instance of CookingLogDao
+func NewCookingLogDao(db *egorm.Component) CookingLogDao {
+	return &cookingLogDAO{db: db}
+}
+
+// Create 创建烹饪记录，并同步菜品的烹饪次数与最近烹饪时间
+func (d *cookingLogDAO) Create(ctx context.Context, log CookingLog) (CookingLog, error) {
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&log).Error; err != nil {
+			return err
+		}
+		return refreshCookingStats(tx, log.DishID)
+	})
+	return log, err
+}
+
+// GetByID 根据ID获取烹饪记录
+func (d *cookingLogDAO) GetByID(ctx context.Context, id int64) (CookingLog, error) {
+	var log CookingLog
+	err := d.db.WithContext(ctx).Where("id = ?", id).First(&log).Error
+	return log, err
+}
+
+// Update 更新烹饪记录，菜品变更时同时刷新新旧两道菜的统计
+func (d *cookingLogDAO) Update(ctx context.Context, log CookingLog, previousDishID int64) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&log).Error; err != nil {
+			return err
+		}
+		if previousDishID != log.DishID {
+			if err := refreshCookingStats(tx, previousDishID); err != nil {
+				return err
+			}
+		}
+		return refreshCookingStats(tx, log.DishID)
+	})
+}
+
+// Delete 删除烹饪记录，并同步菜品的烹饪次数与最近烹饪时间
+func (d *cookingLogDAO) Delete(ctx context.Context, log CookingLog) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", log.ID).Delete(&CookingLog{}).Error; err != nil {
+			return err
+		}
+		return refreshCookingStats(tx, log.DishID)
+	})
+}
+
+// Find 按条件分页查询烹饪记录，按烹饪时间倒序
+func (d *cookingLogDAO) Find(ctx context.Context, filter CookingLogFilter) ([]CookingLog, error) {
+	var logs []CookingLog
+	err := d.filtered(ctx, filter).Order("cooked_at DESC, id DESC").Offset(filter.Offset).Limit(filter.Limit).Find(&logs).Error
+	return logs, err
+}
+
+// Count 按条件统计烹饪记录数
+func (d *cookingLogDAO) Count(ctx context.Context, filter CookingLogFilter) (int64, error) {
+	var count int64
+	err := d.filtered(ctx, filter).Count(&count).Error
+	return count, err
+}
+
+// SumByPeriod 按周期汇总用户的烹饪记录，卡路里与花费按菜品单价乘以份数累计
+func (d *cookingLogDAO) SumByPeriod(ctx context.Context, userID int64, periodFormat string, since int64) ([]CookingPeriodStat, error) {
+	var stats []CookingPeriodStat
+	err := d.db.WithContext(ctx).
+		Table("cooking_logs").
+		Select("DATE_FORMAT(FROM_UNIXTIME(cooking_logs.cooked_at), ?) AS period, "+
+			"COUNT(*) AS times, "+
+			"COALESCE(SUM(cooking_logs.servings), 0) AS servings, "+
+			"COALESCE(SUM(dishes.calorie * cooking_logs.servings), 0) AS calorie, "+
+			"COALESCE(SUM(dishes.price * cooking_logs.servings), 0) AS spend", periodFormat).
+		Joins("JOIN dishes ON dishes.id = cooking_logs.dish_id").
+		Where("cooking_logs.user_id = ? AND cooking_logs.cooked_at >= ?", userID, since).
+		Group("period").
+		Order("period ASC").
+		Scan(&stats).Error
+	return stats, err
+}
+
+// filtered 构造带过滤条件的查询
+func (d *cookingLogDAO) filtered(ctx context.Context, filter CookingLogFilter) *gorm.DB {
+	query := d.db.WithContext(ctx).Model(&CookingLog{}).Where("user_id = ?", filter.UserID)
+	if filter.DishID > 0 {
+		query = query.Where("dish_id = ?", filter.DishID)
+	}
+	if filter.From > 0 {
+		query = query.Where("cooked_at >= ?", filter.From)
+	}
+	if filter.To > 0 {
+		query = query.Where("cooked_at < ?", filter.To)
+	}
+	return query
+}
+
+// refreshCookingStats 根据烹饪记录重新计算菜品的烹饪次数与最近烹饪时间
+func refreshCookingStats(tx *gorm.DB, dishID int64) error {
+	return tx.Model(&Dishes{}).Where("id = ?", dishID).Updates(map[string]interface{}{
+		"times_cooked":   tx.Model(&CookingLog{}).Select("COUNT(*)").Where("dish_id = ?", dishID),
+		"last_cooked_at": tx.Model(&CookingLog{}).Select("COALESCE(MAX(cooked_at), 0)").Where("dish_id = ?", dishID),
+	}).Error
+}
@@ -0,0 +1,156 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DishFavorite struct {
+	ID     int64 `gorm:"primaryKey;autoIncrement;type:BIGINT;comment:'收藏ID'"`
+	UserID int64 `gorm:"type:BIGINT;uniqueIndex:uni_dish_favorites_user_dish;comment:'用户ID'"`
+	DishID int64 `gorm:"type:BIGINT;uniqueIndex:uni_dish_favorites_user_dish;index:idx_dish_favorites_dish;comment:'菜品ID'"`
+	Ctime  int64 `gorm:"comment:'创建时间'"`
+}
+
+// TableName 重命名表
+func (DishFavorite) TableName() string {
+	return "dish_favorites"
+}
+
+type DishRating struct {
+	ID      int64  `gorm:"primaryKey;autoIncrement;type:BIGINT;comment:'评分ID'"`
+	UserID  int64  `gorm:"type:BIGINT;uniqueIndex:uni_dish_ratings_user_dish;comment:'用户ID'"`
+	DishID  int64  `gorm:"type:BIGINT;uniqueIndex:uni_dish_ratings_user_dish;index:idx_dish_ratings_dish;comment:'菜品ID'"`
+	Score   int64  `gorm:"type:TINYINT;comment:'评分 1-5'"`
+	Comment string `gorm:"type:VARCHAR(500);comment:'评价'"`
+	Ctime   int64  `gorm:"comment:'创建时间'"`
+	Utime   int64  `gorm:"comment:'更新时间'"`
+}
+
+// TableName 重命名表
+func (DishRating) TableName() string {
+	return "dish_ratings"
+}
+
+type DishFeedbackDao interface {
+	AddFavorite(ctx context.Context, userID int64, dishID int64) error
+	RemoveFavorite(ctx context.Context, userID int64, dishID int64) error
+	IsFavorite(ctx context.Context, userID int64, dishID int64) (bool, error)
+	SaveRating(ctx context.Context, rating DishRating) (DishRating, error)
+	DeleteRating(ctx context.Context, userID int64, dishID int64) error
+	GetRating(ctx context.Context, userID int64, dishID int64) (DishRating, error)
+	FindRatingsByDishID(ctx context.Context, dishID int64, offset int, limit int) ([]DishRating, error)
+	CountRatingsByDishID(ctx context.Context, dishID int64) (int64, error)
+}
+
+// Implementation of the DishFeedbackDao interface
+type dishFeedbackDAO struct {
+	db *egorm.Component
+}
+
+// NewDishFeedbackDao creates a new instance of DishFeedbackDao
+func NewDishFeedbackDao(db *egorm.Component) DishFeedbackDao {
+	return &dishFeedbackDAO{db: db}
+}
+
+// AddFavorite 收藏菜品，重复收藏不报错，并同步菜品的收藏数
+func (d *dishFeedbackDAO) AddFavorite(ctx context.Context, userID int64, dishID int64) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&DishFavorite{
+			UserID: userID,
+			DishID: dishID,
+			Ctime:  time.Now().Unix(),
+		}).Error
+		if err != nil {
+			return err
+		}
+		return refreshFavoriteCount(tx, dishID)
+	})
+}
+
+// RemoveFavorite 取消收藏，并同步菜品的收藏数
+func (d *dishFeedbackDAO) RemoveFavorite(ctx context.Context, userID int64, dishID int64) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("user_id = ? AND dish_id = ?", userID, dishID).Delete(&DishFavorite{}).Error
+		if err != nil {
+			return err
+		}
+		return refreshFavoriteCount(tx, dishID)
+	})
+}
+
+// IsFavorite 检查用户是否收藏了菜品
+func (d *dishFeedbackDAO) IsFavorite(ctx context.Context, userID int64, dishID int64) (bool, error) {
+	var count int64
+	err := d.db.WithContext(ctx).Model(&DishFavorite{}).Where("user_id = ? AND dish_id = ?", userID, dishID).Count(&count).Error
+	return count > 0, err
+}
+
+// SaveRating 新增或更新用户对菜品的评分，并同步菜品的平均分与评分数
+func (d *dishFeedbackDAO) SaveRating(ctx context.Context, rating DishRating) (DishRating, error) {
+	now := time.Now().Unix()
+	rating.Ctime = now
+	rating.Utime = now
+
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "dish_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"score", "comment", "utime"}),
+		}).Create(&rating).Error
+		if err != nil {
+			return err
+		}
+		return refreshRatingStats(tx, rating.DishID)
+	})
+	return rating, err
+}
+
+// DeleteRating 删除用户对菜品的评分，并同步菜品的平均分与评分数
+func (d *dishFeedbackDAO) DeleteRating(ctx context.Context, userID int64, dishID int64) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("user_id = ? AND dish_id = ?", userID, dishID).Delete(&DishRating{}).Error
+		if err != nil {
+			return err
+		}
+		return refreshRatingStats(tx, dishID)
+	})
+}
+
+// GetRating 获取用户对菜品的评分
+func (d *dishFeedbackDAO) GetRating(ctx context.Context, userID int64, dishID int64) (DishRating, error) {
+	var rating DishRating
+	err := d.db.WithContext(ctx).Where("user_id = ? AND dish_id = ?", userID, dishID).First(&rating).Error
+	return rating, err
+}
+
+// FindRatingsByDishID 分页查询菜品的评分列表
+func (d *dishFeedbackDAO) FindRatingsByDishID(ctx context.Context, dishID int64, offset int, limit int) ([]DishRating, error) {
+	var ratings []DishRating
+	err := d.db.WithContext(ctx).Where("dish_id = ?", dishID).Order("utime DESC").Offset(offset).Limit(limit).Find(&ratings).Error
+	return ratings, err
+}
+
+// CountRatingsByDishID 统计菜品的评分数
+func (d *dishFeedbackDAO) CountRatingsByDishID(ctx context.Context, dishID int64) (int64, error) {
+	var count int64
+	err := d.db.WithContext(ctx).Model(&DishRating{}).Where("dish_id = ?", dishID).Count(&count).Error
+	return count, err
+}
+
+// refreshFavoriteCount 根据收藏表重新计算菜品的收藏数，避免并发下计数漂移
+func refreshFavoriteCount(tx *gorm.DB, dishID int64) error {
+	return tx.Model(&Dishes{}).Where("id = ?", dishID).
+		Update("favorite_count", tx.Model(&DishFavorite{}).Select("COUNT(*)").Where("dish_id = ?", dishID)).Error
+}
+
+// refreshRatingStats 根据评分表重新计算菜品的平均分与评分数
+func refreshRatingStats(tx *gorm.DB, dishID int64) error {
+	return tx.Model(&Dishes{}).Where("id = ?", dishID).Updates(map[string]interface{}{
+		"rating_count": tx.Model(&DishRating{}).Select("COUNT(*)").Where("dish_id = ?", dishID),
+		"rating_avg":   tx.Model(&DishRating{}).Select("COALESCE(AVG(score), 0)").Where("dish_id = ?", dishID),
+	}).Error
+}
@@ -2,8 +2,13 @@ package dao
 
 import (
 	"context"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type DishType struct {
@@ -17,6 +22,9 @@ type DishType struct {
 	Color       string `gorm:"type:VARCHAR(20);comment:'种类颜色'"`
 	Sort        int64  `gorm:"type:BIGINT;default:0;comment:'排序权重'"`
 	Status      int64  `gorm:"type:BIGINT;default:1;comment:'状态 1:启用 0:禁用'"`
+	ParentID    int64  `gorm:"type:BIGINT;default:0;comment:'上级种类ID 0:顶层'"`
+	// Path 物化路径，从顶层到自身的ID，如 /3/17/42/。子孙种类按前缀匹配，可以使用索引
+	Path string `gorm:"type:VARCHAR(255);default:'';index:idx_dish_types_path;comment:'种类路径'"`
 }
 
 // TableName 重命名表
@@ -30,6 +38,13 @@ type DishTypeDao interface {
 	GetByUserID(ctx context.Context, userID int64) ([]DishType, error)
 	Delete(ctx context.Context, id int64) error
 	Save(ctx context.Context, dishType DishType) (DishType, error)
+	// Create 创建种类并根据上级种类的路径生成自身路径
+	Create(ctx context.Context, dishType DishType, parentPath string) (DishType, error)
+	// Move 把用户的种类连同子孙种类移动到 newParentID 下，0 表示移到顶层。
+	// 在事务中锁定种类与新上级后调用 check，check 返回错误时不做任何修改
+	Move(ctx context.Context, userID int64, id int64, newParentID int64, check MoveCheck) (DishType, error)
+	// UpdateSort 在一个事务中改写用户种类的排序权重
+	UpdateSort(ctx context.Context, userID int64, weights map[int64]int64) error
 	Find(ctx context.Context, offset int, limit int) ([]DishType, error)
 	FindByStatus(ctx context.Context, status int64, offset int, limit int) ([]DishType, error)
 	Count(ctx context.Context) (int64, error)
@@ -118,3 +133,114 @@ func (d *dishTypeDAO) Count(ctx context.Context) (int64, error) {
 	err := d.db.WithContext(ctx).Model(&DishType{}).Count(&count).Error
 	return count, err
 }
+
+// Create 在一个事务中插入种类并写入路径，路径中包含自增的ID，需要插入后才能生成
+func (d *dishTypeDAO) Create(ctx context.Context, dishType DishType, parentPath string) (DishType, error) {
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		dishType.Path = ""
+		if err := tx.Create(&dishType).Error; err != nil {
+			return err
+		}
+		dishType.Path = childPath(parentPath, dishType.ID)
+		return tx.Model(&DishType{}).Where("id = ?", dishType.ID).Update("path", dishType.Path).Error
+	})
+	return dishType, err
+}
+
+// MoveCheck 移动前在事务中校验：dishType 与 parent 为加锁后读到的最新数据，parent 为 nil 表示移到顶层或上级已不存在；
+// height 为以 dishType 为根的子树层数
+type MoveCheck func(dishType DishType, parent *DishType, height int) error
+
+// Move 更新种类的上级，并用一条语句替换自身与全部子孙种类路径的前缀。
+// 种类与新上级按ID顺序加锁，并发的两次移动（例如 A 移到 B 下、B 移到 A 下）依次执行，后一次读到前一次改写后的路径
+func (d *dishTypeDAO) Move(ctx context.Context, userID int64, id int64, newParentID int64, check MoveCheck) (DishType, error) {
+	var dishType DishType
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ids := []int64{id}
+		if newParentID > 0 {
+			ids = append(ids, newParentID)
+		}
+		var locked []DishType
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND id IN ?", userID, ids).
+			Order("id").
+			Find(&locked).Error
+		if err != nil {
+			return err
+		}
+		var parent *DishType
+		found := false
+		for i := range locked {
+			switch locked[i].ID {
+			case id:
+				dishType, found = locked[i], true
+			case newParentID:
+				parent = &locked[i]
+			}
+		}
+		if !found {
+			return gorm.ErrRecordNotFound
+		}
+
+		height, err := subtreeHeight(tx, userID, dishType.Path)
+		if err != nil {
+			return err
+		}
+		if err := check(dishType, parent, height); err != nil {
+			return err
+		}
+
+		var parentPath string
+		if parent != nil {
+			parentPath = parent.Path
+		}
+		oldPath := dishType.Path
+		newPath := childPath(parentPath, dishType.ID)
+		now := time.Now().Unix()
+		err = tx.Model(&DishType{}).
+			Where("user_id = ? AND path LIKE ?", userID, escapeLike(oldPath)+"%").
+			Update("path", gorm.Expr("CONCAT(?, SUBSTRING(path, ?))", newPath, len(oldPath)+1)).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&DishType{}).Where("id = ?", dishType.ID).Updates(map[string]interface{}{
+			"parent_id": newParentID,
+			"utime":     now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		dishType.ParentID = newParentID
+		dishType.Path = newPath
+		dishType.Utime = now
+		return nil
+	})
+	return dishType, err
+}
+
+// subtreeHeight 取子孙种类中最深的路径，按路径中的分隔符数计算以 path 为根的子树层数
+func subtreeHeight(db *gorm.DB, userID int64, path string) (int, error) {
+	var deepest int
+	err := db.Model(&DishType{}).
+		Select("COALESCE(MAX(LENGTH(path) - LENGTH(REPLACE(path, '/', ''))), 0)").
+		Where("user_id = ? AND path LIKE ?", userID, escapeLike(path)+"%").
+		Scan(&deepest).Error
+	if err != nil {
+		return 0, err
+	}
+	return deepest - strings.Count(path, "/") + 1, nil
+}
+
+// backfillDishTypePaths 为加入层级之前创建的种类补全路径，这些种类都是顶层种类
+func backfillDishTypePaths(db *egorm.Component) error {
+	return db.Model(&DishType{}).Where("path = ''").
+		Update("path", gorm.Expr("CONCAT('/', id, '/')")).Error
+}
+
+// childPath 上级路径下子种类的路径，上级路径为空时为顶层种类
+func childPath(parentPath string, id int64) string {
+	if parentPath == "" {
+		parentPath = "/"
+	}
+	return parentPath + strconv.FormatInt(id, 10) + "/"
+}
@@ -2,21 +2,37 @@ package dao
 
 import (
 	"context"
+	"strings"
 
 	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
 )
 
 type Dishes struct {
-	ID      int64 `gorm:"primaryKey;type:BIGINT;comment:'业务标识'"`
-	UserID  int64 `gorm:"type:BIGINT;comment:'用户ID'"`
-	Ctime   int64
-	Utime   int64
-	Name    string `gorm:"type:VARCHAR(100);comment:'菜名'"`
-	Desc    string `gorm:"type:VARCHAR(200);comment:'菜描述'"`
-	Price   int64  `gorm:"type:BIGINT;comment:'价格'"`
-	Img     string `gorm:"type:VARCHAR(200);comment:'菜图片'"`
-	Type    int64  `gorm:"type:BIGINT;comment:'菜类别';index:idx_type"`
-	Calorie int64  `gorm:"type:BIGINT;comment:'卡路里'"`
+	ID          int64 `gorm:"primaryKey;type:BIGINT;comment:'业务标识'"`
+	UserID      int64 `gorm:"type:BIGINT;comment:'用户ID'"`
+	Ctime       int64
+	Utime       int64
+	Name        string `gorm:"type:VARCHAR(100);comment:'菜名'"`
+	Desc        string `gorm:"type:VARCHAR(200);comment:'菜描述'"`
+	Price       int64  `gorm:"type:BIGINT;comment:'价格'"`
+	Img         string `gorm:"type:VARCHAR(200);comment:'菜图片'"`
+	Type        int64  `gorm:"type:BIGINT;comment:'菜类别';index:idx_type"`
+	Calorie     int64  `gorm:"type:BIGINT;comment:'卡路里'"`
+	Ingredients string `gorm:"type:TEXT;comment:'食材清单(JSON数组)'"`
+	Allergens   string `gorm:"type:VARCHAR(255);default:'';comment:'含有的过敏原(逗号分隔)'"`
+	// 用户拖动排序的权重，由 UpdateSort 单独维护
+	Sort int64 `gorm:"type:BIGINT;default:0;comment:'排序权重'"`
+	// 聚合值，由 DishFeedbackDao 与 CookingLogDao 在写入评分、收藏或烹饪记录时同步
+	RatingAvg     float64 `gorm:"type:DECIMAL(3,2);default:0;comment:'平均评分'"`
+	RatingCount   int64   `gorm:"type:BIGINT;default:0;comment:'评分数'"`
+	FavoriteCount int64   `gorm:"type:BIGINT;default:0;comment:'收藏数'"`
+	TimesCooked   int64   `gorm:"type:BIGINT;default:0;comment:'烹饪次数'"`
+	LastCookedAt  int64   `gorm:"type:BIGINT;default:0;comment:'最近烹饪时间'"`
+	// 复制来源，自己创建的菜品为0
+	SourceDishID int64  `gorm:"type:BIGINT;default:0;index:idx_dishes_source;comment:'来源菜品ID'"`
+	SourceUserID int64  `gorm:"type:BIGINT;default:0;comment:'来源用户ID'"`
+	SourceDigest string `gorm:"type:CHAR(64);default:'';comment:'复制时来源菜品的内容摘要'"`
 }
 
 // TableName 重命名表
@@ -24,6 +40,12 @@ func (Dishes) TableName() string {
 	return "dishes"
 }
 
+// aggregateColumns 评分、收藏与烹饪记录的聚合列
+var aggregateColumns = []string{"rating_avg", "rating_count", "favorite_count", "times_cooked", "last_cooked_at"}
+
+// dishesOrder 菜品的默认顺序：按用户排序权重从大到小，未排过序的按创建顺序排在后面
+const dishesOrder = "sort DESC, id ASC"
+
 // DishesWithType 包含菜品和种类信息的结构体
 type DishesWithType struct {
 	Dishes
@@ -31,6 +53,26 @@ type DishesWithType struct {
 	TypeDescription string `json:"type_description"`
 	TypeIcon        string `json:"type_icon"`
 	TypeColor       string `json:"type_color"`
+	IsFavorite      bool   `json:"is_favorite"`
+}
+
+// DishesFilter 菜品列表查询条件
+type DishesFilter struct {
+	UserID     int64
+	Type       int64
+	TypePath   string // 非空时代替 Type，包含路径以此为前缀的种类及其子孙种类
+	Keyword    string
+	FavoriteBy int64 // 仅返回该用户收藏的菜品
+	ViewerID   int64 // 用于标记当前用户是否已收藏
+	// 标签过滤：TagIDs 按 MatchAllTags 决定需同时具有全部标签还是任意一个，ExcludeTagIDs 中的标签均不能具有
+	TagIDs        []int64
+	MatchAllTags  bool
+	ExcludeTagIDs []int64
+	// ExcludeAllergens 排除含有其中任一成分的菜品
+	ExcludeAllergens []string
+	OrderBy          string
+	Offset           int
+	Limit            int
 }
 
 type DishesDao interface {
@@ -39,11 +81,22 @@ type DishesDao interface {
 	GetByUserID(ctx context.Context, userID int64) ([]Dishes, error)
 	GetByType(ctx context.Context, typeID int64) ([]Dishes, error)
 	GetByUserIDAndType(ctx context.Context, userID int64, typeID int64) ([]Dishes, error)
+	// GetByTypePath 获取用户路径以 typePath 为前缀的种类及其子孙种类下的菜品，typePath 不能为空
+	GetByTypePath(ctx context.Context, userID int64, typePath string) ([]Dishes, error)
+	// CountByType 按种类统计用户的菜品数
+	CountByType(ctx context.Context, userID int64) ([]DishesTypeCount, error)
 	GetDishesWithTypeInfo(ctx context.Context, userID int64) ([]DishesWithType, error)
+	List(ctx context.Context, filter DishesFilter) ([]DishesWithType, int64, error)
 	Delete(ctx context.Context, id int64) error
+	Merge(ctx context.Context, keepID int64, mergeIDs []int64) error
+	// UpdateSort 在一个事务中改写用户菜品的排序权重
+	UpdateSort(ctx context.Context, userID int64, weights map[int64]int64) error
 	Save(ctx context.Context, config Dishes) (Dishes, error)
 	Find(ctx context.Context, offset int, limit int) ([]Dishes, error)
 	Count(ctx context.Context) (int64, error)
+	Distribution(ctx context.Context, userID int64, column string, grouping DishesGrouping) ([]DishesDistribution, error)
+	CountCreatedByPeriod(ctx context.Context, userID int64, periodFormat string, since int64) ([]DishesPeriodCount, error)
+	Top(ctx context.Context, userID int64, where string, orderBy string, limit int) ([]Dishes, error)
 }
 
 // Implementation of the DishesDao interface
@@ -84,9 +137,16 @@ func (d *dishesDAO) GetByID(ctx context.Context, id int64) (Dishes, error) {
 	return dish, err
 }
 
-// Delete 根据ID删除菜品
+// Delete 根据ID删除菜品，并在同一事务中清理标签关联、收藏、评分与烹饪记录
 func (d *dishesDAO) Delete(ctx context.Context, id int64) error {
-	return d.db.WithContext(ctx).Where("id = ?", id).Delete(&Dishes{}).Error
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&DishTag{}, &DishFavorite{}, &DishRating{}, &CookingLog{}} {
+			if err := tx.Where("dish_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Where("id = ?", id).Delete(&Dishes{}).Error
+	})
 }
 
 // Save 保存或更新菜品信息
@@ -96,8 +156,8 @@ func (d *dishesDAO) Save(ctx context.Context, dish Dishes) (Dishes, error) {
 		err := d.db.WithContext(ctx).Create(&dish).Error
 		return dish, err
 	} else {
-		// 更新菜品
-		err := d.db.WithContext(ctx).Save(&dish).Error
+		// 更新菜品，聚合字段与排序权重单独维护，避免被覆盖
+		err := d.db.WithContext(ctx).Omit(append([]string{"sort"}, aggregateColumns...)...).Save(&dish).Error
 		return dish, err
 	}
 }
@@ -112,21 +172,21 @@ func (d *dishesDAO) Find(ctx context.Context, offset int, limit int) ([]Dishes,
 // GetByUserID 根据用户ID获取菜品列表
 func (d *dishesDAO) GetByUserID(ctx context.Context, userID int64) ([]Dishes, error) {
 	var dishes []Dishes
-	err := d.db.WithContext(ctx).Where("user_id = ?", userID).Find(&dishes).Error
+	err := d.db.WithContext(ctx).Where("user_id = ?", userID).Order(dishesOrder).Find(&dishes).Error
 	return dishes, err
 }
 
 // GetByType 根据菜品种类获取菜品列表
 func (d *dishesDAO) GetByType(ctx context.Context, typeID int64) ([]Dishes, error) {
 	var dishes []Dishes
-	err := d.db.WithContext(ctx).Where("type = ?", typeID).Find(&dishes).Error
+	err := d.db.WithContext(ctx).Where("type = ?", typeID).Order(dishesOrder).Find(&dishes).Error
 	return dishes, err
 }
 
 // GetByUserIDAndType 根据用户ID和菜品种类获取菜品列表
 func (d *dishesDAO) GetByUserIDAndType(ctx context.Context, userID int64, typeID int64) ([]Dishes, error) {
 	var dishes []Dishes
-	err := d.db.WithContext(ctx).Where("user_id = ? AND type = ?", userID, typeID).Find(&dishes).Error
+	err := d.db.WithContext(ctx).Where("user_id = ? AND type = ?", userID, typeID).Order(dishesOrder).Find(&dishes).Error
 	return dishes, err
 }
 
@@ -137,6 +197,36 @@ func (d *dishesDAO) Count(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+// DishesTypeCount 一个种类下的菜品数
+type DishesTypeCount struct {
+	Type  int64
+	Count int64
+}
+
+// GetByTypePath 一条语句查询种类子树下的全部菜品，子孙种类由路径前缀确定
+func (d *dishesDAO) GetByTypePath(ctx context.Context, userID int64, typePath string) ([]Dishes, error) {
+	var dishes []Dishes
+	db := d.db.WithContext(ctx)
+	err := db.Where("user_id = ? AND type IN (?)", userID, typeSubtree(db, userID, typePath)).Order(dishesOrder).Find(&dishes).Error
+	return dishes, err
+}
+
+// CountByType 按种类分组统计用户的菜品数
+func (d *dishesDAO) CountByType(ctx context.Context, userID int64) ([]DishesTypeCount, error) {
+	var counts []DishesTypeCount
+	err := d.db.WithContext(ctx).Model(&Dishes{}).
+		Select("type, COUNT(*) AS count").
+		Where("user_id = ?", userID).
+		Group("type").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// typeSubtree 用户路径以 typePath 为前缀的种类ID子查询，typePath 为空时会匹配全部种类，调用方需先排除
+func typeSubtree(db *gorm.DB, userID int64, typePath string) *gorm.DB {
+	return db.Model(&DishType{}).Select("id").Where("user_id = ? AND path LIKE ?", userID, escapeLike(typePath)+"%")
+}
+
 // GetDishesWithTypeInfo 获取菜品及其种类信息
 func (d *dishesDAO) GetDishesWithTypeInfo(ctx context.Context, userID int64) ([]DishesWithType, error) {
 	var result []DishesWithType
@@ -147,7 +237,70 @@ func (d *dishesDAO) GetDishesWithTypeInfo(ctx context.Context, userID int64) ([]
 		Select("dishes.*, dish_types.name as type_name, dish_types.description as type_description, dish_types.icon as type_icon, dish_types.color as type_color").
 		Joins("LEFT JOIN dish_types ON dishes.type = dish_types.id").
 		Where("dishes.user_id = ?", userID).
+		Order("dishes.sort DESC, dishes.id ASC").
 		Find(&result).Error
 
 	return result, err
 }
+
+// List 按条件分页查询菜品及其种类信息，过滤、排序与分页均在 SQL 中完成
+func (d *dishesDAO) List(ctx context.Context, filter DishesFilter) ([]DishesWithType, int64, error) {
+	query := d.db.WithContext(ctx).
+		Table("dishes").
+		Joins("LEFT JOIN dish_types ON dishes.type = dish_types.id").
+		Where("dishes.user_id = ?", filter.UserID)
+
+	switch {
+	case filter.TypePath != "":
+		query = query.Where("dishes.type IN (?)", typeSubtree(d.db.WithContext(ctx), filter.UserID, filter.TypePath))
+	case filter.Type > 0:
+		query = query.Where("dishes.type = ?", filter.Type)
+	}
+	if filter.Keyword != "" {
+		like := "%" + escapeLike(filter.Keyword) + "%"
+		query = query.Where("(dishes.name LIKE ? OR dishes.`desc` LIKE ?)", like, like)
+	}
+	if filter.FavoriteBy > 0 {
+		query = query.Where("EXISTS (SELECT 1 FROM dish_favorites WHERE dish_favorites.dish_id = dishes.id AND dish_favorites.user_id = ?)", filter.FavoriteBy)
+	}
+	if len(filter.TagIDs) > 0 {
+		if filter.MatchAllTags {
+			// 调用方需保证 TagIDs 已去重，命中的标签数等于要求的标签数即同时具有全部标签
+			query = query.Where("(SELECT COUNT(*) FROM dish_tags WHERE dish_tags.dish_id = dishes.id AND dish_tags.tag_id IN ?) = ?", filter.TagIDs, len(filter.TagIDs))
+		} else {
+			query = query.Where("EXISTS (SELECT 1 FROM dish_tags WHERE dish_tags.dish_id = dishes.id AND dish_tags.tag_id IN ?)", filter.TagIDs)
+		}
+	}
+	if len(filter.ExcludeTagIDs) > 0 {
+		query = query.Where("NOT EXISTS (SELECT 1 FROM dish_tags WHERE dish_tags.dish_id = dishes.id AND dish_tags.tag_id IN ?)", filter.ExcludeTagIDs)
+	}
+	for _, allergen := range filter.ExcludeAllergens {
+		query = query.Where("FIND_IN_SET(?, dishes.allergens) = 0", allergen)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	orderBy := filter.OrderBy
+	if orderBy == "" {
+		orderBy = "dishes.sort DESC, dishes.id ASC"
+	}
+
+	var result []DishesWithType
+	err := query.
+		Select("dishes.*, dish_types.name as type_name, dish_types.description as type_description, dish_types.icon as type_icon, dish_types.color as type_color, "+
+			"EXISTS (SELECT 1 FROM dish_favorites WHERE dish_favorites.dish_id = dishes.id AND dish_favorites.user_id = ?) as is_favorite", filter.ViewerID).
+		Order(orderBy).
+		Offset(filter.Offset).
+		Limit(filter.Limit).
+		Find(&result).Error
+
+	return result, total, err
+}
+
+// escapeLike 转义 LIKE 通配符
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,81 @@
+package dao
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Merge 在一个事务中把 mergeIDs 中的菜品合并到 keepID 后删除：
+// 收藏、评分与标签关联转移到保留菜品，同一用户已收藏或评分过保留菜品时保留原有的那条；
+// 烹饪记录、饮食记录、分享链接与复制来源直接改指保留菜品；最后重新计算保留菜品的聚合值
+func (d *dishesDAO) Merge(ctx context.Context, keepID int64, mergeIDs []int64) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 按菜品逐个转移，避免多道被合并的菜品被同一用户收藏时违反唯一索引
+		for _, id := range mergeIDs {
+			if err := moveUnique(tx, &DishFavorite{}, "user_id", keepID, id); err != nil {
+				return err
+			}
+			if err := moveUnique(tx, &DishRating{}, "user_id", keepID, id); err != nil {
+				return err
+			}
+			if err := moveUnique(tx, &DishTag{}, "tag_id", keepID, id); err != nil {
+				return err
+			}
+		}
+
+		moves := []struct {
+			model  interface{}
+			column string
+			query  string
+			args   []interface{}
+		}{
+			{&CookingLog{}, "dish_id", "dish_id IN ?", []interface{}{mergeIDs}},
+			{&MealRecord{}, "dish_id", "dish_id IN ?", []interface{}{mergeIDs}},
+			{&Share{}, "target_id", "target_type = ? AND target_id IN ?", []interface{}{"dish", mergeIDs}},
+			{&Dishes{}, "source_dish_id", "source_dish_id IN ? AND id <> ?", []interface{}{mergeIDs, keepID}},
+		}
+		for _, m := range moves {
+			if err := tx.Model(m.model).Where(m.query, m.args...).Update(m.column, keepID).Error; err != nil {
+				return err
+			}
+		}
+		// 保留菜品本身复制自被合并的菜品时，来源已不存在，清除来源
+		err := tx.Model(&Dishes{}).Where("id = ? AND source_dish_id IN ?", keepID, mergeIDs).Updates(map[string]interface{}{
+			"source_dish_id": 0,
+			"source_user_id": 0,
+			"source_digest":  "",
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("id IN ?", mergeIDs).Delete(&Dishes{}).Error; err != nil {
+			return err
+		}
+		if err := refreshFavoriteCount(tx, keepID); err != nil {
+			return err
+		}
+		if err := refreshRatingStats(tx, keepID); err != nil {
+			return err
+		}
+		return refreshCookingStats(tx, keepID)
+	})
+}
+
+// moveUnique 把 fromID 上的关联改到 toID，(dish_id, column) 唯一：
+// toID 上已有相同 column 值的关联保留不动，fromID 上剩余的关联删除
+func moveUnique(tx *gorm.DB, model interface{}, column string, toID int64, fromID int64) error {
+	var existing []int64
+	if err := tx.Model(model).Where("dish_id = ?", toID).Pluck(column, &existing).Error; err != nil {
+		return err
+	}
+	query := tx.Model(model).Where("dish_id = ?", fromID)
+	if len(existing) > 0 {
+		query = query.Where(column+" NOT IN ?", existing)
+	}
+	if err := query.Update("dish_id", toID).Error; err != nil {
+		return err
+	}
+	return tx.Where("dish_id = ?", fromID).Delete(model).Error
+}
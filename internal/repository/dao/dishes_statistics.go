@@ -0,0 +1,129 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DishesGrouping 菜品统计的分组方式，Expr 为空时不分组
+type DishesGrouping struct {
+	Expr  string // 分组表达式
+	Label string // 分组名称表达式
+	Join  string // 计算名称需要的关联
+}
+
+var (
+	// DishesGroupingByType 按菜品种类分组
+	DishesGroupingByType = DishesGrouping{
+		Expr:  "CAST(dishes.type AS CHAR)",
+		Label: "COALESCE(dish_types.name, '')",
+		Join:  "LEFT JOIN dish_types ON dish_types.id = dishes.type",
+	}
+	// DishesGroupingByMonth 按创建月份分组
+	DishesGroupingByMonth = DishesGrouping{
+		Expr:  "DATE_FORMAT(FROM_UNIXTIME(dishes.ctime), '%Y-%m')",
+		Label: "DATE_FORMAT(FROM_UNIXTIME(dishes.ctime), '%Y-%m')",
+	}
+)
+
+// DishesDistribution 一组菜品某一列的分布
+type DishesDistribution struct {
+	GroupKey   string
+	GroupLabel string
+	Count      int64
+	Total      int64
+	Min        int64
+	Max        int64
+	Avg        float64
+	P25        float64
+	P50        float64
+	P75        float64
+	P90        float64
+	P95        float64
+}
+
+// DishesPeriodCount 按周期统计的菜品数
+type DishesPeriodCount struct {
+	Period string
+	Count  int64
+}
+
+// distributionColumns 允许统计分布的列
+var distributionColumns = map[string]struct{}{"price": {}, "calorie": {}}
+
+// distributionPercentiles 计算的百分位及其结果列名
+var distributionPercentiles = []struct {
+	fraction float64
+	alias    string
+}{
+	{0.25, "p25"}, {0.5, "p50"}, {0.75, "p75"}, {0.9, "p90"}, {0.95, "p95"},
+}
+
+// Distribution 统计用户菜品某一列的合计、极值、均值与百分位，全部由数据库计算。
+// 百分位在每个分组内按 ROW_NUMBER 取相邻两个排名的值做线性插值，与 PERCENTILE_CONT 一致
+func (d *dishesDAO) Distribution(ctx context.Context, userID int64, column string, grouping DishesGrouping) ([]DishesDistribution, error) {
+	if _, ok := distributionColumns[column]; !ok {
+		return nil, fmt.Errorf("不支持统计的列: %s", column)
+	}
+
+	groupExpr, labelExpr, partition := "''", "''", ""
+	if grouping.Expr != "" {
+		groupExpr, labelExpr = grouping.Expr, grouping.Label
+		partition = "PARTITION BY " + grouping.Expr + " "
+	}
+
+	inner := fmt.Sprintf("SELECT %s AS grp, %s AS label, dishes.%s AS v, "+
+		"ROW_NUMBER() OVER (%sORDER BY dishes.%s, dishes.id) AS rn, "+
+		"COUNT(*) OVER (%s) AS cnt "+
+		"FROM dishes %s WHERE dishes.user_id = ?",
+		groupExpr, labelExpr, column, partition, column, strings.TrimSpace(partition), grouping.Join)
+
+	selects := []string{
+		"grp AS group_key",
+		"MAX(label) AS group_label",
+		"COUNT(*) AS count",
+		"COALESCE(SUM(v), 0) AS total",
+		"COALESCE(MIN(v), 0) AS min",
+		"COALESCE(MAX(v), 0) AS max",
+		"COALESCE(AVG(v), 0) AS avg",
+	}
+	for _, p := range distributionPercentiles {
+		pos := fmt.Sprintf("%g * (cnt - 1)", p.fraction)
+		low := fmt.Sprintf("MAX(CASE WHEN rn = FLOOR(%s) + 1 THEN v END)", pos)
+		high := fmt.Sprintf("MAX(CASE WHEN rn = CEIL(%s) + 1 THEN v END)", pos)
+		frac := fmt.Sprintf("MAX(%s - FLOOR(%s))", pos, pos)
+		selects = append(selects, fmt.Sprintf("COALESCE(%s + (%s - %s) * %s, 0) AS %s", low, high, low, frac, p.alias))
+	}
+
+	var result []DishesDistribution
+	err := d.db.WithContext(ctx).
+		Raw(fmt.Sprintf("SELECT %s FROM (%s) ranked GROUP BY grp ORDER BY grp", strings.Join(selects, ", "), inner), userID).
+		Scan(&result).Error
+	return result, err
+}
+
+// CountCreatedByPeriod 按周期统计用户新建的菜品数，periodFormat 为 DATE_FORMAT 的格式
+func (d *dishesDAO) CountCreatedByPeriod(ctx context.Context, userID int64, periodFormat string, since int64) ([]DishesPeriodCount, error) {
+	var result []DishesPeriodCount
+	err := d.db.WithContext(ctx).
+		Model(&Dishes{}).
+		Select("DATE_FORMAT(FROM_UNIXTIME(dishes.ctime), ?) AS period, COUNT(*) AS count", periodFormat).
+		Where("dishes.user_id = ? AND dishes.ctime >= ?", userID, since).
+		Group("period").
+		Order("period ASC").
+		Scan(&result).Error
+	return result, err
+}
+
+// Top 按排序子句取用户满足条件的前 limit 个菜品
+func (d *dishesDAO) Top(ctx context.Context, userID int64, where string, orderBy string, limit int) ([]Dishes, error) {
+	var dishes []Dishes
+	err := d.db.WithContext(ctx).
+		Where("dishes.user_id = ?", userID).
+		Where(where).
+		Order(orderBy).
+		Limit(limit).
+		Find(&dishes).Error
+	return dishes, err
+}
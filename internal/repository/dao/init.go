@@ -14,12 +14,27 @@ func InitTables(db *egorm.Component) error {
 		&User{},
 		&Dishes{},
 		&DishType{},
+		&DishFavorite{},
+		&DishRating{},
+		&CookingLog{},
+		&Tag{},
+		&DishTag{},
+		&NutritionGoal{},
+		&MealRecord{},
+		&DailyNutritionSnapshot{},
+		&AuditLog{},
+		&APIToken{},
+		&Share{},
 	)
 
 	if err != nil {
 		elog.Error("数据库迁移失败", elog.FieldErr(err))
 		return err
 	}
+	if err := backfillDishTypePaths(db); err != nil {
+		elog.Error("补全菜品种类路径失败", elog.FieldErr(err))
+		return err
+	}
 
 	elog.Info("数据库表迁移成功")
 	return nil
@@ -0,0 +1,177 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/ego-component/egorm"
+	"gorm.io/gorm/clause"
+)
+
+type NutritionGoal struct {
+	UserID        int64 `gorm:"primaryKey;autoIncrement:false;type:BIGINT;comment:'用户ID'"`
+	DailyCalorie  int64 `gorm:"type:BIGINT;default:0;comment:'每日卡路里目标'"`
+	DailyBudget   int64 `gorm:"type:BIGINT;default:0;comment:'每日花费预算'"`
+	WeeklyCalorie int64 `gorm:"type:BIGINT;default:0;comment:'每周卡路里目标'"`
+	WeeklyBudget  int64 `gorm:"type:BIGINT;default:0;comment:'每周花费预算'"`
+	Utime         int64 `gorm:"comment:'更新时间'"`
+}
+
+// TableName 重命名表
+func (NutritionGoal) TableName() string {
+	return "nutrition_goals"
+}
+
+type MealRecord struct {
+	ID       int64  `gorm:"primaryKey;autoIncrement;type:BIGINT;comment:'记录ID'"`
+	UserID   int64  `gorm:"type:BIGINT;index:idx_meal_records_user_day,priority:1;comment:'用户ID'"`
+	DishID   int64  `gorm:"type:BIGINT;comment:'菜品ID'"`
+	DishName string `gorm:"type:VARCHAR(100);comment:'记录时的菜名'"`
+	Day      string `gorm:"type:VARCHAR(10);index:idx_meal_records_user_day,priority:2;index:idx_meal_records_day;comment:'日期 YYYY-MM-DD'"`
+	Servings int64  `gorm:"type:BIGINT;default:1;comment:'份数'"`
+	Calorie  int64  `gorm:"type:BIGINT;comment:'卡路里(已乘份数)'"`
+	Spend    int64  `gorm:"type:BIGINT;comment:'花费(已乘份数)'"`
+	Ctime    int64  `gorm:"comment:'创建时间'"`
+}
+
+// TableName 重命名表
+func (MealRecord) TableName() string {
+	return "meal_records"
+}
+
+type DailyNutritionSnapshot struct {
+	ID            int64  `gorm:"primaryKey;autoIncrement;type:BIGINT;comment:'快照ID'"`
+	UserID        int64  `gorm:"type:BIGINT;uniqueIndex:uni_daily_nutrition_user_day;comment:'用户ID'"`
+	Day           string `gorm:"type:VARCHAR(10);uniqueIndex:uni_daily_nutrition_user_day;comment:'日期 YYYY-MM-DD'"`
+	Meals         int64  `gorm:"type:BIGINT;comment:'记录数'"`
+	Calorie       int64  `gorm:"type:BIGINT;comment:'卡路里合计'"`
+	Spend         int64  `gorm:"type:BIGINT;comment:'花费合计'"`
+	CalorieTarget int64  `gorm:"type:BIGINT;comment:'当日卡路里目标'"`
+	BudgetTarget  int64  `gorm:"type:BIGINT;comment:'当日花费预算'"`
+	Ctime         int64  `gorm:"comment:'创建时间'"`
+}
+
+// TableName 重命名表
+func (DailyNutritionSnapshot) TableName() string {
+	return "daily_nutrition_snapshots"
+}
+
+// MealTotals 饮食记录合计
+type MealTotals struct {
+	Meals   int64
+	Calorie int64
+	Spend   int64
+}
+
+// snapshotBatchSize 每批写入的快照数量
+const snapshotBatchSize = 500
+
+type NutritionDao interface {
+	GetGoal(ctx context.Context, userID int64) (NutritionGoal, error)
+	SaveGoal(ctx context.Context, goal NutritionGoal) error
+	CreateMeal(ctx context.Context, meal MealRecord) (MealRecord, error)
+	GetMeal(ctx context.Context, id int64) (MealRecord, error)
+	DeleteMeal(ctx context.Context, id int64) error
+	FindMeals(ctx context.Context, userID int64, day string) ([]MealRecord, error)
+	SumMeals(ctx context.Context, userID int64, fromDay string, toDay string) (MealTotals, error)
+	BuildSnapshots(ctx context.Context, day string) ([]DailyNutritionSnapshot, error)
+	SaveSnapshots(ctx context.Context, snapshots []DailyNutritionSnapshot) error
+	FindSnapshots(ctx context.Context, userID int64, fromDay string, toDay string) ([]DailyNutritionSnapshot, error)
+}
+
+// Implementation of the NutritionDao interface
+type nutritionDAO struct {
+	db *egorm.Component
+}
+
+// NewNutritionDao creates a new instance of NutritionDao
+func NewNutritionDao(db *egorm.Component) NutritionDao {
+	return &nutritionDAO{db: db}
+}
+
+// GetGoal 获取用户的目标
+func (d *nutritionDAO) GetGoal(ctx context.Context, userID int64) (NutritionGoal, error) {
+	var goal NutritionGoal
+	err := d.db.WithContext(ctx).Where("user_id = ?", userID).First(&goal).Error
+	return goal, err
+}
+
+// SaveGoal 保存用户的目标，已存在时整体覆盖
+func (d *nutritionDAO) SaveGoal(ctx context.Context, goal NutritionGoal) error {
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		UpdateAll: true,
+	}).Create(&goal).Error
+}
+
+// CreateMeal 创建饮食记录
+func (d *nutritionDAO) CreateMeal(ctx context.Context, meal MealRecord) (MealRecord, error) {
+	err := d.db.WithContext(ctx).Create(&meal).Error
+	return meal, err
+}
+
+// GetMeal 根据ID获取饮食记录
+func (d *nutritionDAO) GetMeal(ctx context.Context, id int64) (MealRecord, error) {
+	var meal MealRecord
+	err := d.db.WithContext(ctx).Where("id = ?", id).First(&meal).Error
+	return meal, err
+}
+
+// DeleteMeal 删除饮食记录
+func (d *nutritionDAO) DeleteMeal(ctx context.Context, id int64) error {
+	return d.db.WithContext(ctx).Where("id = ?", id).Delete(&MealRecord{}).Error
+}
+
+// FindMeals 获取用户某一天的饮食记录
+func (d *nutritionDAO) FindMeals(ctx context.Context, userID int64, day string) ([]MealRecord, error) {
+	var meals []MealRecord
+	err := d.db.WithContext(ctx).Where("user_id = ? AND day = ?", userID, day).Order("id ASC").Find(&meals).Error
+	return meals, err
+}
+
+// SumMeals 统计用户在日期范围内（含首尾）的饮食合计
+func (d *nutritionDAO) SumMeals(ctx context.Context, userID int64, fromDay string, toDay string) (MealTotals, error) {
+	var totals MealTotals
+	err := d.db.WithContext(ctx).
+		Model(&MealRecord{}).
+		Select("COUNT(*) AS meals, COALESCE(SUM(calorie), 0) AS calorie, COALESCE(SUM(spend), 0) AS spend").
+		Where("user_id = ? AND day >= ? AND day <= ?", userID, fromDay, toDay).
+		Scan(&totals).Error
+	return totals, err
+}
+
+// BuildSnapshots 按用户汇总某一天的饮食记录，并带上当时的每日目标
+func (d *nutritionDAO) BuildSnapshots(ctx context.Context, day string) ([]DailyNutritionSnapshot, error) {
+	var snapshots []DailyNutritionSnapshot
+	err := d.db.WithContext(ctx).
+		Table("meal_records").
+		Select("meal_records.user_id, meal_records.day, COUNT(*) AS meals, "+
+			"COALESCE(SUM(meal_records.calorie), 0) AS calorie, COALESCE(SUM(meal_records.spend), 0) AS spend, "+
+			"COALESCE(MAX(nutrition_goals.daily_calorie), 0) AS calorie_target, "+
+			"COALESCE(MAX(nutrition_goals.daily_budget), 0) AS budget_target").
+		Joins("LEFT JOIN nutrition_goals ON nutrition_goals.user_id = meal_records.user_id").
+		Where("meal_records.day = ?", day).
+		Group("meal_records.user_id, meal_records.day").
+		Scan(&snapshots).Error
+	return snapshots, err
+}
+
+// SaveSnapshots 批量写入快照，同一用户同一天重复执行时覆盖
+func (d *nutritionDAO) SaveSnapshots(ctx context.Context, snapshots []DailyNutritionSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "day"}},
+		DoUpdates: clause.AssignmentColumns([]string{"meals", "calorie", "spend", "calorie_target", "budget_target", "ctime"}),
+	}).CreateInBatches(&snapshots, snapshotBatchSize).Error
+}
+
+// FindSnapshots 获取用户在日期范围内（含首尾）的每日快照
+func (d *nutritionDAO) FindSnapshots(ctx context.Context, userID int64, fromDay string, toDay string) ([]DailyNutritionSnapshot, error) {
+	var snapshots []DailyNutritionSnapshot
+	err := d.db.WithContext(ctx).
+		Where("user_id = ? AND day >= ? AND day <= ?", userID, fromDay, toDay).
+		Order("day ASC").
+		Find(&snapshots).Error
+	return snapshots, err
+}
@@ -0,0 +1,32 @@
+package dao
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// UpdateSort 在一个事务中改写用户菜品的排序权重，只改写 weights 中的菜品
+func (d *dishesDAO) UpdateSort(ctx context.Context, userID int64, weights map[int64]int64) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return updateSort(tx, &Dishes{}, userID, weights)
+	})
+}
+
+// UpdateSort 在一个事务中改写用户菜品种类的排序权重，只改写 weights 中的种类
+func (d *dishTypeDAO) UpdateSort(ctx context.Context, userID int64, weights map[int64]int64) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return updateSort(tx, &DishType{}, userID, weights)
+	})
+}
+
+// updateSort 逐行改写排序权重，排序不算内容修改，不更新 utime
+func updateSort(tx *gorm.DB, model interface{}, userID int64, weights map[int64]int64) error {
+	for id, weight := range weights {
+		err := tx.Model(model).Where("id = ? AND user_id = ?", id, userID).UpdateColumn("sort", weight).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
+)
+
+// Share 只读分享链接，吊销时直接删除
+type Share struct {
+	ID           int64  `gorm:"primaryKey;autoIncrement;type:BIGINT;comment:'分享ID'"`
+	UserID       int64  `gorm:"type:BIGINT;index:idx_shares_user;comment:'分享者ID'"`
+	Token        string `gorm:"type:VARCHAR(32);uniqueIndex:uni_shares_token;comment:'分享令牌'"`
+	TargetType   string `gorm:"type:VARCHAR(20);comment:'分享对象类型 dish:菜品 dish_type:种类'"`
+	TargetID     int64  `gorm:"type:BIGINT;comment:'分享对象ID'"`
+	ExpiresAt    int64  `gorm:"type:BIGINT;default:0;comment:'过期时间 0:永不过期'"`
+	Views        int64  `gorm:"type:BIGINT;default:0;comment:'访问次数'"`
+	LastViewedAt int64  `gorm:"type:BIGINT;default:0;comment:'最近访问时间'"`
+	Ctime        int64  `gorm:"comment:'创建时间'"`
+}
+
+// TableName 重命名表
+func (Share) TableName() string {
+	return "shares"
+}
+
+type ShareDao interface {
+	Create(ctx context.Context, share Share) (Share, error)
+	GetByToken(ctx context.Context, token string) (Share, error)
+	ListByUser(ctx context.Context, userID int64) ([]Share, error)
+	CountByUser(ctx context.Context, userID int64) (int64, error)
+	// Delete 删除用户的分享，返回删除的行数
+	Delete(ctx context.Context, id int64, userID int64) (int64, error)
+	IncrViews(ctx context.Context, id int64, now int64) error
+	// CountActiveForDish 统计用户对菜品或其种类在 now 时刻仍有效的分享
+	CountActiveForDish(ctx context.Context, userID int64, dishID int64, typeID int64, now int64) (int64, error)
+}
+
+// Implementation of the ShareDao interface
+type shareDAO struct {
+	db *egorm.Component
+}
+
+// NewShareDao creates a new instance of ShareDao
+func NewShareDao(db *egorm.Component) ShareDao {
+	return &shareDAO{db: db}
+}
+
+// Create 创建分享
+func (d *shareDAO) Create(ctx context.Context, share Share) (Share, error) {
+	share.Ctime = time.Now().Unix()
+	err := d.db.WithContext(ctx).Create(&share).Error
+	return share, err
+}
+
+// GetByToken 根据令牌获取分享
+func (d *shareDAO) GetByToken(ctx context.Context, token string) (Share, error) {
+	var share Share
+	err := d.db.WithContext(ctx).Where("token = ?", token).First(&share).Error
+	return share, err
+}
+
+// ListByUser 按创建时间倒序获取用户的分享
+func (d *shareDAO) ListByUser(ctx context.Context, userID int64) ([]Share, error) {
+	var shares []Share
+	err := d.db.WithContext(ctx).Where("user_id = ?", userID).Order("ctime DESC, id DESC").Find(&shares).Error
+	return shares, err
+}
+
+// CountByUser 统计用户的分享数量
+func (d *shareDAO) CountByUser(ctx context.Context, userID int64) (int64, error) {
+	var count int64
+	err := d.db.WithContext(ctx).Model(&Share{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// Delete 删除用户的分享
+func (d *shareDAO) Delete(ctx context.Context, id int64, userID int64) (int64, error) {
+	res := d.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&Share{})
+	return res.RowsAffected, res.Error
+}
+
+// IncrViews 访问次数加一并记录访问时间
+func (d *shareDAO) IncrViews(ctx context.Context, id int64, now int64) error {
+	return d.db.WithContext(ctx).Model(&Share{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"views":          gorm.Expr("views + 1"),
+		"last_viewed_at": now,
+	}).Error
+}
+
+// CountActiveForDish 统计分享该菜品或其所在种类且未过期的分享
+func (d *shareDAO) CountActiveForDish(ctx context.Context, userID int64, dishID int64, typeID int64, now int64) (int64, error) {
+	var count int64
+	db := d.db.WithContext(ctx)
+	err := db.Model(&Share{}).
+		Where("user_id = ? AND (expires_at = 0 OR expires_at > ?)", userID, now).
+		Where(db.Where("target_type = ? AND target_id = ?", "dish", dishID).
+			Or("target_type = ? AND target_id = ?", "dish_type", typeID)).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,153 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Tag struct {
+	ID     int64  `gorm:"primaryKey;autoIncrement;type:BIGINT;comment:'标签ID'"`
+	UserID int64  `gorm:"type:BIGINT;uniqueIndex:uni_tags_user_name;comment:'用户ID'"`
+	Name   string `gorm:"type:VARCHAR(30);uniqueIndex:uni_tags_user_name;comment:'标签名称'"`
+	Color  string `gorm:"type:VARCHAR(20);comment:'标签颜色'"`
+	Ctime  int64  `gorm:"comment:'创建时间'"`
+	Utime  int64  `gorm:"comment:'更新时间'"`
+}
+
+// TableName 重命名表
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// DishTag 菜品与标签的关联
+type DishTag struct {
+	DishID int64 `gorm:"primaryKey;autoIncrement:false;type:BIGINT;comment:'菜品ID'"`
+	TagID  int64 `gorm:"primaryKey;autoIncrement:false;type:BIGINT;index:idx_dish_tags_tag;comment:'标签ID'"`
+	Ctime  int64 `gorm:"comment:'创建时间'"`
+}
+
+// TableName 重命名表
+func (DishTag) TableName() string {
+	return "dish_tags"
+}
+
+// TagWithCount 带菜品数的标签
+type TagWithCount struct {
+	Tag
+	DishCount int64
+}
+
+// DishTagWithName 带标签信息的菜品关联，用于批量加载菜品的标签
+type DishTagWithName struct {
+	DishID int64
+	Tag
+}
+
+type TagDao interface {
+	Create(ctx context.Context, tag Tag) (Tag, error)
+	GetByID(ctx context.Context, id int64) (Tag, error)
+	GetByUserIDAndName(ctx context.Context, userID int64, name string) (Tag, error)
+	FindByUserID(ctx context.Context, userID int64) ([]TagWithCount, error)
+	FindByDishIDs(ctx context.Context, dishIDs []int64) ([]DishTagWithName, error)
+	Update(ctx context.Context, tag Tag) error
+	Delete(ctx context.Context, id int64) error
+	AttachDishes(ctx context.Context, tagID int64, dishIDs []int64) error
+	DetachDish(ctx context.Context, tagID int64, dishID int64) error
+}
+
+// Implementation of the TagDao interface
+type tagDAO struct {
+	db *egorm.Component
+}
+
+// NewTagDao creates a new instance of TagDao
+func NewTagDao(db *egorm.Component) TagDao {
+	return &tagDAO{db: db}
+}
+
+// Create 创建标签
+func (d *tagDAO) Create(ctx context.Context, tag Tag) (Tag, error) {
+	err := d.db.WithContext(ctx).Create(&tag).Error
+	return tag, err
+}
+
+// GetByID 根据ID获取标签
+func (d *tagDAO) GetByID(ctx context.Context, id int64) (Tag, error) {
+	var tag Tag
+	err := d.db.WithContext(ctx).Where("id = ?", id).First(&tag).Error
+	return tag, err
+}
+
+// GetByUserIDAndName 根据用户与名称获取标签
+func (d *tagDAO) GetByUserIDAndName(ctx context.Context, userID int64, name string) (Tag, error) {
+	var tag Tag
+	err := d.db.WithContext(ctx).Where("user_id = ? AND name = ?", userID, name).First(&tag).Error
+	return tag, err
+}
+
+// FindByUserID 获取用户的全部标签及每个标签下的菜品数
+func (d *tagDAO) FindByUserID(ctx context.Context, userID int64) ([]TagWithCount, error) {
+	var tags []TagWithCount
+	err := d.db.WithContext(ctx).
+		Table("tags").
+		Select("tags.*, (SELECT COUNT(*) FROM dish_tags WHERE dish_tags.tag_id = tags.id) AS dish_count").
+		Where("tags.user_id = ?", userID).
+		Order("tags.name ASC, tags.id ASC").
+		Scan(&tags).Error
+	return tags, err
+}
+
+// FindByDishIDs 批量获取菜品的标签
+func (d *tagDAO) FindByDishIDs(ctx context.Context, dishIDs []int64) ([]DishTagWithName, error) {
+	var result []DishTagWithName
+	if len(dishIDs) == 0 {
+		return result, nil
+	}
+
+	err := d.db.WithContext(ctx).
+		Table("dish_tags").
+		Select("dish_tags.dish_id, tags.*").
+		Joins("JOIN tags ON tags.id = dish_tags.tag_id").
+		Where("dish_tags.dish_id IN ?", dishIDs).
+		Order("tags.name ASC, tags.id ASC").
+		Scan(&result).Error
+	return result, err
+}
+
+// Update 更新标签
+func (d *tagDAO) Update(ctx context.Context, tag Tag) error {
+	return d.db.WithContext(ctx).Save(&tag).Error
+}
+
+// Delete 删除标签及其与菜品的关联
+func (d *tagDAO) Delete(ctx context.Context, id int64) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tag_id = ?", id).Delete(&DishTag{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&Tag{}).Error
+	})
+}
+
+// AttachDishes 为标签关联菜品，已关联的菜品忽略
+func (d *tagDAO) AttachDishes(ctx context.Context, tagID int64, dishIDs []int64) error {
+	if len(dishIDs) == 0 {
+		return nil
+	}
+
+	now := time.Now().Unix()
+	rows := make([]DishTag, 0, len(dishIDs))
+	for _, dishID := range dishIDs {
+		rows = append(rows, DishTag{DishID: dishID, TagID: tagID, Ctime: now})
+	}
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+}
+
+// DetachDish 取消标签与菜品的关联
+func (d *tagDAO) DetachDish(ctx context.Context, tagID int64, dishID int64) error {
+	return d.db.WithContext(ctx).Where("tag_id = ? AND dish_id = ?", tagID, dishID).Delete(&DishTag{}).Error
+}
@@ -5,17 +5,29 @@ import (
 	"time"
 
 	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
 )
 
 type User struct {
-	ID        uint64 `gorm:"primaryKey;type:BIGINT;comment:'用户ID'"`
-	Username  string `gorm:"type:VARCHAR(50);uniqueIndex:uni_users_username;comment:'用户名'"`
-	Password  string `gorm:"type:VARCHAR(255);comment:'密码(加密后)'"`
-	Avatar    string `gorm:"type:VARCHAR(200);comment:'头像URL'"`
-	Status    int64  `gorm:"type:BIGINT;default:1;comment:'状态 1:正常 0:禁用'"`
-	LastLogin int64  `gorm:"type:BIGINT;comment:'最后登录时间'"`
-	Ctime     int64  `gorm:"comment:'创建时间'"`
-	Utime     int64  `gorm:"comment:'更新时间'"`
+	ID       uint64 `gorm:"primaryKey;type:BIGINT;comment:'用户ID'"`
+	Username string `gorm:"type:VARCHAR(50);uniqueIndex:uni_users_username;comment:'用户名'"`
+	Password string `gorm:"type:VARCHAR(255);comment:'密码(加密后)'"`
+	Nickname string `gorm:"type:VARCHAR(50);default:'';comment:'显示名称'"`
+	Avatar   string `gorm:"type:VARCHAR(200);comment:'头像URL'"`
+	// Email 只保存验证过的邮箱，未设置时为 NULL，唯一索引允许多个 NULL
+	Email           *string `gorm:"type:VARCHAR(100);uniqueIndex:uni_users_email;comment:'邮箱'"`
+	EmailVerifiedAt int64   `gorm:"type:BIGINT;default:0;comment:'邮箱验证时间 0:未验证'"`
+	// PendingEmail 等待验证的邮箱，不加唯一约束，设置时无法探测邮箱是否已被其他账号使用
+	PendingEmail string `gorm:"type:VARCHAR(100);default:'';comment:'待验证的邮箱'"`
+	Role            string  `gorm:"type:VARCHAR(20);default:'user';comment:'角色 user:普通用户 admin:管理员'"`
+	Status          int64   `gorm:"type:BIGINT;default:1;comment:'状态 1:正常 0:禁用'"`
+	LastLogin       int64   `gorm:"type:BIGINT;comment:'最后登录时间'"`
+	Allergens       string  `gorm:"type:VARCHAR(255);default:'';comment:'过敏原(逗号分隔)'"`
+	Diets           string  `gorm:"type:VARCHAR(255);default:'';comment:'饮食要求(逗号分隔)'"`
+	// TokenVersion 修改密码时递增，使之前签发的刷新令牌失效
+	TokenVersion int64 `gorm:"type:BIGINT;default:0;comment:'令牌版本'"`
+	Ctime        int64 `gorm:"comment:'创建时间'"`
+	Utime        int64 `gorm:"comment:'更新时间'"`
 }
 
 // TableName 重命名表
@@ -27,12 +39,26 @@ type UserDao interface {
 	Create(ctx context.Context, user User) (User, error)
 	GetByID(ctx context.Context, id int64) (User, error)
 	GetByUsername(ctx context.Context, username string) (User, error)
+	GetByEmail(ctx context.Context, email string) (User, error)
 	Update(ctx context.Context, user User) (User, error)
+	UpdateProfile(ctx context.Context, id int64, nickname string, avatar string) error
 	UpdatePassword(ctx context.Context, id int64, newPassword string) error
+	// SetPendingEmail 设置待验证的邮箱，为空表示撤销，已验证的邮箱保持不变
+	SetPendingEmail(ctx context.Context, id int64, email string) error
+	// ClearEmail 解除邮箱绑定，同时清除待验证的邮箱
+	ClearEmail(ctx context.Context, id int64) error
+	// MarkEmailVerified 待验证的邮箱仍为 email 时把它设为已验证的邮箱，返回更新的行数
+	MarkEmailVerified(ctx context.Context, id int64, email string) (int64, error)
 	UpdateLastLogin(ctx context.Context, id int64) error
+	UpdateDietaryProfile(ctx context.Context, id int64, allergens string, diets string) error
 	Delete(ctx context.Context, id int64) error
-	Find(ctx context.Context, offset int, limit int) ([]User, error)
-	Count(ctx context.Context) (int64, error)
+	// DeleteAccount 删除用户及其全部数据，返回被删除或统计数据有变化的菜品（仅含 ID 与 UserID），供调用方清除缓存
+	DeleteAccount(ctx context.Context, id int64) ([]Dishes, error)
+	UpdateStatus(ctx context.Context, id int64, status int64) error
+	UpdateRole(ctx context.Context, id int64, role string) error
+	IncrTokenVersion(ctx context.Context, id int64) error
+	Find(ctx context.Context, keyword string, offset int, limit int) ([]User, error)
+	Count(ctx context.Context, keyword string) (int64, error)
 	CheckUsernameExists(ctx context.Context, username string) (bool, error)
 }
 
@@ -71,6 +97,13 @@ func (u *userDAO) GetByUsername(ctx context.Context, username string) (User, err
 	return user, err
 }
 
+// GetByEmail 根据邮箱获取用户信息
+func (u *userDAO) GetByEmail(ctx context.Context, email string) (User, error) {
+	var user User
+	err := u.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
+	return user, err
+}
+
 // Update 更新用户信息
 func (u *userDAO) Update(ctx context.Context, user User) (User, error) {
 	user.Utime = time.Now().Unix()
@@ -78,14 +111,55 @@ func (u *userDAO) Update(ctx context.Context, user User) (User, error) {
 	return user, err
 }
 
-// UpdatePassword 更新用户密码
-func (u *userDAO) UpdatePassword(ctx context.Context, id int64, newPassword string) error {
+// UpdateProfile 更新显示名称与头像
+func (u *userDAO) UpdateProfile(ctx context.Context, id int64, nickname string, avatar string) error {
 	return u.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Updates(map[string]interface{}{
-		"password": newPassword,
+		"nickname": nickname,
+		"avatar":   avatar,
 		"utime":    time.Now().Unix(),
 	}).Error
 }
 
+// UpdatePassword 更新用户密码，同时递增令牌版本
+func (u *userDAO) UpdatePassword(ctx context.Context, id int64, newPassword string) error {
+	return u.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"password":      newPassword,
+		"token_version": gorm.Expr("token_version + 1"),
+		"utime":         time.Now().Unix(),
+	}).Error
+}
+
+// SetPendingEmail 设置待验证的邮箱
+func (u *userDAO) SetPendingEmail(ctx context.Context, id int64, email string) error {
+	return u.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"pending_email": email,
+		"utime":         time.Now().Unix(),
+	}).Error
+}
+
+// ClearEmail 解除邮箱绑定并清除验证状态
+func (u *userDAO) ClearEmail(ctx context.Context, id int64) error {
+	return u.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"email":             nil,
+		"pending_email":     "",
+		"email_verified_at": 0,
+		"utime":             time.Now().Unix(),
+	}).Error
+}
+
+// MarkEmailVerified 把待验证的邮箱设为已验证的邮箱，邮箱的唯一约束在这里生效。
+// 以待验证的邮箱为条件，验证邮件发出后又修改了邮箱时不会误标记
+func (u *userDAO) MarkEmailVerified(ctx context.Context, id int64, email string) (int64, error) {
+	now := time.Now().Unix()
+	res := u.db.WithContext(ctx).Model(&User{}).Where("id = ? AND pending_email = ?", id, email).Updates(map[string]interface{}{
+		"email":             email,
+		"pending_email":     "",
+		"email_verified_at": now,
+		"utime":             now,
+	})
+	return res.RowsAffected, res.Error
+}
+
 // UpdateLastLogin 更新最后登录时间
 func (u *userDAO) UpdateLastLogin(ctx context.Context, id int64) error {
 	return u.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Updates(map[string]interface{}{
@@ -94,25 +168,130 @@ func (u *userDAO) UpdateLastLogin(ctx context.Context, id int64) error {
 	}).Error
 }
 
+// UpdateDietaryProfile 更新用户的过敏原与饮食要求
+func (u *userDAO) UpdateDietaryProfile(ctx context.Context, id int64, allergens string, diets string) error {
+	return u.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"allergens": allergens,
+		"diets":     diets,
+		"utime":     time.Now().Unix(),
+	}).Error
+}
+
 // Delete 删除用户
 func (u *userDAO) Delete(ctx context.Context, id int64) error {
 	return u.db.WithContext(ctx).Where("id = ?", id).Delete(&User{}).Error
 }
 
-// Find 分页查询用户列表
-func (u *userDAO) Find(ctx context.Context, offset int, limit int) ([]User, error) {
+// DeleteAccount 在一个事务中删除用户及其全部数据：
+// 菜品与其标签关联、收藏、评分，种类，标签，烹饪记录，营养目标、饮食记录与每日快照，访问令牌与分享链接。
+// 用户对其他人菜品的收藏与评分一并删除，并重新计算这些菜品的收藏数与评分统计
+func (u *userDAO) DeleteAccount(ctx context.Context, id int64) ([]Dishes, error) {
+	var affected []Dishes
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		userDishes := tx.Model(&Dishes{}).Select("id").Where("user_id = ?", id)
+		userTags := tx.Model(&Tag{}).Select("id").Where("user_id = ?", id)
+
+		var owned, others []Dishes
+		if err := tx.Select("id", "user_id").Where("user_id = ?", id).Find(&owned).Error; err != nil {
+			return err
+		}
+		if err := tx.Select("id", "user_id").Where("user_id <> ?", id).
+			Where("id IN (?) OR id IN (?)",
+				tx.Model(&DishFavorite{}).Select("dish_id").Where("user_id = ?", id),
+				tx.Model(&DishRating{}).Select("dish_id").Where("user_id = ?", id)).
+			Find(&others).Error; err != nil {
+			return err
+		}
+
+		deletions := []struct {
+			model interface{}
+			query string
+			args  []interface{}
+		}{
+			{&DishTag{}, "dish_id IN (?) OR tag_id IN (?)", []interface{}{userDishes, userTags}},
+			{&DishFavorite{}, "user_id = ? OR dish_id IN (?)", []interface{}{id, userDishes}},
+			{&DishRating{}, "user_id = ? OR dish_id IN (?)", []interface{}{id, userDishes}},
+			{&CookingLog{}, "user_id = ?", []interface{}{id}},
+			{&Tag{}, "user_id = ?", []interface{}{id}},
+			{&Dishes{}, "user_id = ?", []interface{}{id}},
+			{&DishType{}, "user_id = ?", []interface{}{id}},
+			{&NutritionGoal{}, "user_id = ?", []interface{}{id}},
+			{&MealRecord{}, "user_id = ?", []interface{}{id}},
+			{&DailyNutritionSnapshot{}, "user_id = ?", []interface{}{id}},
+			{&APIToken{}, "user_id = ?", []interface{}{id}},
+			{&Share{}, "user_id = ?", []interface{}{id}},
+			{&User{}, "id = ?", []interface{}{id}},
+		}
+		for _, d := range deletions {
+			if err := tx.Where(d.query, d.args...).Delete(d.model).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, dish := range others {
+			if err := refreshFavoriteCount(tx, dish.ID); err != nil {
+				return err
+			}
+			if err := refreshRatingStats(tx, dish.ID); err != nil {
+				return err
+			}
+		}
+		affected = append(owned, others...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return affected, nil
+}
+
+// UpdateStatus 更新用户状态
+func (u *userDAO) UpdateStatus(ctx context.Context, id int64, status int64) error {
+	return u.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status": status,
+		"utime":  time.Now().Unix(),
+	}).Error
+}
+
+// UpdateRole 更新用户角色
+func (u *userDAO) UpdateRole(ctx context.Context, id int64, role string) error {
+	return u.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"role":  role,
+		"utime": time.Now().Unix(),
+	}).Error
+}
+
+// IncrTokenVersion 递增令牌版本，使已签发的刷新令牌失效
+func (u *userDAO) IncrTokenVersion(ctx context.Context, id int64) error {
+	return u.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"token_version": gorm.Expr("token_version + 1"),
+		"utime":         time.Now().Unix(),
+	}).Error
+}
+
+// Find 分页查询用户列表，keyword 非空时按用户名与显示名称模糊匹配
+func (u *userDAO) Find(ctx context.Context, keyword string, offset int, limit int) ([]User, error) {
 	var users []User
-	err := u.db.WithContext(ctx).Offset(offset).Limit(limit).Order("ctime DESC").Find(&users).Error
+	err := u.search(ctx, keyword).Offset(offset).Limit(limit).Order("ctime DESC").Find(&users).Error
 	return users, err
 }
 
-// Count 统计用户总数
-func (u *userDAO) Count(ctx context.Context) (int64, error) {
+// Count 统计用户总数，keyword 含义同 Find
+func (u *userDAO) Count(ctx context.Context, keyword string) (int64, error) {
 	var count int64
-	err := u.db.WithContext(ctx).Model(&User{}).Count(&count).Error
+	err := u.search(ctx, keyword).Count(&count).Error
 	return count, err
 }
 
+func (u *userDAO) search(ctx context.Context, keyword string) *gorm.DB {
+	query := u.db.WithContext(ctx).Model(&User{})
+	if keyword != "" {
+		like := "%" + escapeLike(keyword) + "%"
+		query = query.Where("(username LIKE ? OR nickname LIKE ?)", like, like)
+	}
+	return query
+}
+
 // CheckUsernameExists 检查用户名是否已存在
 func (u *userDAO) CheckUsernameExists(ctx context.Context, username string) (bool, error) {
 	var count int64
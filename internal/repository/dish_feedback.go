@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/repository/cache"
+	"loverrecipe/internal/repository/dao"
+
+	"github.com/ego-component/egorm"
+	"github.com/gotomicro/ego/core/elog"
+	"gorm.io/gorm"
+)
+
+type DishFeedbackRepository interface {
+	AddFavorite(ctx context.Context, userID int64, dishID int64) error
+	RemoveFavorite(ctx context.Context, userID int64, dishID int64) error
+	IsFavorite(ctx context.Context, userID int64, dishID int64) (bool, error)
+	SaveRating(ctx context.Context, req domain.RateDishesRequest) (*domain.DishRating, error)
+	DeleteRating(ctx context.Context, userID int64, dishID int64) error
+	GetRating(ctx context.Context, userID int64, dishID int64) (*domain.DishRating, error)
+	ListRatings(ctx context.Context, dishID int64, offset int, limit int) (*domain.DishRatingListResponse, error)
+}
+
+// dishFeedbackRepository 评分与收藏仓储，写入会改变菜品的评分与收藏数，写入后清除菜品缓存。
+// 评分与收藏的用户不一定是菜品的所属用户，清除列表缓存前先查出所属用户
+type dishFeedbackRepository struct {
+	feedbackDao dao.DishFeedbackDao
+	dishesDao   dao.DishesDao
+	dishesCache cache.DishesCache
+}
+
+func NewDishFeedbackRepository(db *egorm.Component, dishesCache cache.DishesCache) DishFeedbackRepository {
+	return &dishFeedbackRepository{
+		feedbackDao: dao.NewDishFeedbackDao(db),
+		dishesDao:   dao.NewDishesDao(db),
+		dishesCache: dishesCache,
+	}
+}
+
+// AddFavorite 收藏菜品
+func (r *dishFeedbackRepository) AddFavorite(ctx context.Context, userID int64, dishID int64) error {
+	if err := r.feedbackDao.AddFavorite(ctx, userID, dishID); err != nil {
+		return err
+	}
+	r.invalidateDishes(ctx, dishID)
+	return nil
+}
+
+// RemoveFavorite 取消收藏
+func (r *dishFeedbackRepository) RemoveFavorite(ctx context.Context, userID int64, dishID int64) error {
+	if err := r.feedbackDao.RemoveFavorite(ctx, userID, dishID); err != nil {
+		return err
+	}
+	r.invalidateDishes(ctx, dishID)
+	return nil
+}
+
+// IsFavorite 检查是否已收藏
+func (r *dishFeedbackRepository) IsFavorite(ctx context.Context, userID int64, dishID int64) (bool, error) {
+	return r.feedbackDao.IsFavorite(ctx, userID, dishID)
+}
+
+// SaveRating 保存评分
+func (r *dishFeedbackRepository) SaveRating(ctx context.Context, req domain.RateDishesRequest) (*domain.DishRating, error) {
+	saved, err := r.feedbackDao.SaveRating(ctx, dao.DishRating{
+		UserID:  req.UserID,
+		DishID:  req.DishID,
+		Score:   req.Score,
+		Comment: req.Comment,
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.invalidateDishes(ctx, saved.DishID)
+
+	// upsert 时返回的ID不可靠，重新读取一次
+	return r.GetRating(ctx, saved.UserID, saved.DishID)
+}
+
+// DeleteRating 删除评分
+func (r *dishFeedbackRepository) DeleteRating(ctx context.Context, userID int64, dishID int64) error {
+	if err := r.feedbackDao.DeleteRating(ctx, userID, dishID); err != nil {
+		return err
+	}
+	r.invalidateDishes(ctx, dishID)
+	return nil
+}
+
+// invalidateDishes 清除菜品详情与其所属用户的列表缓存。
+// 查不到所属用户时只清除详情，列表由过期时间兜底
+func (r *dishFeedbackRepository) invalidateDishes(ctx context.Context, dishID int64) {
+	dish, err := r.dishesDao.GetByID(ctx, dishID)
+	if err != nil {
+		elog.Warn("查询菜品所属用户失败", elog.FieldErr(err), elog.Int64("dishID", dishID))
+	}
+	invalidateDishesCache(ctx, r.dishesCache, dishID, dish.UserID)
+}
+
+// GetRating 获取用户对菜品的评分
+func (r *dishFeedbackRepository) GetRating(ctx context.Context, userID int64, dishID int64) (*domain.DishRating, error) {
+	rating, err := r.feedbackDao.GetRating(ctx, userID, dishID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrDishRatingNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.daoToDomain(rating), nil
+}
+
+// ListRatings 分页查询菜品的评分
+func (r *dishFeedbackRepository) ListRatings(ctx context.Context, dishID int64, offset int, limit int) (*domain.DishRatingListResponse, error) {
+	ratings, err := r.feedbackDao.FindRatingsByDishID(ctx, dishID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	total, err := r.feedbackDao.CountRatingsByDishID(ctx, dishID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]domain.DishRating, 0, len(ratings))
+	for _, rating := range ratings {
+		list = append(list, *r.daoToDomain(rating))
+	}
+	return &domain.DishRatingListResponse{
+		List:  list,
+		Total: total,
+		Page:  offset/limit + 1,
+		Size:  limit,
+	}, nil
+}
+
+// daoToDomain 将DAO对象转换为领域对象
+func (r *dishFeedbackRepository) daoToDomain(rating dao.DishRating) *domain.DishRating {
+	return &domain.DishRating{
+		ID:      rating.ID,
+		UserID:  rating.UserID,
+		DishID:  rating.DishID,
+		Score:   rating.Score,
+		Comment: rating.Comment,
+		Ctime:   rating.Ctime,
+		Utime:   rating.Utime,
+	}
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/repository/dao"
+
+	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
+)
+
+// DishTypeMoveCheck 移动种类前的校验，dishType 与 parent 为事务中加锁后读到的最新数据，
+// parent 为 nil 表示移到顶层或上级已不存在；height 为以 dishType 为根的子树层数
+type DishTypeMoveCheck func(dishType domain.DishType, parent *domain.DishType, height int) error
+
+type DishTypeRepository interface {
+	Create(ctx context.Context, dishType domain.DishType) (*domain.DishType, error)
+	GetByID(ctx context.Context, id int64) (*domain.DishType, error)
+	GetByIDs(ctx context.Context, ids []int64) (map[int64]domain.DishType, error)
+	GetByUserID(ctx context.Context, userID int64) ([]domain.DishType, error)
+	// Move 把种类连同子孙种类移到 parentID 下，0 表示移到顶层。
+	// check 在事务中对加锁后的最新数据执行，返回错误时不做任何修改，见 DishTypeMoveCheck
+	Move(ctx context.Context, dishType domain.DishType, parentID int64, check DishTypeMoveCheck) (*domain.DishType, error)
+	// UpdateSort 改写用户种类的排序权重，调用方负责校验种类归属
+	UpdateSort(ctx context.Context, userID int64, weights map[int64]int64) error
+}
+
+type dishTypeRepository struct {
+	dishTypeDao dao.DishTypeDao
+}
+
+func NewDishTypeRepository(db *egorm.Component) DishTypeRepository {
+	return &dishTypeRepository{
+		dishTypeDao: dao.NewDishTypeDao(db),
+	}
+}
+
+// Create 创建菜品种类，有上级种类时路径接在上级之后
+func (r *dishTypeRepository) Create(ctx context.Context, dishType domain.DishType) (*domain.DishType, error) {
+	var parentPath string
+	if dishType.ParentID > 0 {
+		parent, err := r.dishTypeDao.GetByID(ctx, dishType.ParentID)
+		if err != nil {
+			return nil, domain.ErrDishTypeParentInvalid
+		}
+		parentPath = parent.Path
+	}
+	saved, err := r.dishTypeDao.Create(ctx, r.domainToDao(dishType), parentPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.daoToDomain(saved), nil
+}
+
+// GetByID 根据ID获取菜品种类
+func (r *dishTypeRepository) GetByID(ctx context.Context, id int64) (*domain.DishType, error) {
+	daoDishType, err := r.dishTypeDao.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrDishTypeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.daoToDomain(daoDishType), nil
+}
+
+// GetByIDs 根据ID列表批量获取菜品种类
+func (r *dishTypeRepository) GetByIDs(ctx context.Context, ids []int64) (map[int64]domain.DishType, error) {
+	daoDishTypes, err := r.dishTypeDao.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int64]domain.DishType, len(daoDishTypes))
+	for id, dt := range daoDishTypes {
+		result[id] = *r.daoToDomain(dt)
+	}
+	return result, nil
+}
+
+// GetByUserID 根据用户ID获取菜品种类列表
+func (r *dishTypeRepository) GetByUserID(ctx context.Context, userID int64) ([]domain.DishType, error) {
+	daoDishTypes, err := r.dishTypeDao.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.DishType, 0, len(daoDishTypes))
+	for _, dt := range daoDishTypes {
+		result = append(result, *r.daoToDomain(dt))
+	}
+	return result, nil
+}
+
+// Move 移动菜品种类，子孙种类的路径在同一事务中更新
+func (r *dishTypeRepository) Move(ctx context.Context, dishType domain.DishType, parentID int64, check DishTypeMoveCheck) (*domain.DishType, error) {
+	moved, err := r.dishTypeDao.Move(ctx, dishType.UserID, dishType.ID, parentID,
+		func(locked dao.DishType, parent *dao.DishType, height int) error {
+			if parent == nil {
+				return check(*r.daoToDomain(locked), nil, height)
+			}
+			return check(*r.daoToDomain(locked), r.daoToDomain(*parent), height)
+		})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrDishTypeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.daoToDomain(moved), nil
+}
+
+// UpdateSort 在一个事务中改写种类的排序权重
+func (r *dishTypeRepository) UpdateSort(ctx context.Context, userID int64, weights map[int64]int64) error {
+	return r.dishTypeDao.UpdateSort(ctx, userID, weights)
+}
+
+// daoToDomain 将DAO对象转换为领域对象
+func (r *dishTypeRepository) daoToDomain(dt dao.DishType) *domain.DishType {
+	return &domain.DishType{
+		ID:          dt.ID,
+		UserID:      dt.UserID,
+		Name:        dt.Name,
+		Description: dt.Description,
+		Icon:        dt.Icon,
+		Color:       dt.Color,
+		Sort:        dt.Sort,
+		Status:      dt.Status,
+		Ctime:       dt.Ctime,
+		Utime:       dt.Utime,
+		ParentID:    dt.ParentID,
+		Path:        dt.Path,
+	}
+}
+
+// domainToDao 将领域对象转换为DAO对象
+func (r *dishTypeRepository) domainToDao(dt domain.DishType) dao.DishType {
+	return dao.DishType{
+		ID:          dt.ID,
+		UserID:      dt.UserID,
+		Name:        dt.Name,
+		Description: dt.Description,
+		Icon:        dt.Icon,
+		Color:       dt.Color,
+		Sort:        dt.Sort,
+		Status:      dt.Status,
+		Ctime:       dt.Ctime,
+		Utime:       dt.Utime,
+		ParentID:    dt.ParentID,
+		Path:        dt.Path,
+	}
+}
@@ -2,34 +2,52 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"loverrecipe/internal/domain"
 	"loverrecipe/internal/repository/dao"
+	"strings"
 
 	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
 )
 
 type DishesRepository interface {
 	Create(ctx context.Context, req domain.CreateDishesRequest) (*domain.Dishes, error)
 	GetByID(ctx context.Context, id int64) (*domain.Dishes, error)
+	GetByIDs(ctx context.Context, ids []int64) (map[int64]domain.Dishes, error)
 	GetByUserID(ctx context.Context, userID int64) ([]domain.Dishes, error)
 	GetByType(ctx context.Context, typeID int64) ([]domain.Dishes, error)
 	GetByUserIDAndType(ctx context.Context, userID int64, typeID int64) ([]domain.Dishes, error)
+	// GetByTypeTree 获取用户的种类及其全部子孙种类下的菜品，调用方负责校验种类归属
+	GetByTypeTree(ctx context.Context, userID int64, typeID int64) ([]domain.Dishes, error)
+	// CountByType 按种类统计用户的菜品数，键为种类ID
+	CountByType(ctx context.Context, userID int64) (map[int64]int64, error)
 	GetDishesWithTypeInfo(ctx context.Context, userID int64) ([]domain.DishesWithType, error)
 	Update(ctx context.Context, req domain.UpdateDishesRequest) (*domain.Dishes, error)
 	Delete(ctx context.Context, id int64, userID int64) error
+	// Merge 把用户的 mergeIDs 菜品合并到 keepID 后删除，调用方负责校验菜品归属
+	Merge(ctx context.Context, userID int64, keepID int64, mergeIDs []int64) error
+	// UpdateSort 改写用户菜品的排序权重，调用方负责校验菜品归属
+	UpdateSort(ctx context.Context, userID int64, weights map[int64]int64) error
 	List(ctx context.Context, query domain.DishesQuery) (*domain.DishesListResponse, error)
 	Count(ctx context.Context) (int64, error)
+	Aggregate(ctx context.Context, userID int64, groupBy domain.DishesGroupBy) ([]domain.DishesAggregate, error)
+	CountCreatedByPeriod(ctx context.Context, userID int64, period domain.StatisticsPeriod, since int64) ([]domain.PeriodCount, error)
+	Top(ctx context.Context, userID int64, sort domain.DishesSort, limit int) ([]domain.Dishes, error)
 }
 
 type dishesRepository struct {
 	dishesDao   dao.DishesDao
 	dishTypeDao dao.DishTypeDao
+	tagDao      dao.TagDao
 }
 
 func NewDishesRepository(db *egorm.Component) DishesRepository {
 	return &dishesRepository{
 		dishesDao:   dao.NewDishesDao(db),
 		dishTypeDao: dao.NewDishTypeDao(db),
+		tagDao:      dao.NewTagDao(db),
 	}
 }
 
@@ -41,21 +59,8 @@ func (r *dishesRepository) Create(ctx context.Context, req domain.CreateDishesRe
 		return nil, err
 	}
 
-	// 转换为DAO对象
-	daoDishes := dao.Dishes{
-		UserID:  dishes.UserID,
-		Name:    dishes.Name,
-		Desc:    dishes.Desc,
-		Price:   dishes.Price,
-		Img:     dishes.Img,
-		Type:    dishes.Type,
-		Calorie: dishes.Calorie,
-		Ctime:   dishes.Ctime,
-		Utime:   dishes.Utime,
-	}
-
-	// 保存到数据库
-	savedDishes, err := r.dishesDao.Save(ctx, daoDishes)
+	// 转换为DAO对象并保存到数据库
+	savedDishes, err := r.dishesDao.Save(ctx, r.domainToDao(*dishes))
 	if err != nil {
 		return nil, err
 	}
@@ -75,6 +80,20 @@ func (r *dishesRepository) GetByID(ctx context.Context, id int64) (*domain.Dishe
 	return r.daoToDomain(daoDishes), nil
 }
 
+// GetByIDs 根据ID列表批量获取菜品
+func (r *dishesRepository) GetByIDs(ctx context.Context, ids []int64) (map[int64]domain.Dishes, error) {
+	daoDishes, err := r.dishesDao.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int64]domain.Dishes, len(daoDishes))
+	for id, dish := range daoDishes {
+		result[id] = *r.daoToDomain(dish)
+	}
+	return result, nil
+}
+
 // GetByUserID 根据用户ID获取菜品列表
 func (r *dishesRepository) GetByUserID(ctx context.Context, userID int64) ([]domain.Dishes, error) {
 	daoDishes, err := r.dishesDao.GetByUserID(ctx, userID)
@@ -105,6 +124,42 @@ func (r *dishesRepository) GetByUserIDAndType(ctx context.Context, userID int64,
 	return r.daoListToDomainList(daoDishes), nil
 }
 
+// GetByTypeTree 按种类路径前缀一次查出子树下的菜品。种类还没有补全路径时拒绝查询，空前缀会匹配全部种类
+func (r *dishesRepository) GetByTypeTree(ctx context.Context, userID int64, typeID int64) ([]domain.Dishes, error) {
+	dishType, err := r.dishTypeDao.GetByID(ctx, typeID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrDishTypeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if dishType.UserID != userID {
+		return nil, domain.ErrDishTypeUserMismatch
+	}
+	if dishType.Path == "" {
+		return nil, domain.ErrDishTypePathMissing
+	}
+	daoDishes, err := r.dishesDao.GetByTypePath(ctx, userID, dishType.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.daoListToDomainList(daoDishes), nil
+}
+
+// CountByType 按种类统计用户的菜品数
+func (r *dishesRepository) CountByType(ctx context.Context, userID int64) (map[int64]int64, error) {
+	counts, err := r.dishesDao.CountByType(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int64]int64, len(counts))
+	for _, c := range counts {
+		result[c.Type] = c.Count
+	}
+	return result, nil
+}
+
 // GetDishesWithTypeInfo 获取菜品及其种类信息
 func (r *dishesRepository) GetDishesWithTypeInfo(ctx context.Context, userID int64) ([]domain.DishesWithType, error) {
 	daoDishesWithType, err := r.dishesDao.GetDishesWithTypeInfo(ctx, userID)
@@ -114,25 +169,7 @@ func (r *dishesRepository) GetDishesWithTypeInfo(ctx context.Context, userID int
 
 	var result []domain.DishesWithType
 	for _, dt := range daoDishesWithType {
-		dishesWithType := domain.DishesWithType{
-			Dishes: domain.Dishes{
-				ID:      dt.ID,
-				UserID:  dt.UserID,
-				Name:    dt.Name,
-				Desc:    dt.Desc,
-				Price:   dt.Price,
-				Img:     dt.Img,
-				Type:    dt.Type,
-				Calorie: dt.Calorie,
-				Ctime:   dt.Ctime,
-				Utime:   dt.Utime,
-			},
-			TypeName:        dt.TypeName,
-			TypeDescription: dt.TypeDescription,
-			TypeIcon:        dt.TypeIcon,
-			TypeColor:       dt.TypeColor,
-		}
-		result = append(result, dishesWithType)
+		result = append(result, r.withTypeToDomain(dt))
 	}
 
 	return result, nil
@@ -151,22 +188,8 @@ func (r *dishesRepository) Update(ctx context.Context, req domain.UpdateDishesRe
 		return nil, err
 	}
 
-	// 转换为DAO对象
-	daoDishes := dao.Dishes{
-		ID:      existingDishes.ID,
-		UserID:  existingDishes.UserID,
-		Name:    existingDishes.Name,
-		Desc:    existingDishes.Desc,
-		Price:   existingDishes.Price,
-		Img:     existingDishes.Img,
-		Type:    existingDishes.Type,
-		Calorie: existingDishes.Calorie,
-		Ctime:   existingDishes.Ctime,
-		Utime:   existingDishes.Utime,
-	}
-
-	// 保存到数据库
-	_, err = r.dishesDao.Save(ctx, daoDishes)
+	// 转换为DAO对象并保存到数据库
+	_, err = r.dishesDao.Save(ctx, r.domainToDao(*existingDishes))
 	if err != nil {
 		return nil, err
 	}
@@ -191,52 +214,70 @@ func (r *dishesRepository) Delete(ctx context.Context, id int64, userID int64) e
 	return r.dishesDao.Delete(ctx, id)
 }
 
+// Merge 合并菜品，转移依赖记录后删除被合并的菜品
+func (r *dishesRepository) Merge(ctx context.Context, userID int64, keepID int64, mergeIDs []int64) error {
+	return r.dishesDao.Merge(ctx, keepID, mergeIDs)
+}
+
+// UpdateSort 在一个事务中改写菜品的排序权重
+func (r *dishesRepository) UpdateSort(ctx context.Context, userID int64, weights map[int64]int64) error {
+	return r.dishesDao.UpdateSort(ctx, userID, weights)
+}
+
 // List 分页查询菜品列表
 func (r *dishesRepository) List(ctx context.Context, query domain.DishesQuery) (*domain.DishesListResponse, error) {
-	var daoDishes []dao.Dishes
-	var err error
-
-	// 根据查询条件获取数据
-	if query.Type > 0 {
-		daoDishes, err = r.dishesDao.GetByUserIDAndType(ctx, query.UserID, query.Type)
-	} else {
-		daoDishes, err = r.dishesDao.GetByUserID(ctx, query.UserID)
+	filter := dao.DishesFilter{
+		UserID:        query.UserID,
+		Type:          query.Type,
+		Keyword:       query.Keyword,
+		ViewerID:      query.UserID,
+		TagIDs:        query.TagIDs,
+		MatchAllTags:  query.TagMatch == domain.TagMatchAll,
+		ExcludeTagIDs: query.ExcludeTagIDs,
+		OrderBy:       dishesOrderBy(query.Sort),
+		Offset:        query.Offset,
+		Limit:         query.Limit,
 	}
-	if err != nil {
-		return nil, err
+	if query.IncludeDescendants && query.Type > 0 {
+		// 种类不存在时仍按种类ID过滤，结果为空
+		if dishType, err := r.dishTypeDao.GetByID(ctx, query.Type); err == nil && dishType.UserID == query.UserID {
+			filter.TypePath = dishType.Path
+		}
 	}
-
-	// 转换为领域对象
-	dishesList := r.daoListToDomainList(daoDishes)
-
-	// 手动分页
-	total := int64(len(dishesList))
-	start := query.Offset
-	end := start + query.Limit
-	if end > len(dishesList) {
-		end = len(dishesList)
+	if query.Favorite {
+		filter.FavoriteBy = query.UserID
 	}
-	if start > len(dishesList) {
-		start = len(dishesList)
+	for _, allergen := range query.ExcludeAllergens {
+		filter.ExcludeAllergens = append(filter.ExcludeAllergens, string(allergen))
 	}
 
-	pagedList := dishesList[start:end]
+	daoDishesWithType, total, err := r.dishesDao.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
 
-	// 获取带种类信息的菜品列表
-	dishesWithType, err := r.GetDishesWithTypeInfo(ctx, query.UserID)
+	// 批量加载当前页菜品的标签
+	dishIDs := make([]int64, 0, len(daoDishesWithType))
+	for _, dt := range daoDishesWithType {
+		dishIDs = append(dishIDs, dt.ID)
+	}
+	dishTags, err := r.tagDao.FindByDishIDs(ctx, dishIDs)
 	if err != nil {
 		return nil, err
 	}
+	tagsByDish := make(map[int64][]domain.Tag, len(dishIDs))
+	for _, dt := range dishTags {
+		tagsByDish[dt.DishID] = append(tagsByDish[dt.DishID], tagToDomain(dt.Tag, 0))
+	}
 
-	// 过滤分页后的菜品
-	var result []domain.DishesWithType
-	for _, dish := range pagedList {
-		for _, dt := range dishesWithType {
-			if dt.ID == dish.ID {
-				result = append(result, dt)
-				break
-			}
+	result := make([]domain.DishesWithType, 0, len(daoDishesWithType))
+	for _, dt := range daoDishesWithType {
+		item := r.withTypeToDomain(dt)
+		item.Tags = tagsByDish[dt.ID]
+		if item.Tags == nil {
+			item.Tags = []domain.Tag{}
 		}
+		result = append(result, item)
 	}
 
 	return &domain.DishesListResponse{
@@ -247,6 +288,21 @@ func (r *dishesRepository) List(ctx context.Context, query domain.DishesQuery) (
 	}, nil
 }
 
+// dishesOrderBy 将排序方式转换为排序子句
+func dishesOrderBy(sort domain.DishesSort) string {
+	switch sort {
+	case domain.DishesSortRating:
+		return "dishes.rating_avg DESC, dishes.rating_count DESC, dishes.id ASC"
+	case domain.DishesSortFavorites:
+		return "dishes.favorite_count DESC, dishes.id ASC"
+	case domain.DishesSortCooked:
+		return "dishes.last_cooked_at ASC, dishes.id ASC"
+	default:
+		// 按用户拖动调整的顺序，未排过序的 sort 为0，按创建顺序排在后面
+		return "dishes.sort DESC, dishes.id ASC"
+	}
+}
+
 // Count 统计菜品总数
 func (r *dishesRepository) Count(ctx context.Context) (int64, error) {
 	return r.dishesDao.Count(ctx)
@@ -255,17 +311,106 @@ func (r *dishesRepository) Count(ctx context.Context) (int64, error) {
 // daoToDomain 将DAO对象转换为领域对象
 func (r *dishesRepository) daoToDomain(daoDishes dao.Dishes) *domain.Dishes {
 	return &domain.Dishes{
-		ID:      daoDishes.ID,
-		UserID:  daoDishes.UserID,
-		Name:    daoDishes.Name,
-		Desc:    daoDishes.Desc,
-		Price:   daoDishes.Price,
-		Img:     daoDishes.Img,
-		Type:    daoDishes.Type,
-		Calorie: daoDishes.Calorie,
-		Ctime:   daoDishes.Ctime,
-		Utime:   daoDishes.Utime,
+		ID:            daoDishes.ID,
+		UserID:        daoDishes.UserID,
+		Name:          daoDishes.Name,
+		Desc:          daoDishes.Desc,
+		Price:         daoDishes.Price,
+		Img:           daoDishes.Img,
+		Type:          daoDishes.Type,
+		Calorie:       daoDishes.Calorie,
+		Ingredients:   decodeIngredients(daoDishes.Ingredients),
+		Allergens:     decodeCommaList[domain.Allergen](daoDishes.Allergens),
+		Ctime:         daoDishes.Ctime,
+		Utime:         daoDishes.Utime,
+		RatingAvg:     daoDishes.RatingAvg,
+		RatingCount:   daoDishes.RatingCount,
+		FavoriteCount: daoDishes.FavoriteCount,
+		TimesCooked:   daoDishes.TimesCooked,
+		LastCookedAt:  daoDishes.LastCookedAt,
+		Sort:          daoDishes.Sort,
+		SourceDishID:  daoDishes.SourceDishID,
+		SourceUserID:  daoDishes.SourceUserID,
+		SourceDigest:  daoDishes.SourceDigest,
+	}
+}
+
+// withTypeToDomain 将带种类信息的DAO对象转换为领域对象
+func (r *dishesRepository) withTypeToDomain(dt dao.DishesWithType) domain.DishesWithType {
+	return domain.DishesWithType{
+		Dishes:          *r.daoToDomain(dt.Dishes),
+		TypeName:        dt.TypeName,
+		TypeDescription: dt.TypeDescription,
+		TypeIcon:        dt.TypeIcon,
+		TypeColor:       dt.TypeColor,
+		IsFavorite:      dt.IsFavorite,
+	}
+}
+
+// domainToDao 将领域对象转换为DAO对象
+func (r *dishesRepository) domainToDao(dishes domain.Dishes) dao.Dishes {
+	return dao.Dishes{
+		ID:          dishes.ID,
+		UserID:      dishes.UserID,
+		Name:        dishes.Name,
+		Desc:        dishes.Desc,
+		Price:       dishes.Price,
+		Img:         dishes.Img,
+		Type:        dishes.Type,
+		Calorie:     dishes.Calorie,
+		Ingredients: encodeIngredients(dishes.Ingredients),
+		Allergens:   encodeCommaList(dishes.Allergens),
+		Ctime:       dishes.Ctime,
+		Utime:       dishes.Utime,
+
+		SourceDishID: dishes.SourceDishID,
+		SourceUserID: dishes.SourceUserID,
+		SourceDigest: dishes.SourceDigest,
+	}
+}
+
+// encodeIngredients 将食材清单编码为JSON存储
+func encodeIngredients(ingredients []string) string {
+	if len(ingredients) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(ingredients)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// decodeIngredients 解析存储的食材清单
+func decodeIngredients(data string) []string {
+	if data == "" {
+		return []string{}
 	}
+	var ingredients []string
+	if err := json.Unmarshal([]byte(data), &ingredients); err != nil {
+		return []string{}
+	}
+	return ingredients
+}
+
+// encodeCommaList 将取值固定且不含逗号的枚举列表编码为逗号分隔的字符串，便于在 SQL 中使用 FIND_IN_SET
+func encodeCommaList[T ~string](values []T) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		parts = append(parts, string(v))
+	}
+	return strings.Join(parts, ",")
+}
+
+// decodeCommaList 解析逗号分隔的枚举列表
+func decodeCommaList[T ~string](data string) []T {
+	result := []T{}
+	for _, part := range strings.Split(data, ",") {
+		if part != "" {
+			result = append(result, T(part))
+		}
+	}
+	return result
 }
 
 // daoListToDomainList 将DAO对象列表转换为领域对象列表
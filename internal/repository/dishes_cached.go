@@ -0,0 +1,284 @@
+package repository
+
+import (
+	"context"
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/pkg/redis/metrics"
+	"loverrecipe/internal/repository/cache"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gotomicro/ego/core/elog"
+	"golang.org/x/sync/singleflight"
+)
+
+// 缓存指标中的缓存名称
+const (
+	dishesCacheName   = "dishes_detail"
+	withTypeCacheName = "dishes_with_type"
+)
+
+const (
+	// lockPollInterval 未抢到回源锁时轮询缓存的间隔
+	lockPollInterval = 20 * time.Millisecond
+	// refreshTimeout 后台刷新旧值与合并回源的超时时间
+	refreshTimeout = 3 * time.Second
+)
+
+// cachedDishesRepository 为菜品详情与带种类信息的列表加上旁路缓存。
+// 同一进程内对同一条目的回源通过 singleflight 合并，可选用 Redis 锁让多个实例只有一个回源。
+// 评分、收藏与烹饪记录的聚合值由其他仓储写库，这些仓储写入后通过 invalidateDishesCache 清除对应缓存
+type cachedDishesRepository struct {
+	DishesRepository
+	cache      cache.DishesCache
+	group      singleflight.Group
+	refreshing sync.Map // 正在后台刷新的条目
+}
+
+// NewCachedDishesRepository 用缓存装饰菜品仓储，缓存读写失败时直接访问数据库
+func NewCachedDishesRepository(repo DishesRepository, c cache.DishesCache) DishesRepository {
+	return &cachedDishesRepository{
+		DishesRepository: repo,
+		cache:            c,
+	}
+}
+
+// Create 创建菜品并清除所属用户的列表缓存
+func (r *cachedDishesRepository) Create(ctx context.Context, req domain.CreateDishesRequest) (*domain.Dishes, error) {
+	dish, err := r.DishesRepository.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	// 同时清除新ID上可能残留的负缓存
+	r.invalidate(ctx, dish.ID, dish.UserID)
+	return dish, nil
+}
+
+// GetByID 先读缓存，未命中时查库并回填，菜品不存在时写入负缓存
+func (r *cachedDishesRepository) GetByID(ctx context.Context, id int64) (*domain.Dishes, error) {
+	dish, err := readThrough(ctx, r, cacheEntry[domain.Dishes]{
+		name: dishesCacheName,
+		key:  "detail:" + strconv.FormatInt(id, 10),
+		get: func(ctx context.Context) (cache.Entry[domain.Dishes], error) {
+			return r.cache.GetDishes(ctx, id)
+		},
+		load: func(ctx context.Context) (domain.Dishes, error) {
+			dish, err := r.DishesRepository.GetByID(ctx, id)
+			if err != nil {
+				return domain.Dishes{}, err
+			}
+			return *dish, nil
+		},
+		set: func(ctx context.Context, dish domain.Dishes) error {
+			return r.cache.SetDishes(ctx, dish)
+		},
+		setNotFound: func(ctx context.Context) error {
+			return r.cache.SetDishesNotFound(ctx, id)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dish, nil
+}
+
+// GetDishesWithTypeInfo 先读缓存，未命中时查库并回填，空列表按负缓存的时间过期
+func (r *cachedDishesRepository) GetDishesWithTypeInfo(ctx context.Context, userID int64) ([]domain.DishesWithType, error) {
+	return readThrough(ctx, r, cacheEntry[[]domain.DishesWithType]{
+		name: withTypeCacheName,
+		key:  "with_type:" + strconv.FormatInt(userID, 10),
+		get: func(ctx context.Context) (cache.Entry[[]domain.DishesWithType], error) {
+			return r.cache.GetDishesWithType(ctx, userID)
+		},
+		load: func(ctx context.Context) ([]domain.DishesWithType, error) {
+			return r.DishesRepository.GetDishesWithTypeInfo(ctx, userID)
+		},
+		set: func(ctx context.Context, dishes []domain.DishesWithType) error {
+			return r.cache.SetDishesWithType(ctx, userID, dishes)
+		},
+	})
+}
+
+// Update 更新菜品并清除相关缓存
+func (r *cachedDishesRepository) Update(ctx context.Context, req domain.UpdateDishesRequest) (*domain.Dishes, error) {
+	dish, err := r.DishesRepository.Update(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, dish.ID, dish.UserID)
+	return dish, nil
+}
+
+// Delete 删除菜品并清除相关缓存
+func (r *cachedDishesRepository) Delete(ctx context.Context, id int64, userID int64) error {
+	if err := r.DishesRepository.Delete(ctx, id, userID); err != nil {
+		return err
+	}
+	r.invalidate(ctx, id, userID)
+	return nil
+}
+
+// Merge 合并菜品并清除保留菜品与被合并菜品的缓存。
+// 复制自被合并菜品的其他菜品改指保留菜品，它们的详情缓存最多滞后一个过期时间
+func (r *cachedDishesRepository) Merge(ctx context.Context, userID int64, keepID int64, mergeIDs []int64) error {
+	if err := r.DishesRepository.Merge(ctx, userID, keepID, mergeIDs); err != nil {
+		return err
+	}
+	r.invalidate(ctx, keepID, userID)
+	for _, id := range mergeIDs {
+		r.invalidate(ctx, id, userID)
+	}
+	return nil
+}
+
+// UpdateSort 改写排序权重并清除被改写菜品与用户列表的缓存
+func (r *cachedDishesRepository) UpdateSort(ctx context.Context, userID int64, weights map[int64]int64) error {
+	if err := r.DishesRepository.UpdateSort(ctx, userID, weights); err != nil {
+		return err
+	}
+	r.invalidate(ctx, 0, userID)
+	for id := range weights {
+		r.invalidate(ctx, id, userID)
+	}
+	return nil
+}
+
+// invalidate 清除缓存，失败时只记录日志，由过期时间兜底
+func (r *cachedDishesRepository) invalidate(ctx context.Context, id int64, userID int64) {
+	invalidateDishesCache(ctx, r.cache, id, userID)
+}
+
+// invalidateDishesCache 清除菜品详情与所属用户的列表缓存，供改变菜品聚合值的其他仓储使用。
+// 失败时只记录日志，由过期时间兜底
+func invalidateDishesCache(ctx context.Context, c cache.DishesCache, id int64, userID int64) {
+	if err := c.Invalidate(ctx, id, userID); err != nil {
+		elog.Warn("清除菜品缓存失败", elog.FieldErr(err), elog.Int64("id", id), elog.Int64("userID", userID))
+	}
+}
+
+// cacheEntry 一种缓存条目的读写方式
+type cacheEntry[T any] struct {
+	name        string // 指标中的缓存名称
+	key         string // 合并回源与回源锁使用的键
+	get         func(ctx context.Context) (cache.Entry[T], error)
+	load        func(ctx context.Context) (T, error)
+	set         func(ctx context.Context, value T) error
+	setNotFound func(ctx context.Context) error // 为空表示不缓存不存在的结果
+}
+
+// readThrough 读缓存，命中旧值时直接返回并在后台刷新，未命中时合并并发请求后回源
+func readThrough[T any](ctx context.Context, r *cachedDishesRepository, e cacheEntry[T]) (T, error) {
+	var zero T
+	entry, err := e.get(ctx)
+	switch {
+	case err == nil:
+		metrics.CacheHit(e.name)
+		if entry.Stale {
+			metrics.CacheStale(e.name)
+			refreshAsync(r, e)
+		}
+		if entry.NotFound {
+			return zero, domain.ErrDishesNotFound
+		}
+		return entry.Value, nil
+	case !cache.IsMiss(err):
+		elog.Warn("读取菜品缓存失败", elog.FieldErr(err), elog.String("key", e.key))
+	}
+	metrics.CacheMiss(e.name)
+
+	// 合并后的回源由所有等待者共享，不能随发起者的请求取消而失败，使用独立的上下文；
+	// 每个等待者只受自己的上下文约束，取消时直接返回，回源继续完成并回填缓存
+	leader := false
+	ch := r.group.DoChan(e.key, func() (interface{}, error) {
+		leader = true
+		loadCtx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+		defer cancel()
+		return rebuild(loadCtx, r, e)
+	})
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case res := <-ch:
+		if !leader {
+			metrics.CacheCoalesced(e.name)
+		}
+		if res.Err != nil {
+			return zero, res.Err
+		}
+		return res.Val.(T), nil
+	}
+}
+
+// rebuild 回源并回填缓存。开启回源锁且锁被其他实例持有时，先等待对方回填，超时后再自行回源
+func rebuild[T any](ctx context.Context, r *cachedDishesRepository, e cacheEntry[T]) (T, error) {
+	var zero T
+	unlock, ok, err := r.cache.Lock(ctx, e.key)
+	switch {
+	case err != nil:
+		elog.Warn("获取菜品缓存回源锁失败", elog.FieldErr(err), elog.String("key", e.key))
+	case ok:
+		defer unlock()
+	default:
+		if entry, filled := waitForFill(ctx, r, e); filled {
+			if entry.NotFound {
+				return zero, domain.ErrDishesNotFound
+			}
+			return entry.Value, nil
+		}
+	}
+
+	value, err := e.load(ctx)
+	if err == domain.ErrDishesNotFound && e.setNotFound != nil {
+		if cerr := e.setNotFound(ctx); cerr != nil {
+			elog.Warn("写入菜品负缓存失败", elog.FieldErr(cerr), elog.String("key", e.key))
+		}
+		return zero, err
+	}
+	if err != nil {
+		return zero, err
+	}
+
+	if cerr := e.set(ctx, value); cerr != nil {
+		elog.Warn("写入菜品缓存失败", elog.FieldErr(cerr), elog.String("key", e.key))
+	}
+	return value, nil
+}
+
+// waitForFill 等待持有回源锁的实例回填缓存，返回是否等到了未过期的条目
+func waitForFill[T any](ctx context.Context, r *cachedDishesRepository, e cacheEntry[T]) (cache.Entry[T], bool) {
+	timer := time.NewTimer(r.cache.LockWait())
+	defer timer.Stop()
+	ticker := time.NewTicker(lockPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return cache.Entry[T]{}, false
+		case <-timer.C:
+			return cache.Entry[T]{}, false
+		case <-ticker.C:
+			entry, err := e.get(ctx)
+			if err == nil && !entry.Stale {
+				return entry, true
+			}
+		}
+	}
+}
+
+// refreshAsync 在后台刷新已过期的条目，同一条目同时只有一个刷新任务
+func refreshAsync[T any](r *cachedDishesRepository, e cacheEntry[T]) {
+	if _, running := r.refreshing.LoadOrStore(e.key, struct{}{}); running {
+		return
+	}
+	go func() {
+		defer r.refreshing.Delete(e.key)
+		// 请求返回后仍需完成刷新，使用独立的上下文
+		ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+		defer cancel()
+		_, _, _ = r.group.Do(e.key, func() (interface{}, error) {
+			return rebuild(ctx, r, e)
+		})
+	}()
+}
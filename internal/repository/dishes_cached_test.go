@@ -0,0 +1,313 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/repository/cache"
+	"loverrecipe/internal/repository/dao"
+)
+
+// fakeDishesRepository 内存中的菜品仓储，记录回源次数
+type fakeDishesRepository struct {
+	DishesRepository
+	mu        sync.Mutex
+	dishes    map[int64]domain.Dishes
+	nextID    int64
+	loads     int
+	listLoads int
+}
+
+func newFakeDishesRepository(dishes ...domain.Dishes) *fakeDishesRepository {
+	repo := &fakeDishesRepository{dishes: make(map[int64]domain.Dishes), nextID: 100}
+	for _, dish := range dishes {
+		repo.dishes[dish.ID] = dish
+	}
+	return repo
+}
+
+func (f *fakeDishesRepository) GetByID(ctx context.Context, id int64) (*domain.Dishes, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.loads++
+	dish, ok := f.dishes[id]
+	if !ok {
+		return nil, domain.ErrDishesNotFound
+	}
+	return &dish, nil
+}
+
+func (f *fakeDishesRepository) GetDishesWithTypeInfo(ctx context.Context, userID int64) ([]domain.DishesWithType, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listLoads++
+	var result []domain.DishesWithType
+	for _, dish := range f.dishes {
+		if dish.UserID == userID {
+			result = append(result, domain.DishesWithType{Dishes: dish})
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeDishesRepository) Create(ctx context.Context, req domain.CreateDishesRequest) (*domain.Dishes, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	dish := domain.Dishes{ID: f.nextID, UserID: req.UserID, Name: req.Name}
+	f.dishes[dish.ID] = dish
+	return &dish, nil
+}
+
+func (f *fakeDishesRepository) Update(ctx context.Context, req domain.UpdateDishesRequest) (*domain.Dishes, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	dish, ok := f.dishes[req.ID]
+	if !ok {
+		return nil, domain.ErrDishesNotFound
+	}
+	dish.Name = req.Name
+	f.dishes[dish.ID] = dish
+	return &dish, nil
+}
+
+func (f *fakeDishesRepository) Delete(ctx context.Context, id int64, userID int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.dishes, id)
+	return nil
+}
+
+func (f *fakeDishesRepository) counts() (int, int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.loads, f.listLoads
+}
+
+func newTestCachedRepository(t *testing.T, repo DishesRepository) (*miniredis.Miniredis, DishesRepository) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	c := cache.NewDishesRedisCache(client, cache.Config{Enabled: true, TTL: time.Minute, NegativeTTL: 10 * time.Second})
+	return mr, NewCachedDishesRepository(repo, c)
+}
+
+func TestCachedDishesGetByIDHitAndMiss(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeDishesRepository(domain.Dishes{ID: 1, UserID: 7, Name: "红烧肉"})
+	mr, repo := newTestCachedRepository(t, fake)
+
+	for i := 0; i < 3; i++ {
+		dish, err := repo.GetByID(ctx, 1)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if dish.Name != "红烧肉" {
+			t.Errorf("GetByID() name = %q, want %q", dish.Name, "红烧肉")
+		}
+	}
+	if loads, _ := fake.counts(); loads != 1 {
+		t.Errorf("GetByID() loaded %d times, want 1", loads)
+	}
+	if !mr.Exists("dishes:detail:1") {
+		t.Errorf("GetByID() did not fill the cache")
+	}
+}
+
+func TestCachedDishesNegativeCache(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeDishesRepository()
+	mr, repo := newTestCachedRepository(t, fake)
+
+	for i := 0; i < 3; i++ {
+		if _, err := repo.GetByID(ctx, 42); err != domain.ErrDishesNotFound {
+			t.Fatalf("GetByID() error = %v, want %v", err, domain.ErrDishesNotFound)
+		}
+	}
+	if loads, _ := fake.counts(); loads != 1 {
+		t.Errorf("missing dish loaded %d times, want 1", loads)
+	}
+
+	// 负缓存过期后重新回源
+	mr.FastForward(11 * time.Second)
+	if _, err := repo.GetByID(ctx, 42); err != domain.ErrDishesNotFound {
+		t.Fatalf("GetByID() error = %v, want %v", err, domain.ErrDishesNotFound)
+	}
+	if loads, _ := fake.counts(); loads != 2 {
+		t.Errorf("missing dish loaded %d times after negative TTL, want 2", loads)
+	}
+}
+
+func TestCachedDishesCreateInvalidates(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeDishesRepository()
+	mr, repo := newTestCachedRepository(t, fake)
+
+	// 新ID上残留的负缓存与用户列表缓存都应被清除
+	if _, err := repo.GetByID(ctx, 100); err != domain.ErrDishesNotFound {
+		t.Fatalf("GetByID() error = %v, want %v", err, domain.ErrDishesNotFound)
+	}
+	if _, err := repo.GetDishesWithTypeInfo(ctx, 7); err != nil {
+		t.Fatalf("GetDishesWithTypeInfo() error = %v", err)
+	}
+
+	created, err := repo.Create(ctx, domain.CreateDishesRequest{UserID: 7, Name: "清蒸鱼"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if mr.Exists("dishes:detail:100") || mr.Exists("dishes:with_type:7") {
+		t.Fatalf("Create() did not invalidate the cache")
+	}
+
+	dish, err := repo.GetByID(ctx, created.ID)
+	if err != nil || dish.Name != "清蒸鱼" {
+		t.Errorf("GetByID() after Create = %+v, %v", dish, err)
+	}
+	list, err := repo.GetDishesWithTypeInfo(ctx, 7)
+	if err != nil || len(list) != 1 {
+		t.Errorf("GetDishesWithTypeInfo() after Create = %d dishes, %v, want 1", len(list), err)
+	}
+}
+
+func TestCachedDishesUpdateInvalidates(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeDishesRepository(domain.Dishes{ID: 1, UserID: 7, Name: "旧名称"})
+	mr, repo := newTestCachedRepository(t, fake)
+
+	if _, err := repo.GetByID(ctx, 1); err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if _, err := repo.GetDishesWithTypeInfo(ctx, 7); err != nil {
+		t.Fatalf("GetDishesWithTypeInfo() error = %v", err)
+	}
+
+	if _, err := repo.Update(ctx, domain.UpdateDishesRequest{ID: 1, UserID: 7, Name: "新名称"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if mr.Exists("dishes:detail:1") || mr.Exists("dishes:with_type:7") {
+		t.Fatalf("Update() did not invalidate the cache")
+	}
+
+	dish, err := repo.GetByID(ctx, 1)
+	if err != nil || dish.Name != "新名称" {
+		t.Errorf("GetByID() after Update = %+v, %v, want the new name", dish, err)
+	}
+	list, err := repo.GetDishesWithTypeInfo(ctx, 7)
+	if err != nil || len(list) != 1 || list[0].Name != "新名称" {
+		t.Errorf("GetDishesWithTypeInfo() after Update = %+v, %v, want the new name", list, err)
+	}
+	if loads, listLoads := fake.counts(); loads != 2 || listLoads != 2 {
+		t.Errorf("loads = %d/%d, want 2/2", loads, listLoads)
+	}
+}
+
+func TestCachedDishesDeleteInvalidates(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeDishesRepository(domain.Dishes{ID: 1, UserID: 7, Name: "凉拌黄瓜"})
+	mr, repo := newTestCachedRepository(t, fake)
+
+	if _, err := repo.GetByID(ctx, 1); err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if _, err := repo.GetDishesWithTypeInfo(ctx, 7); err != nil {
+		t.Fatalf("GetDishesWithTypeInfo() error = %v", err)
+	}
+
+	if err := repo.Delete(ctx, 1, 7); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if mr.Exists("dishes:detail:1") || mr.Exists("dishes:with_type:7") {
+		t.Fatalf("Delete() did not invalidate the cache")
+	}
+	if _, err := repo.GetByID(ctx, 1); err != domain.ErrDishesNotFound {
+		t.Errorf("GetByID() after Delete error = %v, want %v", err, domain.ErrDishesNotFound)
+	}
+	list, err := repo.GetDishesWithTypeInfo(ctx, 7)
+	if err != nil || len(list) != 0 {
+		t.Errorf("GetDishesWithTypeInfo() after Delete = %d dishes, %v, want 0", len(list), err)
+	}
+}
+
+// fakeDishesDao 从内存菜品仓储中查询菜品的所属用户
+type fakeDishesDao struct {
+	dao.DishesDao
+	repo *fakeDishesRepository
+}
+
+func (f fakeDishesDao) GetByID(ctx context.Context, id int64) (dao.Dishes, error) {
+	f.repo.mu.Lock()
+	defer f.repo.mu.Unlock()
+	dish, ok := f.repo.dishes[id]
+	if !ok {
+		return dao.Dishes{}, gorm.ErrRecordNotFound
+	}
+	return dao.Dishes{ID: dish.ID, UserID: dish.UserID}, nil
+}
+
+// fakeFeedbackDao 保存评分时像数据库一样同步内存菜品的评分聚合值
+type fakeFeedbackDao struct {
+	dao.DishFeedbackDao
+	repo *fakeDishesRepository
+}
+
+func (f fakeFeedbackDao) SaveRating(ctx context.Context, rating dao.DishRating) (dao.DishRating, error) {
+	f.repo.mu.Lock()
+	defer f.repo.mu.Unlock()
+	dish := f.repo.dishes[rating.DishID]
+	dish.RatingAvg = float64(rating.Score)
+	dish.RatingCount = 1
+	f.repo.dishes[rating.DishID] = dish
+	return rating, nil
+}
+
+func (f fakeFeedbackDao) GetRating(ctx context.Context, userID int64, dishID int64) (dao.DishRating, error) {
+	return dao.DishRating{UserID: userID, DishID: dishID}, nil
+}
+
+func TestDishFeedbackSaveRatingInvalidates(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeDishesRepository(domain.Dishes{ID: 1, UserID: 7, Name: "红烧肉"})
+	mr, repo := newTestCachedRepository(t, fake)
+	feedback := &dishFeedbackRepository{
+		feedbackDao: fakeFeedbackDao{repo: fake},
+		dishesDao:   fakeDishesDao{repo: fake},
+		dishesCache: repo.(*cachedDishesRepository).cache,
+	}
+
+	if _, err := repo.GetByID(ctx, 1); err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if _, err := repo.GetDishesWithTypeInfo(ctx, 7); err != nil {
+		t.Fatalf("GetDishesWithTypeInfo() error = %v", err)
+	}
+
+	// 其他用户评分，清除的应该是菜品所属用户的列表缓存
+	if _, err := feedback.SaveRating(ctx, domain.RateDishesRequest{UserID: 8, DishID: 1, Score: 5}); err != nil {
+		t.Fatalf("SaveRating() error = %v", err)
+	}
+	if mr.Exists("dishes:detail:1") || mr.Exists("dishes:with_type:7") {
+		t.Fatalf("SaveRating() did not invalidate the cache, keys = %v", mr.Keys())
+	}
+
+	dish, err := repo.GetByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if dish.RatingAvg != 5 || dish.RatingCount != 1 {
+		t.Errorf("GetByID() rating = %v/%d, want 5/1", dish.RatingAvg, dish.RatingCount)
+	}
+	list, err := repo.GetDishesWithTypeInfo(ctx, 7)
+	if err != nil {
+		t.Fatalf("GetDishesWithTypeInfo() error = %v", err)
+	}
+	if len(list) != 1 || list[0].RatingAvg != 5 {
+		t.Errorf("GetDishesWithTypeInfo() = %+v, want rating 5", list)
+	}
+}
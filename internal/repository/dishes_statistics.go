@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/repository/dao"
+)
+
+// Aggregate 统计用户菜品价格与卡路里的分布，groupBy 为空时返回一条整体汇总
+func (r *dishesRepository) Aggregate(ctx context.Context, userID int64, groupBy domain.DishesGroupBy) ([]domain.DishesAggregate, error) {
+	var grouping dao.DishesGrouping
+	switch groupBy {
+	case domain.DishesGroupByType:
+		grouping = dao.DishesGroupingByType
+	case domain.DishesGroupByMonth:
+		grouping = dao.DishesGroupingByMonth
+	}
+
+	prices, err := r.dishesDao.Distribution(ctx, userID, "price", grouping)
+	if err != nil {
+		return nil, err
+	}
+	calories, err := r.dishesDao.Distribution(ctx, userID, "calorie", grouping)
+	if err != nil {
+		return nil, err
+	}
+
+	// 两次查询的分组相同，按分组标识合并
+	caloriesByKey := make(map[string]dao.DishesDistribution, len(calories))
+	for _, c := range calories {
+		caloriesByKey[c.GroupKey] = c
+	}
+
+	result := make([]domain.DishesAggregate, 0, len(prices))
+	for _, p := range prices {
+		result = append(result, domain.DishesAggregate{
+			Key:     p.GroupKey,
+			Label:   p.GroupLabel,
+			Count:   p.Count,
+			Price:   distributionToDomain(p),
+			Calorie: distributionToDomain(caloriesByKey[p.GroupKey]),
+		})
+	}
+	// 没有菜品时整体汇总也返回一条全零记录
+	if groupBy == domain.DishesGroupByNone && len(result) == 0 {
+		result = append(result, domain.DishesAggregate{})
+	}
+	return result, nil
+}
+
+// CountCreatedByPeriod 按周期统计用户新建的菜品数
+func (r *dishesRepository) CountCreatedByPeriod(ctx context.Context, userID int64, period domain.StatisticsPeriod, since int64) ([]domain.PeriodCount, error) {
+	// ISO 周的年份与周数分别对应 %x 与 %v
+	format := "%Y-%m"
+	if period == domain.StatisticsPeriodWeek {
+		format = "%x-W%v"
+	}
+
+	counts, err := r.dishesDao.CountCreatedByPeriod(ctx, userID, format, since)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.PeriodCount, 0, len(counts))
+	for _, c := range counts {
+		result = append(result, domain.PeriodCount{Period: c.Period, Count: c.Count})
+	}
+	return result, nil
+}
+
+// Top 按评分或收藏数取用户排名靠前的菜品，没有评分或收藏的菜品不参与排名
+func (r *dishesRepository) Top(ctx context.Context, userID int64, sort domain.DishesSort, limit int) ([]domain.Dishes, error) {
+	where := "1 = 1"
+	switch sort {
+	case domain.DishesSortRating:
+		where = "dishes.rating_count > 0"
+	case domain.DishesSortFavorites:
+		where = "dishes.favorite_count > 0"
+	}
+
+	dishes, err := r.dishesDao.Top(ctx, userID, where, dishesOrderBy(sort), limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.daoListToDomainList(dishes), nil
+}
+
+// distributionToDomain 将DAO分布转换为领域对象
+func distributionToDomain(d dao.DishesDistribution) domain.DistributionStat {
+	return domain.DistributionStat{
+		Total:  d.Total,
+		Min:    d.Min,
+		Max:    d.Max,
+		Avg:    d.Avg,
+		Median: d.P50,
+		P25:    d.P25,
+		P75:    d.P75,
+		P90:    d.P90,
+		P95:    d.P95,
+	}
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"loverrecipe/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/gotomicro/ego/core/elog"
+	"github.com/redis/go-redis/v9"
+)
+
+// EmailTokenConfig 邮件令牌配置
+type EmailTokenConfig struct {
+	// Secret 令牌签名密钥，多实例部署时必须配置相同的值；为空时启动时随机生成，重启后已发出的链接失效
+	Secret         string
+	VerifyTTL      time.Duration // 验证邮箱链接的有效期
+	ResetTTL       time.Duration // 重置密码链接的有效期
+	ResendInterval time.Duration // 同一用户同一用途两次发送的最小间隔
+}
+
+// 默认邮件令牌配置
+const (
+	defaultVerifyTTL      = 24 * time.Hour
+	defaultResetTTL       = 30 * time.Minute
+	defaultResendInterval = time.Minute
+	emailTokenIDBytes     = 24
+)
+
+// EmailTokenRepository 保存验证邮箱与重置密码的一次性令牌。
+// 令牌为 <随机ID>.<签名>，签名不对的令牌不访问 Redis 直接拒绝
+type EmailTokenRepository interface {
+	// Issue 签发令牌，有效期按用途取配置
+	Issue(ctx context.Context, purpose domain.EmailTokenPurpose, claims domain.EmailTokenClaims) (string, error)
+	// Consume 校验并删除令牌，令牌只能使用一次。无效、已使用或已过期返回 ErrEmailTokenInvalid
+	Consume(ctx context.Context, purpose domain.EmailTokenPurpose, token string) (domain.EmailTokenClaims, error)
+	// AllowSend 检查并占用发送间隔，距上次发送不足 ResendInterval 时返回 false
+	AllowSend(ctx context.Context, purpose domain.EmailTokenPurpose, userID int64) (bool, error)
+}
+
+type emailTokenRepository struct {
+	cmd    redis.Cmdable
+	cfg    EmailTokenConfig
+	secret []byte
+}
+
+// NewEmailTokenRepository 创建基于 Redis 的邮件令牌存储，未配置的项使用默认值
+func NewEmailTokenRepository(cmd redis.Cmdable, cfg EmailTokenConfig) EmailTokenRepository {
+	if cfg.VerifyTTL <= 0 {
+		cfg.VerifyTTL = defaultVerifyTTL
+	}
+	if cfg.ResetTTL <= 0 {
+		cfg.ResetTTL = defaultResetTTL
+	}
+	if cfg.ResendInterval <= 0 {
+		cfg.ResendInterval = defaultResendInterval
+	}
+	secret := []byte(cfg.Secret)
+	if len(secret) == 0 {
+		elog.Warn("未配置邮件令牌签名密钥，使用随机密钥，重启后已发出的链接失效")
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			panic(err)
+		}
+	}
+	return &emailTokenRepository{cmd: cmd, cfg: cfg, secret: secret}
+}
+
+// Issue 生成随机ID并签名，令牌内容保存在 Redis 中
+func (r *emailTokenRepository) Issue(ctx context.Context, purpose domain.EmailTokenPurpose,
+	claims domain.EmailTokenClaims) (string, error) {
+	buf := make([]byte, emailTokenIDBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	id := base64.RawURLEncoding.EncodeToString(buf)
+
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	if err := r.cmd.Set(ctx, r.tokenKey(purpose, id), data, r.ttl(purpose)).Err(); err != nil {
+		return "", err
+	}
+	return id + "." + r.sign(purpose, id), nil
+}
+
+// Consume 校验签名后用 GETDEL 取出令牌，并发使用同一令牌只有一个请求成功
+func (r *emailTokenRepository) Consume(ctx context.Context, purpose domain.EmailTokenPurpose,
+	token string) (domain.EmailTokenClaims, error) {
+	id, sig, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(r.sign(purpose, id))) {
+		return domain.EmailTokenClaims{}, domain.ErrEmailTokenInvalid
+	}
+
+	data, err := r.cmd.GetDel(ctx, r.tokenKey(purpose, id)).Bytes()
+	if err == redis.Nil {
+		return domain.EmailTokenClaims{}, domain.ErrEmailTokenInvalid
+	}
+	if err != nil {
+		return domain.EmailTokenClaims{}, err
+	}
+	var claims domain.EmailTokenClaims
+	if err := json.Unmarshal(data, &claims); err != nil {
+		return domain.EmailTokenClaims{}, err
+	}
+	return claims, nil
+}
+
+// AllowSend 使用 SET NX 占用发送间隔
+func (r *emailTokenRepository) AllowSend(ctx context.Context, purpose domain.EmailTokenPurpose, userID int64) (bool, error) {
+	key := fmt.Sprintf("email:cooldown:%s:%d", purpose, userID)
+	return r.cmd.SetNX(ctx, key, 1, r.cfg.ResendInterval).Result()
+}
+
+func (r *emailTokenRepository) ttl(purpose domain.EmailTokenPurpose) time.Duration {
+	if purpose == domain.EmailTokenReset {
+		return r.cfg.ResetTTL
+	}
+	return r.cfg.VerifyTTL
+}
+
+// sign 对用途与ID签名，一种用途的令牌不能用于另一种用途
+func (r *emailTokenRepository) sign(purpose domain.EmailTokenPurpose, id string) string {
+	mac := hmac.New(sha256.New, r.secret)
+	mac.Write([]byte(string(purpose) + ":" + id))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (r *emailTokenRepository) tokenKey(purpose domain.EmailTokenPurpose, id string) string {
+	return fmt.Sprintf("email:token:%s:%s", purpose, id)
+}
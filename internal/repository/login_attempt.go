@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"loverrecipe/internal/domain"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// LoginLockoutConfig 登录失败锁定配置。
+// 同一维度在 Window 内连续失败达到阈值后锁定 BaseLockout，此后每多失败一次锁定时间翻倍，最长 MaxLockout
+type LoginLockoutConfig struct {
+	UsernameThreshold int64         // 按用户名计数的阈值
+	IPThreshold       int64         // 按IP计数的阈值，同一出口IP下可能有多个用户，通常大于用户名阈值
+	Window            time.Duration // 失败计数在最后一次失败后保留的时间
+	BaseLockout       time.Duration
+	MaxLockout        time.Duration
+}
+
+// 默认锁定配置
+const (
+	defaultUsernameThreshold = 5
+	defaultIPThreshold       = 20
+	defaultLockoutWindow     = 15 * time.Minute
+	defaultBaseLockout       = time.Minute
+	defaultMaxLockout        = time.Hour
+)
+
+// LoginAttemptRepository 记录登录失败次数与锁定状态
+type LoginAttemptRepository interface {
+	// Locked 返回用户名与IP中较长的剩余锁定时间，未锁定返回0
+	Locked(ctx context.Context, username string, ip string) (time.Duration, error)
+	// RecordFailure 记录一次登录失败，返回因本次失败产生的锁定时间，未达到阈值返回0
+	RecordFailure(ctx context.Context, username string, ip string) (time.Duration, error)
+	// Reset 登录成功后清除用户名的失败计数，IP计数按窗口自然过期
+	Reset(ctx context.Context, username string) error
+	// ListLockouts 列出当前生效的锁定，scope 为空表示全部维度
+	ListLockouts(ctx context.Context, scope domain.LockoutScope) ([]domain.LoginLockout, error)
+	// ClearLockout 解除锁定并清除失败计数
+	ClearLockout(ctx context.Context, scope domain.LockoutScope, subject string) error
+}
+
+type loginAttemptRepository struct {
+	cmd redis.Cmdable
+	cfg LoginLockoutConfig
+}
+
+// NewLoginAttemptRepository 创建基于 Redis 的登录失败记录，未配置的项使用默认值
+func NewLoginAttemptRepository(cmd redis.Cmdable, cfg LoginLockoutConfig) LoginAttemptRepository {
+	if cfg.UsernameThreshold <= 0 {
+		cfg.UsernameThreshold = defaultUsernameThreshold
+	}
+	if cfg.IPThreshold <= 0 {
+		cfg.IPThreshold = defaultIPThreshold
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = defaultLockoutWindow
+	}
+	if cfg.BaseLockout <= 0 {
+		cfg.BaseLockout = defaultBaseLockout
+	}
+	if cfg.MaxLockout < cfg.BaseLockout {
+		cfg.MaxLockout = defaultMaxLockout
+		if cfg.MaxLockout < cfg.BaseLockout {
+			cfg.MaxLockout = cfg.BaseLockout
+		}
+	}
+	return &loginAttemptRepository{cmd: cmd, cfg: cfg}
+}
+
+// recordFailureScript 累加失败次数，达到阈值后按指数增长设置锁定。
+// KEYS[1] 失败计数键，KEYS[2] 锁定键；ARGV 依次为阈值、计数窗口、基础锁定时间、最长锁定时间（毫秒）。
+// 计数键在锁定结束后仍保留一个窗口，锁定期满后再次失败会得到更长的锁定。返回 {失败次数, 锁定毫秒数}
+var recordFailureScript = redis.NewScript(`
+local threshold = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local base = tonumber(ARGV[3])
+local max = tonumber(ARGV[4])
+
+local failures = redis.call("INCR", KEYS[1])
+local lock = 0
+if failures >= threshold then
+	local exp = failures - threshold
+	if exp > 40 then
+		exp = 40
+	end
+	lock = base * math.pow(2, exp)
+	if lock > max then
+		lock = max
+	end
+	redis.call("SET", KEYS[2], failures, "PX", lock)
+end
+redis.call("PEXPIRE", KEYS[1], window + lock)
+return {failures, lock}
+`)
+
+// Locked 查询用户名与IP的锁定状态
+func (r *loginAttemptRepository) Locked(ctx context.Context, username string, ip string) (time.Duration, error) {
+	pipe := r.cmd.Pipeline()
+	userTTL := pipe.PTTL(ctx, r.lockKey(domain.LockoutScopeUsername, username))
+	ipTTL := pipe.PTTL(ctx, r.lockKey(domain.LockoutScopeIP, ip))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	// 键不存在时 PTTL 返回负数
+	retry := userTTL.Val()
+	if ipTTL.Val() > retry {
+		retry = ipTTL.Val()
+	}
+	if retry < 0 {
+		return 0, nil
+	}
+	return retry, nil
+}
+
+// RecordFailure 分别按用户名与IP记录失败
+func (r *loginAttemptRepository) RecordFailure(ctx context.Context, username string, ip string) (time.Duration, error) {
+	userLock, err := r.recordFailure(ctx, domain.LockoutScopeUsername, username, r.cfg.UsernameThreshold)
+	if err != nil {
+		return 0, err
+	}
+	ipLock, err := r.recordFailure(ctx, domain.LockoutScopeIP, ip, r.cfg.IPThreshold)
+	if err != nil {
+		return 0, err
+	}
+	if ipLock > userLock {
+		return ipLock, nil
+	}
+	return userLock, nil
+}
+
+func (r *loginAttemptRepository) recordFailure(ctx context.Context, scope domain.LockoutScope, subject string, threshold int64) (time.Duration, error) {
+	result, err := recordFailureScript.Run(ctx, r.cmd,
+		[]string{r.failKey(scope, subject), r.lockKey(scope, subject)},
+		threshold, r.cfg.Window.Milliseconds(), r.cfg.BaseLockout.Milliseconds(), r.cfg.MaxLockout.Milliseconds(),
+	).Int64Slice()
+	if err != nil {
+		return 0, err
+	}
+	if len(result) != 2 {
+		return 0, fmt.Errorf("记录登录失败返回值异常: %v", result)
+	}
+	return time.Duration(result[1]) * time.Millisecond, nil
+}
+
+// Reset 清除用户名的失败计数。锁定键不删除，登录成功的前提是未被锁定。
+// IP计数不清除，否则攻击者穿插登录自己的账号即可让同一IP的计数归零，继续撞库
+func (r *loginAttemptRepository) Reset(ctx context.Context, username string) error {
+	return r.cmd.Del(ctx, r.failKey(domain.LockoutScopeUsername, username)).Err()
+}
+
+// ListLockouts 扫描锁定键，按剩余锁定时间从长到短排序
+func (r *loginAttemptRepository) ListLockouts(ctx context.Context, scope domain.LockoutScope) ([]domain.LoginLockout, error) {
+	scopes := []domain.LockoutScope{domain.LockoutScopeUsername, domain.LockoutScopeIP}
+	if scope != "" {
+		scopes = []domain.LockoutScope{scope}
+	}
+
+	lockouts := make([]domain.LoginLockout, 0)
+	now := time.Now()
+	for _, s := range scopes {
+		prefix := r.lockKey(s, "")
+		iter := r.cmd.Scan(ctx, 0, prefix+"*", 100).Iterator()
+		for iter.Next(ctx) {
+			subject := strings.TrimPrefix(iter.Val(), prefix)
+			pipe := r.cmd.Pipeline()
+			ttl := pipe.PTTL(ctx, iter.Val())
+			failures := pipe.Get(ctx, r.failKey(s, subject))
+			if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+				return nil, err
+			}
+			// 扫描与查询之间锁定可能刚好到期
+			if ttl.Val() <= 0 {
+				continue
+			}
+			count, _ := failures.Int64()
+			lockouts = append(lockouts, domain.LoginLockout{
+				Scope:      s,
+				Subject:    subject,
+				Failures:   count,
+				RetryAfter: int64((ttl.Val() + time.Second - 1) / time.Second),
+				LockedTill: now.Add(ttl.Val()).Unix(),
+			})
+		}
+		if err := iter.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(lockouts, func(i, j int) bool {
+		return lockouts[i].RetryAfter > lockouts[j].RetryAfter
+	})
+	return lockouts, nil
+}
+
+// ClearLockout 删除锁定键与失败计数
+func (r *loginAttemptRepository) ClearLockout(ctx context.Context, scope domain.LockoutScope, subject string) error {
+	return r.cmd.Del(ctx, r.lockKey(scope, subject), r.failKey(scope, subject)).Err()
+}
+
+func (r *loginAttemptRepository) failKey(scope domain.LockoutScope, subject string) string {
+	return fmt.Sprintf("login:fail:%s:%s", scope, subject)
+}
+
+func (r *loginAttemptRepository) lockKey(scope domain.LockoutScope, subject string) string {
+	return fmt.Sprintf("login:lock:%s:%s", scope, subject)
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/repository/dao"
+	"time"
+
+	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
+)
+
+type NutritionRepository interface {
+	GetGoal(ctx context.Context, userID int64) (domain.NutritionGoal, error)
+	SaveGoal(ctx context.Context, goal domain.NutritionGoal) error
+	CreateMeal(ctx context.Context, meal domain.MealRecord) (*domain.MealRecord, error)
+	GetMeal(ctx context.Context, id int64) (*domain.MealRecord, error)
+	DeleteMeal(ctx context.Context, id int64) error
+	FindMeals(ctx context.Context, userID int64, day string) ([]domain.MealRecord, error)
+	SumMeals(ctx context.Context, userID int64, fromDay string, toDay string) (domain.NutritionTotals, error)
+	SnapshotDay(ctx context.Context, day string) (int, error)
+	FindSnapshots(ctx context.Context, userID int64, fromDay string, toDay string) ([]domain.DailyNutritionSnapshot, error)
+}
+
+type nutritionRepository struct {
+	nutritionDao dao.NutritionDao
+}
+
+func NewNutritionRepository(db *egorm.Component) NutritionRepository {
+	return &nutritionRepository{
+		nutritionDao: dao.NewNutritionDao(db),
+	}
+}
+
+// GetGoal 获取用户的目标，未设置时返回空目标
+func (r *nutritionRepository) GetGoal(ctx context.Context, userID int64) (domain.NutritionGoal, error) {
+	goal, err := r.nutritionDao.GetGoal(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.NutritionGoal{UserID: userID}, nil
+	}
+	if err != nil {
+		return domain.NutritionGoal{}, err
+	}
+
+	return domain.NutritionGoal{
+		UserID:        goal.UserID,
+		DailyCalorie:  goal.DailyCalorie,
+		DailyBudget:   goal.DailyBudget,
+		WeeklyCalorie: goal.WeeklyCalorie,
+		WeeklyBudget:  goal.WeeklyBudget,
+		Utime:         goal.Utime,
+	}, nil
+}
+
+// SaveGoal 保存用户的目标
+func (r *nutritionRepository) SaveGoal(ctx context.Context, goal domain.NutritionGoal) error {
+	return r.nutritionDao.SaveGoal(ctx, dao.NutritionGoal{
+		UserID:        goal.UserID,
+		DailyCalorie:  goal.DailyCalorie,
+		DailyBudget:   goal.DailyBudget,
+		WeeklyCalorie: goal.WeeklyCalorie,
+		WeeklyBudget:  goal.WeeklyBudget,
+		Utime:         goal.Utime,
+	})
+}
+
+// CreateMeal 创建饮食记录
+func (r *nutritionRepository) CreateMeal(ctx context.Context, meal domain.MealRecord) (*domain.MealRecord, error) {
+	saved, err := r.nutritionDao.CreateMeal(ctx, dao.MealRecord{
+		UserID:   meal.UserID,
+		DishID:   meal.DishID,
+		DishName: meal.DishName,
+		Day:      meal.Day,
+		Servings: meal.Servings,
+		Calorie:  meal.Calorie,
+		Spend:    meal.Spend,
+		Ctime:    meal.Ctime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := r.mealToDomain(saved)
+	return &result, nil
+}
+
+// GetMeal 根据ID获取饮食记录
+func (r *nutritionRepository) GetMeal(ctx context.Context, id int64) (*domain.MealRecord, error) {
+	meal, err := r.nutritionDao.GetMeal(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrMealRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := r.mealToDomain(meal)
+	return &result, nil
+}
+
+// DeleteMeal 删除饮食记录
+func (r *nutritionRepository) DeleteMeal(ctx context.Context, id int64) error {
+	return r.nutritionDao.DeleteMeal(ctx, id)
+}
+
+// FindMeals 获取用户某一天的饮食记录
+func (r *nutritionRepository) FindMeals(ctx context.Context, userID int64, day string) ([]domain.MealRecord, error) {
+	meals, err := r.nutritionDao.FindMeals(ctx, userID, day)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.MealRecord, 0, len(meals))
+	for _, meal := range meals {
+		result = append(result, r.mealToDomain(meal))
+	}
+	return result, nil
+}
+
+// SumMeals 统计用户在日期范围内的饮食合计
+func (r *nutritionRepository) SumMeals(ctx context.Context, userID int64, fromDay string, toDay string) (domain.NutritionTotals, error) {
+	totals, err := r.nutritionDao.SumMeals(ctx, userID, fromDay, toDay)
+	if err != nil {
+		return domain.NutritionTotals{}, err
+	}
+	return domain.NutritionTotals{
+		Meals:   totals.Meals,
+		Calorie: totals.Calorie,
+		Spend:   totals.Spend,
+	}, nil
+}
+
+// SnapshotDay 汇总某一天所有用户的饮食合计并保存为快照，返回写入的快照数
+func (r *nutritionRepository) SnapshotDay(ctx context.Context, day string) (int, error) {
+	snapshots, err := r.nutritionDao.BuildSnapshots(ctx, day)
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now().Unix()
+	for i := range snapshots {
+		snapshots[i].Ctime = now
+	}
+	if err := r.nutritionDao.SaveSnapshots(ctx, snapshots); err != nil {
+		return 0, err
+	}
+	return len(snapshots), nil
+}
+
+// FindSnapshots 获取用户在日期范围内的每日快照
+func (r *nutritionRepository) FindSnapshots(ctx context.Context, userID int64, fromDay string, toDay string) ([]domain.DailyNutritionSnapshot, error) {
+	snapshots, err := r.nutritionDao.FindSnapshots(ctx, userID, fromDay, toDay)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.DailyNutritionSnapshot, 0, len(snapshots))
+	for _, s := range snapshots {
+		result = append(result, domain.DailyNutritionSnapshot{
+			UserID:        s.UserID,
+			Day:           s.Day,
+			Meals:         s.Meals,
+			Calorie:       s.Calorie,
+			Spend:         s.Spend,
+			CalorieTarget: s.CalorieTarget,
+			BudgetTarget:  s.BudgetTarget,
+			Ctime:         s.Ctime,
+		})
+	}
+	return result, nil
+}
+
+// mealToDomain 将DAO对象转换为领域对象
+func (r *nutritionRepository) mealToDomain(meal dao.MealRecord) domain.MealRecord {
+	return domain.MealRecord{
+		ID:       meal.ID,
+		UserID:   meal.UserID,
+		DishID:   meal.DishID,
+		DishName: meal.DishName,
+		Day:      meal.Day,
+		Servings: meal.Servings,
+		Calorie:  meal.Calorie,
+		Spend:    meal.Spend,
+		Ctime:    meal.Ctime,
+	}
+}
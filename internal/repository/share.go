@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/repository/dao"
+
+	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
+)
+
+type ShareRepository interface {
+	Create(ctx context.Context, share domain.Share) (domain.Share, error)
+	// GetByToken 根据令牌获取分享，不存在返回 ErrShareNotFound
+	GetByToken(ctx context.Context, token string) (domain.Share, error)
+	ListByUser(ctx context.Context, userID int64) ([]domain.Share, error)
+	CountByUser(ctx context.Context, userID int64) (int64, error)
+	// Delete 吊销用户的分享，不存在或不属于该用户返回 ErrShareNotFound
+	Delete(ctx context.Context, id int64, userID int64) error
+	IncrViews(ctx context.Context, id int64, now int64) error
+	// HasActiveDishShare 菜品的所有者是否在 now 时刻仍通过分享链接公开该菜品或其所在种类
+	HasActiveDishShare(ctx context.Context, dish domain.Dishes, now int64) (bool, error)
+}
+
+type shareRepository struct {
+	shareDao dao.ShareDao
+}
+
+func NewShareRepository(db *egorm.Component) ShareRepository {
+	return &shareRepository{
+		shareDao: dao.NewShareDao(db),
+	}
+}
+
+// Create 保存分享
+func (r *shareRepository) Create(ctx context.Context, share domain.Share) (domain.Share, error) {
+	created, err := r.shareDao.Create(ctx, dao.Share{
+		UserID:     share.UserID,
+		Token:      share.Token,
+		TargetType: string(share.TargetType),
+		TargetID:   share.TargetID,
+		ExpiresAt:  share.ExpiresAt,
+	})
+	if err != nil {
+		return domain.Share{}, err
+	}
+	return r.toDomain(created), nil
+}
+
+// GetByToken 根据令牌获取分享
+func (r *shareRepository) GetByToken(ctx context.Context, token string) (domain.Share, error) {
+	share, err := r.shareDao.GetByToken(ctx, token)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.Share{}, domain.ErrShareNotFound
+	}
+	if err != nil {
+		return domain.Share{}, err
+	}
+	return r.toDomain(share), nil
+}
+
+// ListByUser 获取用户的分享
+func (r *shareRepository) ListByUser(ctx context.Context, userID int64) ([]domain.Share, error) {
+	shares, err := r.shareDao.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.Share, 0, len(shares))
+	for _, share := range shares {
+		result = append(result, r.toDomain(share))
+	}
+	return result, nil
+}
+
+// CountByUser 统计用户的分享数量
+func (r *shareRepository) CountByUser(ctx context.Context, userID int64) (int64, error) {
+	return r.shareDao.CountByUser(ctx, userID)
+}
+
+// Delete 吊销用户的分享
+func (r *shareRepository) Delete(ctx context.Context, id int64, userID int64) error {
+	rows, err := r.shareDao.Delete(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrShareNotFound
+	}
+	return nil
+}
+
+// IncrViews 记录一次访问
+func (r *shareRepository) IncrViews(ctx context.Context, id int64, now int64) error {
+	return r.shareDao.IncrViews(ctx, id, now)
+}
+
+// HasActiveDishShare 判断菜品是否处于有效的分享中
+func (r *shareRepository) HasActiveDishShare(ctx context.Context, dish domain.Dishes, now int64) (bool, error) {
+	count, err := r.shareDao.CountActiveForDish(ctx, dish.UserID, dish.ID, dish.Type, now)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *shareRepository) toDomain(share dao.Share) domain.Share {
+	return domain.Share{
+		ID:           share.ID,
+		UserID:       share.UserID,
+		Token:        share.Token,
+		TargetType:   domain.ShareTargetType(share.TargetType),
+		TargetID:     share.TargetID,
+		ExpiresAt:    share.ExpiresAt,
+		Views:        share.Views,
+		LastViewedAt: share.LastViewedAt,
+		Ctime:        share.Ctime,
+	}
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/repository/cache"
+	"loverrecipe/internal/repository/dao"
+
+	"github.com/ego-component/egorm"
+	"gorm.io/gorm"
+)
+
+type TagRepository interface {
+	Create(ctx context.Context, tag domain.Tag) (*domain.Tag, error)
+	GetByID(ctx context.Context, id int64) (*domain.Tag, error)
+	GetByUserIDAndName(ctx context.Context, userID int64, name string) (*domain.Tag, error)
+	GetByUserID(ctx context.Context, userID int64) ([]domain.Tag, error)
+	Update(ctx context.Context, tag domain.Tag) error
+	Delete(ctx context.Context, id int64) error
+	// AttachDishes 为用户的标签关联菜品，菜品都属于该用户
+	AttachDishes(ctx context.Context, userID int64, tagID int64, dishIDs []int64) error
+	// DetachDish 取消用户的标签与菜品的关联
+	DetachDish(ctx context.Context, userID int64, tagID int64, dishID int64) error
+}
+
+type tagRepository struct {
+	tagDao      dao.TagDao
+	dishesCache cache.DishesCache
+}
+
+func NewTagRepository(db *egorm.Component, dishesCache cache.DishesCache) TagRepository {
+	return &tagRepository{
+		tagDao:      dao.NewTagDao(db),
+		dishesCache: dishesCache,
+	}
+}
+
+// Create 创建标签
+func (r *tagRepository) Create(ctx context.Context, tag domain.Tag) (*domain.Tag, error) {
+	saved, err := r.tagDao.Create(ctx, r.domainToDao(tag))
+	if err != nil {
+		return nil, err
+	}
+
+	result := tagToDomain(saved, 0)
+	return &result, nil
+}
+
+// GetByID 根据ID获取标签
+func (r *tagRepository) GetByID(ctx context.Context, id int64) (*domain.Tag, error) {
+	tag, err := r.tagDao.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrTagNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := tagToDomain(tag, 0)
+	return &result, nil
+}
+
+// GetByUserIDAndName 根据用户与名称获取标签
+func (r *tagRepository) GetByUserIDAndName(ctx context.Context, userID int64, name string) (*domain.Tag, error) {
+	tag, err := r.tagDao.GetByUserIDAndName(ctx, userID, name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrTagNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := tagToDomain(tag, 0)
+	return &result, nil
+}
+
+// GetByUserID 获取用户的全部标签
+func (r *tagRepository) GetByUserID(ctx context.Context, userID int64) ([]domain.Tag, error) {
+	tags, err := r.tagDao.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Tag, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, tagToDomain(tag.Tag, tag.DishCount))
+	}
+	return result, nil
+}
+
+// Update 更新标签
+func (r *tagRepository) Update(ctx context.Context, tag domain.Tag) error {
+	return r.tagDao.Update(ctx, r.domainToDao(tag))
+}
+
+// Delete 删除标签
+func (r *tagRepository) Delete(ctx context.Context, id int64) error {
+	return r.tagDao.Delete(ctx, id)
+}
+
+// AttachDishes 为标签关联菜品并清除这些菜品的缓存
+func (r *tagRepository) AttachDishes(ctx context.Context, userID int64, tagID int64, dishIDs []int64) error {
+	if err := r.tagDao.AttachDishes(ctx, tagID, dishIDs); err != nil {
+		return err
+	}
+	for _, dishID := range dishIDs {
+		invalidateDishesCache(ctx, r.dishesCache, dishID, userID)
+	}
+	return nil
+}
+
+// DetachDish 取消标签与菜品的关联并清除菜品的缓存
+func (r *tagRepository) DetachDish(ctx context.Context, userID int64, tagID int64, dishID int64) error {
+	if err := r.tagDao.DetachDish(ctx, tagID, dishID); err != nil {
+		return err
+	}
+	invalidateDishesCache(ctx, r.dishesCache, dishID, userID)
+	return nil
+}
+
+// domainToDao 将领域对象转换为DAO对象
+func (r *tagRepository) domainToDao(tag domain.Tag) dao.Tag {
+	return dao.Tag{
+		ID:     tag.ID,
+		UserID: tag.UserID,
+		Name:   tag.Name,
+		Color:  tag.Color,
+		Ctime:  tag.Ctime,
+		Utime:  tag.Utime,
+	}
+}
+
+// tagToDomain 将DAO对象转换为领域对象
+func tagToDomain(tag dao.Tag, dishCount int64) domain.Tag {
+	return domain.Tag{
+		ID:        tag.ID,
+		UserID:    tag.UserID,
+		Name:      tag.Name,
+		Color:     tag.Color,
+		DishCount: dishCount,
+		Ctime:     tag.Ctime,
+		Utime:     tag.Utime,
+	}
+}
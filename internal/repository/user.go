@@ -2,21 +2,56 @@ package repository
 
 import (
 	"context"
+	"github.com/go-sql-driver/mysql"
 	"github.com/pkg/errors"
+	"gorm.io/gorm"
 	"loverrecipe/internal/domain"
+	"loverrecipe/internal/repository/cache"
 	"loverrecipe/internal/repository/dao"
+
+	"github.com/gotomicro/ego/core/elog"
 )
 
 type UserRepository interface {
+	// CreateUser 创建用户，用户名重复时返回 ErrUserAlreadyExists
 	CreateUser(ctx context.Context, user *domain.CreateUserInput) error
+	UsernameExists(ctx context.Context, username string) (bool, error)
+	GetByID(ctx context.Context, id int64) (domain.User, error)
+	GetByUsername(ctx context.Context, username string) (domain.User, error)
+	GetByEmail(ctx context.Context, email string) (domain.User, error)
+	UpdateLastLogin(ctx context.Context, id int64) error
+	UpdateProfile(ctx context.Context, user domain.User) error
+	// UpdatePassword 更新密码并递增令牌版本
+	UpdatePassword(ctx context.Context, id int64, hashedPassword string) error
+	// SetPendingEmail 设置待验证的邮箱，为空表示撤销。不检查邮箱是否已被其他账号使用
+	SetPendingEmail(ctx context.Context, id int64, email string) error
+	// ClearEmail 解除邮箱绑定，同时撤销待验证的邮箱
+	ClearEmail(ctx context.Context, id int64) error
+	// VerifyEmail 用户待验证的邮箱仍为 email 时设为已验证的邮箱，否则返回 ErrEmailTokenInvalid；
+	// 邮箱已被其他账号验证时返回 ErrEmailAlreadyUsed
+	VerifyEmail(ctx context.Context, id int64, email string) error
+	// DeleteAccount 删除用户及其全部数据
+	DeleteAccount(ctx context.Context, id int64) error
+	List(ctx context.Context, query domain.UserQuery) ([]domain.User, int64, error)
+	Count(ctx context.Context) (int64, error)
+	// GetAuthState 获取用户状态、角色与令牌版本，优先读取短期缓存，用户不存在返回 ErrUserNotFound
+	GetAuthState(ctx context.Context, id int64) (domain.UserAuthState, error)
+	UpdateStatus(ctx context.Context, id int64, status int64) error
+	UpdateRole(ctx context.Context, id int64, role domain.Role) error
+	// RevokeTokens 递增令牌版本，使已签发的刷新令牌失效
+	RevokeTokens(ctx context.Context, id int64) error
+	GetDietaryProfile(ctx context.Context, userID int64) (domain.DietaryProfile, error)
+	SaveDietaryProfile(ctx context.Context, userID int64, profile domain.DietaryProfile) error
 }
 
 type userRepository struct {
-	dao dao.UserDao
+	dao         dao.UserDao
+	statusCache cache.UserStatusCache
+	dishesCache cache.DishesCache
 }
 
-func NewUserRepository(dao dao.UserDao) UserRepository {
-	return &userRepository{dao: dao}
+func NewUserRepository(dao dao.UserDao, statusCache cache.UserStatusCache, dishesCache cache.DishesCache) UserRepository {
+	return &userRepository{dao: dao, statusCache: statusCache, dishesCache: dishesCache}
 }
 
 func (r *userRepository) CreateUser(ctx context.Context, user *domain.CreateUserInput) error {
@@ -25,12 +60,286 @@ func (r *userRepository) CreateUser(ctx context.Context, user *domain.CreateUser
 		Username: user.Name,
 		Password: user.Password,
 		Avatar:   user.Avatar,
-		Status:   user.Status,
+		Status:   domain.UserStatusNormal,
 	}
 
 	_, err := r.dao.Create(ctx, du)
+	if isDuplicateEntry(err) {
+		// 预检查与插入之间被并发注册抢先，由唯一索引兜底
+		return domain.ErrUserAlreadyExists
+	}
 	if err != nil {
 		return errors.Wrap(err, "create user failed")
 	}
 	return nil
 }
+
+// UsernameExists 检查用户名是否已被注册
+func (r *userRepository) UsernameExists(ctx context.Context, username string) (bool, error) {
+	exists, err := r.dao.CheckUsernameExists(ctx, username)
+	if err != nil {
+		return false, errors.Wrap(err, "check username failed")
+	}
+	return exists, nil
+}
+
+// GetByID 根据ID获取用户
+func (r *userRepository) GetByID(ctx context.Context, id int64) (domain.User, error) {
+	du, err := r.dao.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.User{}, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return domain.User{}, errors.Wrap(err, "get user failed")
+	}
+	return r.toDomain(du), nil
+}
+
+// GetByUsername 根据用户名获取用户
+func (r *userRepository) GetByUsername(ctx context.Context, username string) (domain.User, error) {
+	du, err := r.dao.GetByUsername(ctx, username)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.User{}, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return domain.User{}, errors.Wrap(err, "get user by username failed")
+	}
+	return r.toDomain(du), nil
+}
+
+// GetByEmail 根据邮箱获取用户
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (domain.User, error) {
+	du, err := r.dao.GetByEmail(ctx, email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.User{}, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return domain.User{}, errors.Wrap(err, "get user by email failed")
+	}
+	return r.toDomain(du), nil
+}
+
+// UpdateLastLogin 更新最后登录时间
+func (r *userRepository) UpdateLastLogin(ctx context.Context, id int64) error {
+	if err := r.dao.UpdateLastLogin(ctx, id); err != nil {
+		return errors.Wrap(err, "update last login failed")
+	}
+	return nil
+}
+
+// UpdateProfile 更新显示名称与头像
+func (r *userRepository) UpdateProfile(ctx context.Context, user domain.User) error {
+	if err := r.dao.UpdateProfile(ctx, user.ID, user.Nickname, user.Avatar); err != nil {
+		return errors.Wrap(err, "update profile failed")
+	}
+	return nil
+}
+
+// UpdatePassword 更新密码并递增令牌版本
+func (r *userRepository) UpdatePassword(ctx context.Context, id int64, hashedPassword string) error {
+	if err := r.dao.UpdatePassword(ctx, id, hashedPassword); err != nil {
+		return errors.Wrap(err, "update password failed")
+	}
+	r.invalidateStatus(ctx, id)
+	return nil
+}
+
+// SetPendingEmail 设置待验证的邮箱
+func (r *userRepository) SetPendingEmail(ctx context.Context, id int64, email string) error {
+	if err := r.dao.SetPendingEmail(ctx, id, email); err != nil {
+		return errors.Wrap(err, "set pending email failed")
+	}
+	return nil
+}
+
+// ClearEmail 解除邮箱绑定
+func (r *userRepository) ClearEmail(ctx context.Context, id int64) error {
+	if err := r.dao.ClearEmail(ctx, id); err != nil {
+		return errors.Wrap(err, "clear email failed")
+	}
+	return nil
+}
+
+// VerifyEmail 把待验证的邮箱设为已验证的邮箱
+func (r *userRepository) VerifyEmail(ctx context.Context, id int64, email string) error {
+	rows, err := r.dao.MarkEmailVerified(ctx, id, email)
+	if isDuplicateEntry(err) {
+		return domain.ErrEmailAlreadyUsed
+	}
+	if err != nil {
+		return errors.Wrap(err, "verify email failed")
+	}
+	if rows == 0 {
+		return domain.ErrEmailTokenInvalid
+	}
+	return nil
+}
+
+// DeleteAccount 删除用户及其全部数据，并清除被删除菜品与统计有变化菜品的缓存
+func (r *userRepository) DeleteAccount(ctx context.Context, id int64) error {
+	dishes, err := r.dao.DeleteAccount(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "delete account failed")
+	}
+	r.invalidateStatus(ctx, id)
+	// 没有菜品的用户也可能缓存了空列表
+	r.invalidateDishes(ctx, 0, id)
+	for _, dish := range dishes {
+		r.invalidateDishes(ctx, dish.ID, dish.UserID)
+	}
+	return nil
+}
+
+// List 分页查询用户，同时返回满足条件的总数
+func (r *userRepository) List(ctx context.Context, query domain.UserQuery) ([]domain.User, int64, error) {
+	total, err := r.dao.Count(ctx, query.Keyword)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "count users failed")
+	}
+	dus, err := r.dao.Find(ctx, query.Keyword, query.Offset, query.Limit)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "find users failed")
+	}
+
+	users := make([]domain.User, 0, len(dus))
+	for _, du := range dus {
+		users = append(users, r.toDomain(du))
+	}
+	return users, total, nil
+}
+
+// Count 统计用户总数
+func (r *userRepository) Count(ctx context.Context) (int64, error) {
+	count, err := r.dao.Count(ctx, "")
+	if err != nil {
+		return 0, errors.Wrap(err, "count users failed")
+	}
+	return count, nil
+}
+
+// GetAuthState 获取用户状态、角色与令牌版本。缓存读写失败时直接查库，不存在的用户同样缓存，避免反复查库
+func (r *userRepository) GetAuthState(ctx context.Context, id int64) (domain.UserAuthState, error) {
+	state, err := r.statusCache.Get(ctx, id)
+	switch {
+	case err == nil && state.Status == cache.StatusNotFound:
+		return domain.UserAuthState{}, domain.ErrUserNotFound
+	case err == nil:
+		return state, nil
+	case !cache.IsMiss(err):
+		elog.Warn("读取用户状态缓存失败", elog.FieldErr(err), elog.Int64("userID", id))
+	}
+
+	du, err := r.dao.GetByID(ctx, id)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		state = domain.UserAuthState{Status: cache.StatusNotFound}
+	case err != nil:
+		return domain.UserAuthState{}, errors.Wrap(err, "get user status failed")
+	default:
+		state = domain.UserAuthState{Status: du.Status, Role: domain.Role(du.Role), TokenVersion: du.TokenVersion}
+	}
+	if cerr := r.statusCache.Set(ctx, id, state); cerr != nil {
+		elog.Warn("写入用户状态缓存失败", elog.FieldErr(cerr), elog.Int64("userID", id))
+	}
+
+	if state.Status == cache.StatusNotFound {
+		return domain.UserAuthState{}, domain.ErrUserNotFound
+	}
+	return state, nil
+}
+
+// UpdateStatus 更新用户状态并清除状态缓存
+func (r *userRepository) UpdateStatus(ctx context.Context, id int64, status int64) error {
+	if err := r.dao.UpdateStatus(ctx, id, status); err != nil {
+		return errors.Wrap(err, "update status failed")
+	}
+	r.invalidateStatus(ctx, id)
+	return nil
+}
+
+// invalidateStatus 清除状态缓存，失败时由缓存过期时间兜底
+func (r *userRepository) invalidateStatus(ctx context.Context, id int64) {
+	if err := r.statusCache.Del(ctx, id); err != nil {
+		elog.Warn("清除用户状态缓存失败", elog.FieldErr(err), elog.Int64("userID", id))
+	}
+}
+
+// invalidateDishes 清除菜品缓存，失败时由缓存过期时间兜底
+func (r *userRepository) invalidateDishes(ctx context.Context, id int64, userID int64) {
+	invalidateDishesCache(ctx, r.dishesCache, id, userID)
+}
+
+// UpdateRole 更新用户角色并清除状态缓存
+func (r *userRepository) UpdateRole(ctx context.Context, id int64, role domain.Role) error {
+	if err := r.dao.UpdateRole(ctx, id, string(role)); err != nil {
+		return errors.Wrap(err, "update role failed")
+	}
+	r.invalidateStatus(ctx, id)
+	return nil
+}
+
+// RevokeTokens 递增令牌版本并清除状态缓存
+func (r *userRepository) RevokeTokens(ctx context.Context, id int64) error {
+	if err := r.dao.IncrTokenVersion(ctx, id); err != nil {
+		return errors.Wrap(err, "revoke tokens failed")
+	}
+	r.invalidateStatus(ctx, id)
+	return nil
+}
+
+// GetDietaryProfile 获取用户的饮食档案，用户不存在时返回空档案
+func (r *userRepository) GetDietaryProfile(ctx context.Context, userID int64) (domain.DietaryProfile, error) {
+	du, err := r.dao.GetByID(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.DietaryProfile{Allergens: []domain.Allergen{}, Diets: []domain.Diet{}}, nil
+	}
+	if err != nil {
+		return domain.DietaryProfile{}, errors.Wrap(err, "get dietary profile failed")
+	}
+
+	return domain.DietaryProfile{
+		Allergens: decodeCommaList[domain.Allergen](du.Allergens),
+		Diets:     decodeCommaList[domain.Diet](du.Diets),
+	}, nil
+}
+
+// SaveDietaryProfile 保存用户的饮食档案
+func (r *userRepository) SaveDietaryProfile(ctx context.Context, userID int64, profile domain.DietaryProfile) error {
+	err := r.dao.UpdateDietaryProfile(ctx, userID, encodeCommaList(profile.Allergens), encodeCommaList(profile.Diets))
+	if err != nil {
+		return errors.Wrap(err, "save dietary profile failed")
+	}
+	return nil
+}
+
+func (r *userRepository) toDomain(du dao.User) domain.User {
+	var email string
+	if du.Email != nil {
+		email = *du.Email
+	}
+	return domain.User{
+		ID:            int64(du.ID),
+		Name:          du.Username,
+		Nickname:      du.Nickname,
+		Password:      du.Password,
+		Avatar:        du.Avatar,
+		Email:         email,
+		EmailVerified: email != "" && du.EmailVerifiedAt > 0,
+		PendingEmail:  du.PendingEmail,
+		Role:          domain.Role(du.Role),
+		Status:        du.Status,
+		LastLogin:     du.LastLogin,
+		Ctime:         du.Ctime,
+		Utime:         du.Utime,
+		TokenVersion:  du.TokenVersion,
+	}
+}
+
+// mysqlErrDuplicateEntry MySQL 唯一索引冲突的错误码
+const mysqlErrDuplicateEntry = 1062
+
+// isDuplicateEntry 判断是否为唯一索引冲突
+func isDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry
+}
@@ -30,6 +30,7 @@ const (
 	CodeTokenExpired       = 1004 // 令牌过期
 	CodeTokenInvalid       = 1005 // 令牌无效
 	CodePermissionDenied   = 1006 // 权限不足
+	CodeUserDisabled       = 1007 // 账号已禁用
 
 	// 菜品相关错误码 (2000-2999)
 	CodeDishNotFound     = 2001 // 菜品不存在
@@ -93,6 +94,7 @@ var errorMessages = map[int]string{
 	CodeTokenExpired:       "令牌过期",
 	CodeTokenInvalid:       "令牌无效",
 	CodePermissionDenied:   "权限不足",
+	CodeUserDisabled:       "账号已禁用",
 
 	// 菜品相关错误
 	CodeDishNotFound:     "菜品不存在",
@@ -237,6 +237,14 @@ func PermissionDenied(c *gin.Context) {
 	})
 }
 
+// UserDisabled 账号已禁用
+func UserDisabled(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{
+		Code: CodeUserDisabled,
+		Msg:  GetErrorMessage(CodeUserDisabled),
+	})
+}
+
 // DishNotFound 菜品不存在
 func DishNotFound(c *gin.Context) {
 	c.JSON(http.StatusOK, Response{
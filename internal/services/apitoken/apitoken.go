@@ -0,0 +1,159 @@
+package apitoken
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/repository"
+	"loverrecipe/internal/services/audit"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gotomicro/ego/core/elog"
+)
+
+// secretBytes 令牌随机部分的字节数
+const secretBytes = 32
+
+// hintLength 返回给用户辨认令牌的前缀长度，包含 lrp_ 前缀
+const hintLength = 12
+
+type Service interface {
+	// Create 创建访问令牌，返回的明文令牌只出现这一次
+	Create(ctx context.Context, req domain.CreateAPITokenRequest) (domain.CreateAPITokenResponse, error)
+	List(ctx context.Context, userID int64) ([]domain.APIToken, error)
+	Revoke(ctx context.Context, userID int64, id int64) error
+	// Authenticate 校验明文令牌并更新最近使用时间，令牌无效或过期返回 ErrAPITokenInvalid
+	Authenticate(ctx context.Context, raw string) (domain.APIToken, error)
+}
+
+type service struct {
+	repo    repository.APITokenRepository
+	auditor audit.Recorder
+}
+
+func NewService(repo repository.APITokenRepository, auditor audit.Recorder) Service {
+	return &service{repo: repo, auditor: auditor}
+}
+
+// Create 创建访问令牌。权限范围去重后校验，库中只保存令牌的 SHA-256 哈希
+func (s *service) Create(ctx context.Context, req domain.CreateAPITokenRequest) (domain.CreateAPITokenResponse, error) {
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return domain.CreateAPITokenResponse{}, err
+	}
+	if req.ExpiresAt != 0 && req.ExpiresAt <= time.Now().Unix() {
+		return domain.CreateAPITokenResponse{}, domain.ErrAPITokenExpiryInvalid
+	}
+
+	count, err := s.repo.CountByUser(ctx, req.UserID)
+	if err != nil {
+		return domain.CreateAPITokenResponse{}, err
+	}
+	if count >= domain.MaxAPITokensPerUser {
+		return domain.CreateAPITokenResponse{}, domain.ErrAPITokenLimitExceeded
+	}
+
+	raw, err := generate()
+	if err != nil {
+		return domain.CreateAPITokenResponse{}, err
+	}
+	token, err := s.repo.Create(ctx, domain.APIToken{
+		UserID:    req.UserID,
+		Name:      strings.TrimSpace(req.Name),
+		Hint:      raw[:hintLength],
+		Scopes:    scopes,
+		ExpiresAt: req.ExpiresAt,
+	}, hash(raw))
+	if err != nil {
+		return domain.CreateAPITokenResponse{}, err
+	}
+
+	s.record(ctx, req.UserID, domain.AuditAPITokenCreate, token.ID, domain.NewAuditDiff(nil, token))
+	return domain.CreateAPITokenResponse{APIToken: token, Token: raw}, nil
+}
+
+// List 获取用户的访问令牌，不含明文
+func (s *service) List(ctx context.Context, userID int64) ([]domain.APIToken, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+// Revoke 吊销访问令牌，只能吊销自己的令牌
+func (s *service) Revoke(ctx context.Context, userID int64, id int64) error {
+	if err := s.repo.Delete(ctx, id, userID); err != nil {
+		return err
+	}
+	s.record(ctx, userID, domain.AuditAPITokenRevoke, id, nil)
+	return nil
+}
+
+// Authenticate 校验访问令牌。更新最近使用时间失败只打印日志，不影响本次请求
+func (s *service) Authenticate(ctx context.Context, raw string) (domain.APIToken, error) {
+	if !strings.HasPrefix(raw, domain.APITokenPrefix) {
+		return domain.APIToken{}, domain.ErrAPITokenInvalid
+	}
+	token, err := s.repo.GetByHash(ctx, hash(raw))
+	if err == domain.ErrAPITokenNotFound {
+		return domain.APIToken{}, domain.ErrAPITokenInvalid
+	}
+	if err != nil {
+		return domain.APIToken{}, err
+	}
+
+	now := time.Now().Unix()
+	if token.Expired(now) {
+		return domain.APIToken{}, domain.ErrAPITokenInvalid
+	}
+	if err := s.repo.TouchLastUsed(ctx, token.ID, now); err != nil {
+		elog.Warn("更新访问令牌使用时间失败", elog.FieldErr(err), elog.Int64("tokenID", token.ID))
+	}
+	return token, nil
+}
+
+func (s *service) record(ctx context.Context, userID int64, action domain.AuditAction, tokenID int64, diff []byte) {
+	s.auditor.Record(ctx, domain.AuditLog{
+		ActorID:    userID,
+		Action:     action,
+		TargetType: domain.AuditTargetAPIToken,
+		TargetID:   strconv.FormatInt(tokenID, 10),
+		Diff:       diff,
+	})
+}
+
+// normalizeScopes 去重并校验权限范围，保持 APITokenScopes 中的顺序
+func normalizeScopes(scopes []string) ([]string, error) {
+	requested := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		requested[strings.TrimSpace(scope)] = true
+	}
+	result := make([]string, 0, len(requested))
+	for _, scope := range domain.APITokenScopes {
+		if requested[scope] {
+			result = append(result, scope)
+			delete(requested, scope)
+		}
+	}
+	if len(result) == 0 || len(requested) > 0 {
+		return nil, domain.ErrAPITokenScopeInvalid
+	}
+	return result, nil
+}
+
+// generate 生成明文令牌：前缀加 32 字节随机数的 base64url 编码
+func generate() (string, error) {
+	buf := make([]byte, secretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return domain.APITokenPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hash 令牌本身是高熵随机数，使用 SHA-256 即可，无需加盐的慢哈希
+func hash(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
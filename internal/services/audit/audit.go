@@ -0,0 +1,153 @@
+package audit
+
+import (
+	"context"
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/repository"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gotomicro/ego/core/elog"
+)
+
+// Config 异步写入配置
+type Config struct {
+	BufferSize    int           // 缓冲队列长度，队列满时丢弃新记录并打印日志
+	BatchSize     int           // 每批最多写入的记录数
+	FlushInterval time.Duration // 未攒满一批时的最长等待时间
+}
+
+// 默认写入配置
+const (
+	defaultBufferSize    = 1024
+	defaultBatchSize     = 100
+	defaultFlushInterval = time.Second
+	flushTimeout         = 5 * time.Second
+)
+
+// Recorder 记录审计日志，调用方不等待写库
+type Recorder interface {
+	// Record 补充请求来源信息与时间后放入缓冲队列
+	Record(ctx context.Context, log domain.AuditLog)
+}
+
+type Service interface {
+	Recorder
+	List(ctx context.Context, query domain.AuditLogQuery) ([]domain.AuditLog, int64, error)
+	// Start 后台批量写库，ctx 取消后写完队列中剩余的记录再返回
+	Start(ctx context.Context)
+}
+
+type service struct {
+	repo  repository.AuditLogRepository
+	cfg   Config
+	queue chan domain.AuditLog
+}
+
+// NewService 创建审计服务，未配置的项使用默认值。需要调用 Start 才会写库
+func NewService(repo repository.AuditLogRepository, cfg Config) Service {
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = defaultBufferSize
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = defaultFlushInterval
+	}
+	return &service{
+		repo:  repo,
+		cfg:   cfg,
+		queue: make(chan domain.AuditLog, cfg.BufferSize),
+	}
+}
+
+// Record 放入缓冲队列，队列满时丢弃，不阻塞请求
+func (s *service) Record(ctx context.Context, log domain.AuditLog) {
+	meta := domain.RequestMetaFrom(ctx)
+	log.IP = meta.IP
+	log.UserAgent = truncate(meta.UserAgent, 255)
+	log.TraceID = meta.TraceID
+	log.TargetID = truncate(log.TargetID, 64)
+	if log.Ctime == 0 {
+		log.Ctime = time.Now().Unix()
+	}
+
+	select {
+	case s.queue <- log:
+	default:
+		elog.Warn("审计队列已满，丢弃记录", elog.String("action", string(log.Action)),
+			elog.Int64("actorID", log.ActorID), elog.String("targetID", log.TargetID))
+	}
+}
+
+// List 分页查询审计记录
+func (s *service) List(ctx context.Context, query domain.AuditLogQuery) ([]domain.AuditLog, int64, error) {
+	query.TargetType = strings.TrimSpace(query.TargetType)
+	query.TargetID = strings.TrimSpace(query.TargetID)
+	return s.repo.List(ctx, query)
+}
+
+// Start 攒批写库，攒满一批或到达刷新间隔时写入
+func (s *service) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]domain.AuditLog, 0, s.cfg.BatchSize)
+	for {
+		select {
+		case log := <-s.queue:
+			batch = append(batch, log)
+			if len(batch) >= s.cfg.BatchSize {
+				batch = s.flush(batch)
+			}
+		case <-ticker.C:
+			batch = s.flush(batch)
+		case <-ctx.Done():
+			s.drain(batch)
+			return
+		}
+	}
+}
+
+// drain 服务停止时写完队列中剩余的记录
+func (s *service) drain(batch []domain.AuditLog) {
+	for {
+		select {
+		case log := <-s.queue:
+			batch = append(batch, log)
+			if len(batch) >= s.cfg.BatchSize {
+				batch = s.flush(batch)
+			}
+		default:
+			s.flush(batch)
+			return
+		}
+	}
+}
+
+// flush 写入一批记录并返回清空后的切片，写库失败时打印日志后丢弃
+func (s *service) flush(batch []domain.AuditLog) []domain.AuditLog {
+	if len(batch) == 0 {
+		return batch
+	}
+	// 服务停止时上游的上下文已取消，写库使用独立的上下文
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	if err := s.repo.BatchCreate(ctx, batch); err != nil {
+		elog.Error("写入审计记录失败", elog.FieldErr(err), elog.Int("count", len(batch)))
+	}
+	return batch[:0]
+}
+
+// truncate 按字节截断，保证不超过数据库列宽且不截断多字节字符
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max]
+}
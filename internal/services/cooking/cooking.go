@@ -0,0 +1,128 @@
+package cooking
+
+import (
+	"context"
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/repository"
+)
+
+type Service interface {
+	CreateCookingLog(ctx context.Context, req domain.CreateCookingLogRequest) (*domain.CookingLog, error)
+	GetCookingLog(ctx context.Context, id int64, userID int64) (*domain.CookingLog, error)
+	UpdateCookingLog(ctx context.Context, req domain.UpdateCookingLogRequest) (*domain.CookingLog, error)
+	DeleteCookingLog(ctx context.Context, id int64, userID int64) error
+	ListCookingLogs(ctx context.Context, query domain.CookingLogQuery) (*domain.CookingLogListResponse, error)
+}
+
+type service struct {
+	repo       repository.CookingLogRepository
+	dishesRepo repository.DishesRepository
+}
+
+// NewService 创建烹饪记录服务实例
+func NewService(repo repository.CookingLogRepository, dishesRepo repository.DishesRepository) Service {
+	return &service{
+		repo:       repo,
+		dishesRepo: dishesRepo,
+	}
+}
+
+// CreateCookingLog 记录一次烹饪
+func (s *service) CreateCookingLog(ctx context.Context, req domain.CreateCookingLogRequest) (*domain.CookingLog, error) {
+	log, err := domain.NewCookingLog(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkDishOwned(ctx, log.DishID, log.UserID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Create(ctx, *log)
+}
+
+// GetCookingLog 获取烹饪记录详情
+func (s *service) GetCookingLog(ctx context.Context, id int64, userID int64) (*domain.CookingLog, error) {
+	if id <= 0 {
+		return nil, domain.ErrCookingLogNotFound
+	}
+
+	log, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if log.UserID != userID {
+		return nil, domain.ErrCookingLogUserMismatch
+	}
+
+	return log, nil
+}
+
+// UpdateCookingLog 更新烹饪记录
+func (s *service) UpdateCookingLog(ctx context.Context, req domain.UpdateCookingLogRequest) (*domain.CookingLog, error) {
+	log, err := s.GetCookingLog(ctx, req.ID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousDishID := log.DishID
+	if err := log.Update(req); err != nil {
+		return nil, err
+	}
+	if log.DishID != previousDishID {
+		if err := s.checkDishOwned(ctx, log.DishID, log.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Update(ctx, *log, previousDishID); err != nil {
+		return nil, err
+	}
+
+	return log, nil
+}
+
+// DeleteCookingLog 删除烹饪记录
+func (s *service) DeleteCookingLog(ctx context.Context, id int64, userID int64) error {
+	log, err := s.GetCookingLog(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if err := log.CanDelete(userID); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, *log)
+}
+
+// ListCookingLogs 分页查询烹饪记录
+func (s *service) ListCookingLogs(ctx context.Context, query domain.CookingLogQuery) (*domain.CookingLogListResponse, error) {
+	if query.UserID <= 0 {
+		return nil, domain.ErrCookingLogUserMismatch
+	}
+
+	// 设置默认分页参数
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+	if query.Limit > 100 {
+		query.Limit = 100
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+
+	return s.repo.List(ctx, query)
+}
+
+// checkDishOwned 只能记录自己的菜品，否则会改动其他用户菜品的烹饪次数与最近烹饪时间
+func (s *service) checkDishOwned(ctx context.Context, dishID int64, userID int64) error {
+	dish, err := s.dishesRepo.GetByID(ctx, dishID)
+	if err != nil {
+		return err
+	}
+	if dish.UserID != userID {
+		return domain.ErrDishesUserMismatch
+	}
+	return nil
+}
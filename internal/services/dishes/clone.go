@@ -0,0 +1,111 @@
+package dishes
+
+import (
+	"context"
+
+	"loverrecipe/internal/domain"
+)
+
+// CloneDishes 将用户可读的菜品（自己的，或通过有效分享链接公开的）复制到用户自己的菜品中，记录来源菜品与来源用户。
+// 来源种类不属于该用户时，按名称匹配用户已有的种类，没有则复制一个同名种类
+func (s *service) CloneDishes(ctx context.Context, userID int64, sourceID int64) (*domain.Dishes, error) {
+	if userID <= 0 {
+		return nil, domain.ErrDishesUserMismatch
+	}
+	source, err := s.getReadableDishes(ctx, userID, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	typeID, err := s.resolveCloneType(ctx, userID, *source)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CreateDishes(ctx, domain.CreateDishesRequest{
+		UserID:       userID,
+		Name:         source.Name,
+		Desc:         source.Desc,
+		Price:        source.Price,
+		Img:          source.Img,
+		Type:         typeID,
+		Calorie:      source.Calorie,
+		Ingredients:  source.Ingredients,
+		Allergens:    source.Allergens,
+		SourceDishID: source.ID,
+		SourceUserID: source.UserID,
+		SourceDigest: source.ContentDigest(),
+	})
+}
+
+// GetDishesSourceStatus 对比复制而来的菜品与来源菜品，判断来源在复制后是否有改动。
+// 来源已删除或分享已失效时按来源不存在处理，不透露无权读取的菜品是否有改动
+func (s *service) GetDishesSourceStatus(ctx context.Context, userID int64, id int64) (*domain.DishesSourceStatus, error) {
+	dish, err := s.GetDishesByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if dish.UserID != userID {
+		return nil, domain.ErrDishesUserMismatch
+	}
+	if dish.SourceDishID == 0 {
+		return nil, domain.ErrDishesNotCloned
+	}
+
+	status := &domain.DishesSourceStatus{
+		SourceDishID: dish.SourceDishID,
+		SourceUserID: dish.SourceUserID,
+	}
+	source, err := s.getReadableDishes(ctx, userID, dish.SourceDishID)
+	if err == domain.ErrDishesNotFound || err == domain.ErrDishesUserMismatch {
+		return status, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	status.SourceExists = true
+	status.SourceUtime = source.Utime
+	status.Changed = source.ContentDigest() != dish.SourceDigest
+	return status, nil
+}
+
+// resolveCloneType 为复制的菜品确定种类：来源种类属于该用户时直接使用；
+// 否则按名称匹配用户已有的种类；仍没有时复制来源种类的名称、描述、图标与颜色创建新种类。
+// 来源种类已不存在，或既不属于该用户也不属于来源菜品的所有者时归入"未分类"，不复制其他用户种类的信息
+func (s *service) resolveCloneType(ctx context.Context, userID int64, source domain.Dishes) (int64, error) {
+	sourceType, err := s.typeRepo.GetByID(ctx, source.Type)
+	switch {
+	case err == domain.ErrDishTypeNotFound:
+		sourceType = &domain.DishType{Name: uncategorizedTypeName}
+	case err != nil:
+		return 0, err
+	case sourceType.UserID == userID:
+		return sourceType.ID, nil
+	case sourceType.UserID != source.UserID:
+		sourceType = &domain.DishType{Name: uncategorizedTypeName}
+	}
+
+	dishTypes, err := s.typeRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	key := domain.NormalizeDishName(sourceType.Name)
+	for _, dt := range dishTypes {
+		if domain.NormalizeDishName(dt.Name) == key {
+			return dt.ID, nil
+		}
+	}
+
+	dishType, err := domain.NewDishType(userID, sourceType.Name)
+	if err != nil {
+		return 0, err
+	}
+	dishType.Description = sourceType.Description
+	dishType.Icon = sourceType.Icon
+	dishType.Color = sourceType.Color
+	created, err := s.typeRepo.Create(ctx, *dishType)
+	if err != nil {
+		return 0, err
+	}
+	return created.ID, nil
+}
@@ -0,0 +1,48 @@
+package dishes
+
+import (
+	"context"
+	"loverrecipe/internal/domain"
+	"math/rand"
+)
+
+// RandomDishes 在满足过滤条件的菜品中随机挑选一道
+func (s *service) RandomDishes(ctx context.Context, query domain.DishesQuery) (*domain.DishesWithType, error) {
+	query.Offset = 0
+	query.Limit = 1
+
+	// 先取总数，再按随机偏移取一条，过滤条件与列表保持一致
+	first, err := s.ListDishes(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if first.Total == 0 || len(first.List) == 0 {
+		return nil, domain.ErrDishesNotFound
+	}
+
+	offset := rand.Int63n(first.Total)
+	if offset == 0 {
+		return &first.List[0], nil
+	}
+
+	query.Offset = int(offset)
+	picked, err := s.ListDishes(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	// 两次查询之间菜品被删除时退回第一条
+	if len(picked.List) == 0 {
+		return &first.List[0], nil
+	}
+	return &picked.List[0], nil
+}
+
+// flagDietaryConflicts 为每道菜标记与饮食档案冲突的成分
+func flagDietaryConflicts(profile domain.DietaryProfile, dishes []domain.DishesWithType) {
+	for i := range dishes {
+		dishes[i].Conflicts = domain.CheckDietaryConflicts(profile, dishes[i].Allergens)
+		if dishes[i].Conflicts == nil {
+			dishes[i].Conflicts = []domain.DietaryConflict{}
+		}
+	}
+}
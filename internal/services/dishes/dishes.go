@@ -2,33 +2,71 @@ package dishes
 
 import (
 	"context"
+	"io"
 	"loverrecipe/internal/domain"
+	"loverrecipe/internal/pkg/schemaorg"
 	"loverrecipe/internal/repository"
+	"loverrecipe/internal/services/audit"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Service interface {
 	CreateDishes(ctx context.Context, req domain.CreateDishesRequest) (*domain.Dishes, error)
 	GetDishesByID(ctx context.Context, id int64) (*domain.Dishes, error)
 	GetDishesByUserID(ctx context.Context, userID int64) ([]domain.Dishes, error)
-	GetDishesByType(ctx context.Context, typeID int64) ([]domain.Dishes, error)
+	GetDishesByType(ctx context.Context, userID int64, typeID int64, includeDescendants bool) ([]domain.Dishes, error)
 	GetDishesByUserIDAndType(ctx context.Context, userID int64, typeID int64) ([]domain.Dishes, error)
 	GetDishesWithTypeInfo(ctx context.Context, userID int64) ([]domain.DishesWithType, error)
 	UpdateDishes(ctx context.Context, req domain.UpdateDishesRequest) (*domain.Dishes, error)
 	DeleteDishes(ctx context.Context, id int64, userID int64) error
 	ListDishes(ctx context.Context, query domain.DishesQuery) (*domain.DishesListResponse, error)
 	GetDishesCount(ctx context.Context) (int64, error)
-	SearchDishes(ctx context.Context, userID int64, keyword string, offset int, limit int) (*domain.DishesListResponse, error)
-	GetDishesStatistics(ctx context.Context, userID int64) (*DishesStatistics, error)
+	SearchDishes(ctx context.Context, query domain.DishesQuery) (*domain.DishesListResponse, error)
+	RandomDishes(ctx context.Context, query domain.DishesQuery) (*domain.DishesWithType, error)
+	GetDishesStatistics(ctx context.Context, query domain.DishesStatisticsQuery) (*DishesStatistics, error)
+	ExportDishes(ctx context.Context, userID int64, format domain.DishesExchangeFormat) (*domain.DishesExportFile, error)
+	ImportDishes(ctx context.Context, userID int64, format domain.DishesExchangeFormat, r io.Reader) (*domain.DishesImportResult, error)
+	ImportRecipe(ctx context.Context, req domain.RecipeImportRequest) (*domain.Dishes, error)
+	FavoriteDishes(ctx context.Context, userID int64, dishID int64) error
+	UnfavoriteDishes(ctx context.Context, userID int64, dishID int64) error
+	RateDishes(ctx context.Context, req domain.RateDishesRequest) (*domain.DishRating, error)
+	DeleteDishesRating(ctx context.Context, userID int64, dishID int64) error
+	ListDishesRatings(ctx context.Context, userID int64, dishID int64, offset int, limit int) (*domain.DishRatingListResponse, error)
+	CloneDishes(ctx context.Context, userID int64, sourceID int64) (*domain.Dishes, error)
+	GetDishesSourceStatus(ctx context.Context, userID int64, id int64) (*domain.DishesSourceStatus, error)
+	FindDuplicateDishes(ctx context.Context, userID int64, minSimilarity float64) ([]domain.DishesDuplicateGroup, error)
+	FindSimilarDishes(ctx context.Context, userID int64, name string, excludeID int64) ([]domain.DishesDuplicateItem, error)
+	MergeDishes(ctx context.Context, req domain.MergeDishesRequest) (*domain.Dishes, error)
+	ReorderDishes(ctx context.Context, req domain.ReorderRequest) error
 }
 
 type service struct {
-	repo repository.DishesRepository
+	repo          repository.DishesRepository
+	typeRepo      repository.DishTypeRepository
+	feedbackRepo  repository.DishFeedbackRepository
+	cookingRepo   repository.CookingLogRepository
+	userRepo      repository.UserRepository
+	shareRepo     repository.ShareRepository
+	recipeFetcher *schemaorg.Fetcher
+	auditor       audit.Recorder
 }
 
 // NewService 创建菜品服务实例
-func NewService(repo repository.DishesRepository) Service {
+func NewService(repo repository.DishesRepository, typeRepo repository.DishTypeRepository,
+	feedbackRepo repository.DishFeedbackRepository, cookingRepo repository.CookingLogRepository,
+	userRepo repository.UserRepository, shareRepo repository.ShareRepository, recipeFetcher *schemaorg.Fetcher,
+	auditor audit.Recorder) Service {
 	return &service{
-		repo: repo,
+		repo:          repo,
+		typeRepo:      typeRepo,
+		feedbackRepo:  feedbackRepo,
+		cookingRepo:   cookingRepo,
+		userRepo:      userRepo,
+		shareRepo:     shareRepo,
+		recipeFetcher: recipeFetcher,
+		auditor:       auditor,
 	}
 }
 
@@ -44,6 +82,7 @@ func (s *service) CreateDishes(ctx context.Context, req domain.CreateDishesReque
 	if err != nil {
 		return nil, err
 	}
+	s.recordDishes(ctx, domain.AuditDishCreate, req.UserID, dishes.ID, nil, dishes)
 
 	return dishes, nil
 }
@@ -62,6 +101,25 @@ func (s *service) GetDishesByID(ctx context.Context, id int64) (*domain.Dishes,
 	return dishes, nil
 }
 
+// getReadableDishes 获取用户可以读取的菜品：自己的菜品，或所有者仍通过有效的分享链接公开的菜品及其种类下的菜品
+func (s *service) getReadableDishes(ctx context.Context, userID int64, id int64) (*domain.Dishes, error) {
+	dish, err := s.GetDishesByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if dish.UserID == userID {
+		return dish, nil
+	}
+	shared, err := s.shareRepo.HasActiveDishShare(ctx, *dish, time.Now().Unix())
+	if err != nil {
+		return nil, err
+	}
+	if !shared {
+		return nil, domain.ErrDishesUserMismatch
+	}
+	return dish, nil
+}
+
 // GetDishesByUserID 根据用户ID获取菜品列表
 func (s *service) GetDishesByUserID(ctx context.Context, userID int64) ([]domain.Dishes, error) {
 	if userID <= 0 {
@@ -76,13 +134,23 @@ func (s *service) GetDishesByUserID(ctx context.Context, userID int64) ([]domain
 	return dishes, nil
 }
 
-// GetDishesByType 根据菜品种类获取菜品列表
-func (s *service) GetDishesByType(ctx context.Context, typeID int64) ([]domain.Dishes, error) {
+// GetDishesByType 根据用户自己的菜品种类获取菜品列表，includeDescendants 为 true 时包含全部子孙种类
+func (s *service) GetDishesByType(ctx context.Context, userID int64, typeID int64, includeDescendants bool) ([]domain.Dishes, error) {
 	if typeID <= 0 {
 		return nil, domain.ErrDishesTypeInvalid
 	}
+	dishType, err := s.typeRepo.GetByID(ctx, typeID)
+	if err != nil {
+		return nil, err
+	}
+	if dishType.UserID != userID {
+		return nil, domain.ErrDishTypeUserMismatch
+	}
+	if includeDescendants {
+		return s.repo.GetByTypeTree(ctx, userID, typeID)
+	}
 
-	dishes, err := s.repo.GetByType(ctx, typeID)
+	dishes, err := s.repo.GetByUserIDAndType(ctx, userID, typeID)
 	if err != nil {
 		return nil, err
 	}
@@ -128,11 +196,15 @@ func (s *service) UpdateDishes(ctx context.Context, req domain.UpdateDishesReque
 		return nil, err
 	}
 
+	// 更新前的菜品用于记录审计差异，读取失败时由仓储层返回具体错误
+	before, _ := s.repo.GetByID(ctx, req.ID)
+
 	// 调用仓储层更新菜品
 	dishes, err := s.repo.Update(ctx, req)
 	if err != nil {
 		return nil, err
 	}
+	s.recordDishes(ctx, domain.AuditDishUpdate, req.UserID, dishes.ID, before, dishes)
 
 	return dishes, nil
 }
@@ -146,10 +218,13 @@ func (s *service) DeleteDishes(ctx context.Context, id int64, userID int64) erro
 		return domain.ErrDishesUserMismatch
 	}
 
+	before, _ := s.repo.GetByID(ctx, id)
+
 	err := s.repo.Delete(ctx, id, userID)
 	if err != nil {
 		return err
 	}
+	s.recordDishes(ctx, domain.AuditDishDelete, userID, id, before, nil)
 
 	return nil
 }
@@ -161,6 +236,18 @@ func (s *service) ListDishes(ctx context.Context, query domain.DishesQuery) (*do
 		return nil, err
 	}
 
+	// 标签过滤去重，按全部匹配时依赖去重后的数量
+	var err error
+	if query.TagIDs, err = domain.NormalizeTagFilter(query.TagIDs); err != nil {
+		return nil, err
+	}
+	if query.ExcludeTagIDs, err = domain.NormalizeTagFilter(query.ExcludeTagIDs); err != nil {
+		return nil, err
+	}
+	if query.TagMatch, err = domain.ParseTagMatchMode(string(query.TagMatch)); err != nil {
+		return nil, err
+	}
+
 	// 设置默认分页参数
 	if query.Limit <= 0 {
 		query.Limit = 10
@@ -172,10 +259,20 @@ func (s *service) ListDishes(ctx context.Context, query domain.DishesQuery) (*do
 		query.Offset = 0
 	}
 
+	// 按当前用户的饮食档案排除或标记冲突的菜品
+	profile, err := s.userRepo.GetDietaryProfile(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if query.ExcludeConflicts {
+		query.ExcludeAllergens = profile.ForbiddenAllergens()
+	}
+
 	result, err := s.repo.List(ctx, query)
 	if err != nil {
 		return nil, err
 	}
+	flagDietaryConflicts(profile, result.List)
 
 	return result, nil
 }
@@ -190,105 +287,115 @@ func (s *service) GetDishesCount(ctx context.Context) (int64, error) {
 	return count, nil
 }
 
-// SearchDishes 搜索菜品（简单实现，实际项目中可能需要全文搜索）
-func (s *service) SearchDishes(ctx context.Context, userID int64, keyword string, offset int, limit int) (*domain.DishesListResponse, error) {
+// SearchDishes 搜索菜品，按名称与描述模糊匹配
+func (s *service) SearchDishes(ctx context.Context, query domain.DishesQuery) (*domain.DishesListResponse, error) {
+	query.Keyword = strings.TrimSpace(query.Keyword)
+	return s.ListDishes(ctx, query)
+}
+
+// GetDishesStatistics 获取菜品统计信息
+func (s *service) GetDishesStatistics(ctx context.Context, query domain.DishesStatisticsQuery) (*DishesStatistics, error) {
+	userID := query.UserID
 	if userID <= 0 {
 		return nil, domain.ErrDishesUserMismatch
 	}
-	if keyword == "" {
-		// 如果关键词为空，返回所有菜品
-		query := domain.DishesQuery{
-			UserID: userID,
-			Offset: offset,
-			Limit:  limit,
-		}
-		return s.ListDishes(ctx, query)
-	}
 
-	// 获取用户所有菜品
-	allDishes, err := s.repo.GetByUserID(ctx, userID)
+	period, err := domain.ParseStatisticsPeriod(string(query.Period))
 	if err != nil {
 		return nil, err
 	}
-
-	// 简单关键词匹配
-	var matchedDishes []domain.Dishes
-	for _, dish := range allDishes {
-		if contains(dish.Name, keyword) || contains(dish.Desc, keyword) {
-			matchedDishes = append(matchedDishes, dish)
-		}
+	periods := query.Periods
+	if periods <= 0 {
+		periods = domain.DefaultStatisticsPeriods
+	}
+	if periods > domain.MaxStatisticsPeriods {
+		periods = domain.MaxStatisticsPeriods
 	}
 
-	// 手动分页
-	total := int64(len(matchedDishes))
-	start := offset
-	end := start + limit
-	if end > len(matchedDishes) {
-		end = len(matchedDishes)
+	groupBy, err := domain.ParseDishesGroupBy(string(query.GroupBy))
+	if err != nil {
+		return nil, err
 	}
-	if start > len(matchedDishes) {
-		start = len(matchedDishes)
+	weeks := query.Weeks
+	if weeks <= 0 {
+		weeks = domain.DefaultStatisticsPeriods
+	}
+	if weeks > domain.MaxStatisticsPeriods {
+		weeks = domain.MaxStatisticsPeriods
 	}
 
-	pagedDishes := matchedDishes[start:end]
-
-	// 转换为带种类信息的格式
-	var result []domain.DishesWithType
-	dishesWithType, err := s.repo.GetDishesWithTypeInfo(ctx, userID)
+	// 价格与卡路里的合计、极值与百分位均由数据库聚合
+	summary, err := s.repo.Aggregate(ctx, userID, domain.DishesGroupByNone)
 	if err != nil {
 		return nil, err
 	}
+	overall := summary[0]
 
-	for _, dish := range pagedDishes {
-		for _, dt := range dishesWithType {
-			if dt.ID == dish.ID {
-				result = append(result, dt)
-				break
-			}
-		}
+	stats := &DishesStatistics{
+		TotalDishes:  overall.Count,
+		TotalPrice:   overall.Price.Total,
+		TotalCalorie: overall.Calorie.Total,
+		Price:        overall.Price,
+		Calorie:      overall.Calorie,
+		GroupBy:      groupBy,
+	}
+	if stats.TotalDishes > 0 {
+		stats.AvgPrice = stats.TotalPrice / stats.TotalDishes
+		stats.AvgCalorie = stats.TotalCalorie / stats.TotalDishes
 	}
 
-	return &domain.DishesListResponse{
-		List:  result,
-		Total: total,
-		Page:  offset/limit + 1,
-		Size:  limit,
-	}, nil
-}
-
-// GetDishesStatistics 获取菜品统计信息
-func (s *service) GetDishesStatistics(ctx context.Context, userID int64) (*DishesStatistics, error) {
-	if userID <= 0 {
-		return nil, domain.ErrDishesUserMismatch
+	if groupBy != domain.DishesGroupByNone {
+		stats.Groups, err = s.repo.Aggregate(ctx, userID, groupBy)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// 获取用户所有菜品
-	allDishes, err := s.repo.GetByUserID(ctx, userID)
+	now := time.Now()
+	weekSince := domain.StatisticsPeriodWeek.Since(now, weeks)
+	created, err := s.repo.CountCreatedByPeriod(ctx, userID, domain.StatisticsPeriodWeek, weekSince.Unix())
 	if err != nil {
 		return nil, err
 	}
+	stats.CreatedWeekly = domain.FillPeriodCounts(domain.StatisticsPeriodWeek, weekSince, now, created)
 
-	// 计算统计信息
-	stats := &DishesStatistics{
-		TotalDishes:  int64(len(allDishes)),
-		TotalPrice:   0,
-		TotalCalorie: 0,
-		AvgPrice:     0,
-		AvgCalorie:   0,
+	topRated, err := s.repo.Top(ctx, userID, domain.DishesSortRating, statisticsRankSize)
+	if err != nil {
+		return nil, err
 	}
+	stats.TopRated = toRankItems(topRated)
+	mostFavorited, err := s.repo.Top(ctx, userID, domain.DishesSortFavorites, statisticsRankSize)
+	if err != nil {
+		return nil, err
+	}
+	stats.MostFavorited = toRankItems(mostFavorited)
 
-	if stats.TotalDishes > 0 {
-		for _, dish := range allDishes {
-			stats.TotalPrice += dish.Price
-			stats.TotalCalorie += dish.Calorie
-		}
-		stats.AvgPrice = stats.TotalPrice / stats.TotalDishes
-		stats.AvgCalorie = stats.TotalCalorie / stats.TotalDishes
+	// 按周期统计实际烹饪的卡路里与花费，数据来自烹饪记录而非菜单
+	stats.Period = period
+	since := period.Since(now, periods).Unix()
+	stats.Cooking, err = s.cookingRepo.SumByPeriod(ctx, userID, period, since)
+	if err != nil {
+		return nil, err
 	}
 
 	return stats, nil
 }
 
+// toRankItems 将菜品转换为排行项
+func toRankItems(dishes []domain.Dishes) []DishesRankItem {
+	items := make([]DishesRankItem, 0, len(dishes))
+	for _, dish := range dishes {
+		items = append(items, DishesRankItem{
+			ID:            dish.ID,
+			Name:          dish.Name,
+			RatingAvg:     dish.RatingAvg,
+			RatingCount:   dish.RatingCount,
+			FavoriteCount: dish.FavoriteCount,
+		})
+	}
+	return items
+}
+
 // validateCreateRequest 验证创建请求
 func (s *service) validateCreateRequest(req domain.CreateDishesRequest) error {
 	// 基础验证已在domain层完成，这里可以添加服务层特有的验证逻辑
@@ -306,24 +413,12 @@ func (s *service) validateQuery(query domain.DishesQuery) error {
 	if query.UserID <= 0 {
 		return domain.ErrDishesUserMismatch
 	}
+	if _, err := domain.ParseDishesSort(string(query.Sort)); err != nil {
+		return err
+	}
 	return nil
 }
 
-// contains 简单的字符串包含检查
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && (s == substr ||
-		(len(s) > len(substr) && (s[:len(substr)] == substr ||
-			s[len(s)-len(substr):] == substr ||
-			func() bool {
-				for i := 1; i <= len(s)-len(substr); i++ {
-					if s[i:i+len(substr)] == substr {
-						return true
-					}
-				}
-				return false
-			}())))
-}
-
 // DishesStatistics 菜品统计信息
 type DishesStatistics struct {
 	TotalDishes  int64 `json:"total_dishes"`
@@ -331,4 +426,70 @@ type DishesStatistics struct {
 	TotalCalorie int64 `json:"total_calorie"`
 	AvgPrice     int64 `json:"avg_price"`
 	AvgCalorie   int64 `json:"avg_calorie"`
+	// 价格与卡路里的分布
+	Price   domain.DistributionStat `json:"price"`
+	Calorie domain.DistributionStat `json:"calorie"`
+	// 按种类或创建月份的分组汇总，未指定分组时为空
+	GroupBy domain.DishesGroupBy     `json:"group_by"`
+	Groups  []domain.DishesAggregate `json:"groups"`
+	// 最近若干周每周新建的菜品数
+	CreatedWeekly []domain.PeriodCount `json:"created_weekly"`
+	// 评分最高与收藏最多的菜品
+	TopRated      []DishesRankItem `json:"top_rated"`
+	MostFavorited []DishesRankItem `json:"most_favorited"`
+	// 按周期汇总的烹饪记录
+	Period  domain.StatisticsPeriod    `json:"period"`
+	Cooking []domain.CookingPeriodStat `json:"cooking"`
+}
+
+// statisticsRankSize 统计中排行榜的长度
+const statisticsRankSize = 5
+
+// DishesRankItem 菜品排行项
+type DishesRankItem struct {
+	ID            int64   `json:"id"`
+	Name          string  `json:"name"`
+	RatingAvg     float64 `json:"rating_avg"`
+	RatingCount   int64   `json:"rating_count"`
+	FavoriteCount int64   `json:"favorite_count"`
+}
+
+// dishesAudit 审计差异中记录的菜品字段，不含评分、收藏等由其他操作维护的聚合值
+type dishesAudit struct {
+	Name        string            `json:"name"`
+	Desc        string            `json:"desc"`
+	Price       int64             `json:"price"`
+	Img         string            `json:"img"`
+	Type        int64             `json:"type"`
+	Calorie     int64             `json:"calorie"`
+	Ingredients []string          `json:"ingredients"`
+	Allergens   []domain.Allergen `json:"allergens"`
+}
+
+// recordDishes 记录菜品的审计日志，before 或 after 为 nil 分别表示新建与删除
+func (s *service) recordDishes(ctx context.Context, action domain.AuditAction, actorID int64, dishID int64,
+	before *domain.Dishes, after *domain.Dishes) {
+	s.auditor.Record(ctx, domain.AuditLog{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: domain.AuditTargetDishes,
+		TargetID:   strconv.FormatInt(dishID, 10),
+		Diff:       domain.NewAuditDiff(toDishesAudit(before), toDishesAudit(after)),
+	})
+}
+
+func toDishesAudit(d *domain.Dishes) *dishesAudit {
+	if d == nil {
+		return nil
+	}
+	return &dishesAudit{
+		Name:        d.Name,
+		Desc:        d.Desc,
+		Price:       d.Price,
+		Img:         d.Img,
+		Type:        d.Type,
+		Calorie:     d.Calorie,
+		Ingredients: d.Ingredients,
+		Allergens:   d.Allergens,
+	}
 }
@@ -0,0 +1,219 @@
+package dishes
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"loverrecipe/internal/domain"
+)
+
+// FindDuplicateDishes 在用户的菜品中查找名称相同或相近的菜品，按相似度不低于 minSimilarity 两两相连分组，
+// 每组给出建议保留的菜品。minSimilarity 为 0 时使用默认阈值
+func (s *service) FindDuplicateDishes(ctx context.Context, userID int64, minSimilarity float64) ([]domain.DishesDuplicateGroup, error) {
+	if minSimilarity == 0 {
+		minSimilarity = domain.DefaultDuplicateSimilarity
+	}
+	if minSimilarity < domain.MinDuplicateSimilarity || minSimilarity > 1 {
+		return nil, domain.ErrDuplicateSimilarityInvalid
+	}
+	dishes, err := s.GetDishesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, len(dishes))
+	for i, dish := range dishes {
+		keys[i] = domain.DishNameKey(dish.Name)
+	}
+
+	// 并查集，相近的两道菜品归入同一组
+	parent := make([]int, len(dishes))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i := range dishes {
+		for j := i + 1; j < len(dishes); j++ {
+			if keys[i] == "" || keys[j] == "" || !lengthsClose(keys[i], keys[j], minSimilarity) {
+				continue
+			}
+			if domain.DishNameSimilarity(keys[i], keys[j]) >= minSimilarity {
+				parent[find(i)] = find(j)
+			}
+		}
+	}
+
+	members := make(map[int][]int)
+	for i := range dishes {
+		root := find(i)
+		members[root] = append(members[root], i)
+	}
+	groups := make([]domain.DishesDuplicateGroup, 0)
+	for _, idx := range members {
+		if len(idx) < 2 {
+			continue
+		}
+		groups = append(groups, duplicateGroup(dishes, keys, idx))
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].KeepID < groups[j].KeepID
+	})
+	return groups, nil
+}
+
+// FindSimilarDishes 查找用户已有菜品中与 name 相同或相近的菜品，不包括 excludeID，按相似度从高到低
+func (s *service) FindSimilarDishes(ctx context.Context, userID int64, name string, excludeID int64) ([]domain.DishesDuplicateItem, error) {
+	key := domain.DishNameKey(name)
+	if key == "" {
+		return nil, nil
+	}
+	dishes, err := s.GetDishesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var similar []domain.DishesDuplicateItem
+	for _, dish := range dishes {
+		if dish.ID == excludeID {
+			continue
+		}
+		other := domain.DishNameKey(dish.Name)
+		if other == "" || !lengthsClose(key, other, domain.DefaultDuplicateSimilarity) {
+			continue
+		}
+		if similarity := domain.DishNameSimilarity(key, other); similarity >= domain.DefaultDuplicateSimilarity {
+			similar = append(similar, domain.DishesDuplicateItem{Dishes: dish, Similarity: similarity})
+		}
+	}
+	sort.SliceStable(similar, func(i, j int) bool {
+		return similar[i].Similarity > similar[j].Similarity
+	})
+	return similar, nil
+}
+
+// MergeDishes 把用户的多道菜品合并到保留菜品：转移收藏、评分、标签、烹饪与饮食记录、分享链接和复制来源后删除被合并的菜品。
+// 所有菜品都必须属于该用户
+func (s *service) MergeDishes(ctx context.Context, req domain.MergeDishesRequest) (*domain.Dishes, error) {
+	mergeIDs := make([]int64, 0, len(req.MergeIDs))
+	seen := map[int64]bool{req.KeepID: true}
+	for _, id := range req.MergeIDs {
+		if id == req.KeepID {
+			return nil, domain.ErrDishesMergeInvalid
+		}
+		if !seen[id] {
+			seen[id] = true
+			mergeIDs = append(mergeIDs, id)
+		}
+	}
+	if len(mergeIDs) == 0 {
+		return nil, domain.ErrDishesMergeInvalid
+	}
+	if len(mergeIDs) > domain.MaxMergeDishes {
+		return nil, domain.ErrDishesMergeTooMany
+	}
+
+	keep, err := s.GetDishesByID(ctx, req.KeepID)
+	if err != nil {
+		return nil, err
+	}
+	if keep.UserID != req.UserID {
+		return nil, domain.ErrDishesUserMismatch
+	}
+	merged, err := s.repo.GetByIDs(ctx, mergeIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range mergeIDs {
+		dish, ok := merged[id]
+		if !ok {
+			return nil, domain.ErrDishesNotFound
+		}
+		if dish.UserID != req.UserID {
+			return nil, domain.ErrDishesUserMismatch
+		}
+	}
+
+	if err := s.repo.Merge(ctx, req.UserID, keep.ID, mergeIDs); err != nil {
+		return nil, err
+	}
+	// 每道被合并的菜品记录一条，差异中包含合并前的内容与保留菜品的ID
+	for _, id := range mergeIDs {
+		dish := merged[id]
+		s.auditor.Record(ctx, domain.AuditLog{
+			ActorID:    req.UserID,
+			Action:     domain.AuditDishMerge,
+			TargetType: domain.AuditTargetDishes,
+			TargetID:   strconv.FormatInt(id, 10),
+			Diff: domain.NewAuditDiff(toDishesAudit(&dish), struct {
+				MergedInto int64 `json:"merged_into"`
+			}{keep.ID}),
+		})
+	}
+	return s.repo.GetByID(ctx, keep.ID)
+}
+
+// duplicateGroup 生成一组疑似重复菜品的合并建议
+func duplicateGroup(dishes []domain.Dishes, keys []string, idx []int) domain.DishesDuplicateGroup {
+	keep := idx[0]
+	for _, i := range idx[1:] {
+		if preferKeep(dishes[i], dishes[keep]) {
+			keep = i
+		}
+	}
+
+	group := domain.DishesDuplicateGroup{
+		KeepID:   dishes[keep].ID,
+		MergeIDs: make([]int64, 0, len(idx)-1),
+		Dishes:   []domain.DishesDuplicateItem{{Dishes: dishes[keep], Similarity: 1}},
+	}
+	others := make([]domain.DishesDuplicateItem, 0, len(idx)-1)
+	for _, i := range idx {
+		if i == keep {
+			continue
+		}
+		others = append(others, domain.DishesDuplicateItem{
+			Dishes:     dishes[i],
+			Similarity: domain.DishNameSimilarity(keys[keep], keys[i]),
+		})
+	}
+	sort.SliceStable(others, func(i, j int) bool {
+		if others[i].Similarity != others[j].Similarity {
+			return others[i].Similarity > others[j].Similarity
+		}
+		return others[i].ID < others[j].ID
+	})
+	for _, item := range others {
+		group.MergeIDs = append(group.MergeIDs, item.ID)
+	}
+	group.Dishes = append(group.Dishes, others...)
+	return group
+}
+
+// preferKeep 判断 a 是否比 b 更适合保留：烹饪、评分与收藏次数多的优先，其次创建早的
+func preferKeep(a domain.Dishes, b domain.Dishes) bool {
+	activityA := a.TimesCooked + a.RatingCount + a.FavoriteCount
+	activityB := b.TimesCooked + b.RatingCount + b.FavoriteCount
+	if activityA != activityB {
+		return activityA > activityB
+	}
+	if a.Ctime != b.Ctime {
+		return a.Ctime < b.Ctime
+	}
+	return a.ID < b.ID
+}
+
+// lengthsClose 两个名称键的长度差是否允许相似度达到 minSimilarity，用于跳过不可能相近的比较
+func lengthsClose(a string, b string, minSimilarity float64) bool {
+	la, lb := len([]rune(a)), len([]rune(b))
+	if la < lb {
+		la, lb = lb, la
+	}
+	return 1-float64(la-lb)/float64(la) >= minSimilarity
+}
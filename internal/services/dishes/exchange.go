@@ -0,0 +1,533 @@
+package dishes
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"loverrecipe/internal/domain"
+)
+
+const (
+	// maxImportRows 单次导入的最大行数
+	maxImportRows = 1000
+	// pendingDishTypeID 种类尚未创建时用于字段校验的占位ID
+	pendingDishTypeID int64 = math.MaxInt64
+	// uncategorizedTypeName Markdown 导出时无种类菜品的分组名
+	uncategorizedTypeName = "未分类"
+)
+
+// utf8BOM 让电子表格软件正确识别 UTF-8 编码的 CSV
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// csvHeader CSV 导出列，导入时按列名匹配，列的顺序与多余列不影响导入
+var csvHeader = []string{
+	"id", "name", "desc", "price", "img", "calorie", "ingredients", "allergens",
+	"type_id", "type_name", "type_description", "type_icon", "type_color",
+	"ctime", "utime",
+}
+
+// importRow 解析后的导入行，err 记录解析阶段的行级错误
+type importRow struct {
+	domain.DishesImportRow
+	err error
+}
+
+// ExportDishes 导出用户的菜品及其种类
+func (s *service) ExportDishes(ctx context.Context, userID int64, format domain.DishesExchangeFormat) (*domain.DishesExportFile, error) {
+	if userID <= 0 {
+		return nil, domain.ErrDishesUserMismatch
+	}
+
+	export, err := s.buildExport(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		content     []byte
+		contentType string
+	)
+	switch format {
+	case domain.DishesExchangeJSON:
+		content, err = json.MarshalIndent(export, "", "  ")
+		contentType = "application/json; charset=utf-8"
+	case domain.DishesExchangeCSV:
+		content, err = encodeDishesCSV(export)
+		contentType = "text/csv; charset=utf-8"
+	case domain.DishesExchangeMarkdown:
+		content = encodeDishesMarkdown(export)
+		contentType = "text/markdown; charset=utf-8"
+	default:
+		return nil, domain.ErrDishesExchangeFormatInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ext := string(format)
+	if format == domain.DishesExchangeMarkdown {
+		ext = "md"
+	}
+	return &domain.DishesExportFile{
+		Name:        fmt.Sprintf("dishes-%s.%s", time.Unix(export.ExportedAt, 0).Format("20060102150405"), ext),
+		ContentType: contentType,
+		Content:     content,
+	}, nil
+}
+
+// ImportDishes 导入菜品，逐行校验、自动创建缺失的种类并按名称去重
+func (s *service) ImportDishes(ctx context.Context, userID int64, format domain.DishesExchangeFormat, r io.Reader) (*domain.DishesImportResult, error) {
+	if userID <= 0 {
+		return nil, domain.ErrDishesUserMismatch
+	}
+
+	var (
+		rows []importRow
+		err  error
+	)
+	switch format {
+	case domain.DishesExchangeJSON:
+		rows, err = decodeDishesJSON(r)
+	case domain.DishesExchangeCSV:
+		rows, err = decodeDishesCSV(r)
+	default:
+		return nil, domain.ErrDishesExchangeFormatInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, domain.ErrDishesImportEmpty
+	}
+	if len(rows) > maxImportRows {
+		return nil, domain.ErrDishesImportTooLarge
+	}
+
+	// 已有菜品名称，用于去重
+	existingDishes, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(existingDishes)+len(rows))
+	for _, dish := range existingDishes {
+		seen[domain.NormalizeDishName(dish.Name)] = true
+	}
+
+	// 已有种类名称到ID的映射
+	dishTypes, err := s.typeRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	typeIDs := make(map[string]int64, len(dishTypes))
+	for _, dt := range dishTypes {
+		key := domain.NormalizeDishName(dt.Name)
+		if _, ok := typeIDs[key]; !ok {
+			typeIDs[key] = dt.ID
+		}
+	}
+
+	result := &domain.DishesImportResult{
+		Total:        len(rows),
+		CreatedTypes: []string{},
+		Rows:         make([]domain.DishesImportRowResult, 0, len(rows)),
+	}
+	for _, row := range rows {
+		result.AddRow(s.importRow(ctx, userID, row, seen, typeIDs, result))
+	}
+
+	return result, nil
+}
+
+// importRow 导入单行菜品
+func (s *service) importRow(ctx context.Context, userID int64, row importRow,
+	seen map[string]bool, typeIDs map[string]int64, result *domain.DishesImportResult) domain.DishesImportRowResult {
+	row.Name = strings.TrimSpace(row.Name)
+	row.TypeName = strings.TrimSpace(row.TypeName)
+	rowResult := domain.DishesImportRowResult{Row: row.Row, Name: row.Name}
+
+	fail := func(err error) domain.DishesImportRowResult {
+		rowResult.Status = domain.DishesImportFailed
+		rowResult.Reason = err.Error()
+		return rowResult
+	}
+
+	if row.err != nil {
+		return fail(row.err)
+	}
+
+	nameKey := domain.NormalizeDishName(row.Name)
+	if nameKey != "" && seen[nameKey] {
+		rowResult.Status = domain.DishesImportSkipped
+		rowResult.Reason = "同名菜品已存在"
+		return rowResult
+	}
+
+	typeKey := domain.NormalizeDishName(row.TypeName)
+	if typeKey == "" {
+		return fail(domain.ErrDishesTypeInvalid)
+	}
+
+	// 种类可能尚未创建，先用占位ID完成字段校验，避免为无效行创建多余的种类
+	typeID := typeIDs[typeKey]
+	req := row.ToCreateRequest(userID, typeID)
+	if typeID == 0 {
+		req.Type = pendingDishTypeID
+	}
+	if err := req.Validate(); err != nil {
+		return fail(err)
+	}
+
+	if typeID == 0 {
+		dishType, err := domain.NewDishType(userID, row.TypeName)
+		if err != nil {
+			return fail(err)
+		}
+		dishType.Description = row.TypeDescription
+		dishType.Icon = row.TypeIcon
+		dishType.Color = row.TypeColor
+
+		created, err := s.typeRepo.Create(ctx, *dishType)
+		if err != nil {
+			return fail(err)
+		}
+		typeID = created.ID
+		typeIDs[typeKey] = typeID
+		result.CreatedTypes = append(result.CreatedTypes, created.Name)
+	}
+	req.Type = typeID
+
+	dish, err := s.repo.Create(ctx, req)
+	if err != nil {
+		return fail(err)
+	}
+	s.recordDishes(ctx, domain.AuditDishCreate, req.UserID, dish.ID, nil, dish)
+	seen[nameKey] = true
+
+	rowResult.Status = domain.DishesImportCreated
+	rowResult.DishID = dish.ID
+	return rowResult
+}
+
+// buildExport 汇总用户的菜品与其引用的种类
+func (s *service) buildExport(ctx context.Context, userID int64) (*domain.DishesExport, error) {
+	dishes, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	dishTypes, err := s.typeRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 补充菜品引用但不属于该用户的种类，保证导出数据自洽
+	known := make(map[int64]bool, len(dishTypes))
+	for _, dt := range dishTypes {
+		known[dt.ID] = true
+	}
+	for _, dish := range dishes {
+		if dish.Type <= 0 || known[dish.Type] {
+			continue
+		}
+		known[dish.Type] = true
+		dt, err := s.typeRepo.GetByID(ctx, dish.Type)
+		if err != nil {
+			continue
+		}
+		dishTypes = append(dishTypes, *dt)
+	}
+
+	if dishes == nil {
+		dishes = []domain.Dishes{}
+	}
+	return &domain.DishesExport{
+		Version:    domain.DishesExportVersion,
+		ExportedAt: time.Now().Unix(),
+		DishTypes:  dishTypes,
+		Dishes:     dishes,
+	}, nil
+}
+
+// encodeDishesCSV 以扁平的 CSV 格式导出，每行一个菜品并带上种类信息
+func encodeDishesCSV(export *domain.DishesExport) ([]byte, error) {
+	types := indexDishTypes(export.DishTypes)
+
+	var buf bytes.Buffer
+	buf.Write(utf8BOM)
+	w := csv.NewWriter(&buf)
+	if err := w.Write(csvHeader); err != nil {
+		return nil, err
+	}
+	for _, dish := range export.Dishes {
+		dt := types[dish.Type]
+		record := []string{
+			strconv.FormatInt(dish.ID, 10),
+			dish.Name,
+			dish.Desc,
+			strconv.FormatInt(dish.Price, 10),
+			dish.Img,
+			strconv.FormatInt(dish.Calorie, 10),
+			strings.Join(dish.Ingredients, "\n"),
+			joinAllergens(dish.Allergens),
+			strconv.FormatInt(dish.Type, 10),
+			dt.Name,
+			dt.Description,
+			dt.Icon,
+			dt.Color,
+			strconv.FormatInt(dish.Ctime, 10),
+			strconv.FormatInt(dish.Utime, 10),
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeDishesMarkdown 按种类分组导出为 Markdown
+func encodeDishesMarkdown(export *domain.DishesExport) []byte {
+	grouped := make(map[int64][]domain.Dishes)
+	for _, dish := range export.Dishes {
+		grouped[dish.Type] = append(grouped[dish.Type], dish)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("# 我的菜谱\n\n")
+	fmt.Fprintf(&buf, "> 导出时间：%s，共 %d 道菜\n", time.Unix(export.ExportedAt, 0).Format("2006-01-02 15:04:05"), len(export.Dishes))
+
+	writeGroup := func(title string, description string, dishes []domain.Dishes) {
+		fmt.Fprintf(&buf, "\n## %s\n", markdownEscape(title))
+		if description != "" {
+			fmt.Fprintf(&buf, "\n%s\n", markdownEscape(description))
+		}
+		for _, dish := range dishes {
+			fmt.Fprintf(&buf, "\n### %s\n\n", markdownEscape(dish.Name))
+			if dish.Img != "" {
+				fmt.Fprintf(&buf, "![%s](%s)\n\n", markdownEscape(dish.Name), dish.Img)
+			}
+			if dish.Desc != "" {
+				fmt.Fprintf(&buf, "%s\n\n", markdownEscape(dish.Desc))
+			}
+			fmt.Fprintf(&buf, "- 价格：%d\n", dish.Price)
+			fmt.Fprintf(&buf, "- 卡路里：%d\n", dish.Calorie)
+			if len(dish.Ingredients) > 0 {
+				buf.WriteString("\n#### 食材\n\n")
+				for _, ingredient := range dish.Ingredients {
+					fmt.Fprintf(&buf, "- %s\n", markdownEscape(ingredient))
+				}
+			}
+		}
+	}
+
+	for _, dt := range export.DishTypes {
+		if dishes, ok := grouped[dt.ID]; ok {
+			writeGroup(dt.Name, dt.Description, dishes)
+			delete(grouped, dt.ID)
+		}
+	}
+
+	// 种类已不存在的菜品统一归入未分类，保持导出顺序稳定
+	var uncategorized []domain.Dishes
+	for _, dish := range export.Dishes {
+		if _, ok := grouped[dish.Type]; ok {
+			uncategorized = append(uncategorized, dish)
+		}
+	}
+	if len(uncategorized) > 0 {
+		writeGroup(uncategorizedTypeName, "", uncategorized)
+	}
+
+	return buf.Bytes()
+}
+
+// markdownEscape 转义会破坏 Markdown 结构的字符
+func markdownEscape(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", " ")
+	s = strings.ReplaceAll(s, "\n", " ")
+	replacer := strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "#", `\#`, "[", `\[`, "]", `\]`, "`", "\\`")
+	return replacer.Replace(s)
+}
+
+// decodeDishesJSON 解析 JSON 导入内容，兼容导出文件以及直接填写 type_name 的菜品列表
+func decodeDishesJSON(r io.Reader) ([]importRow, error) {
+	var payload struct {
+		DishTypes []domain.DishType `json:"dish_types"`
+		Dishes    []struct {
+			domain.Dishes
+			TypeName string `json:"type_name"`
+		} `json:"dishes"`
+	}
+	if err := json.NewDecoder(r).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrDishesImportMalformed, err.Error())
+	}
+
+	types := indexDishTypes(payload.DishTypes)
+	rows := make([]importRow, 0, len(payload.Dishes))
+	for i, dish := range payload.Dishes {
+		row := domain.DishesImportRow{
+			Row:         i + 1,
+			Name:        dish.Name,
+			Desc:        dish.Desc,
+			Price:       dish.Price,
+			Img:         dish.Img,
+			Calorie:     dish.Calorie,
+			Ingredients: dish.Ingredients,
+			Allergens:   dish.Allergens,
+			TypeName:    dish.TypeName,
+		}
+		if dt, ok := types[dish.Type]; ok {
+			if row.TypeName == "" {
+				row.TypeName = dt.Name
+			}
+			row.TypeDescription = dt.Description
+			row.TypeIcon = dt.Icon
+			row.TypeColor = dt.Color
+		}
+		rows = append(rows, importRow{DishesImportRow: row})
+	}
+	return rows, nil
+}
+
+// decodeDishesCSV 解析 CSV 导入内容，行号与电子表格中的行号一致
+func decodeDishesCSV(r io.Reader) ([]importRow, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, utf8BOM)
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, domain.ErrDishesImportEmpty
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrDishesImportMalformed, err.Error())
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, fmt.Errorf("%w: 缺少 name 列", domain.ErrDishesImportMalformed)
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domain.ErrDishesImportMalformed, err.Error())
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+		// 引号内换行与空行会使记录数与行号不一致，取记录第一个字段所在的实际行号
+		line, _ := reader.FieldPos(0)
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		row := importRow{DishesImportRow: domain.DishesImportRow{
+			Row:             line,
+			Name:            field("name"),
+			Desc:            field("desc"),
+			Img:             field("img"),
+			Ingredients:     splitIngredients(field("ingredients")),
+			Allergens:       splitAllergens(field("allergens")),
+			TypeName:        field("type_name"),
+			TypeDescription: field("type_description"),
+			TypeIcon:        field("type_icon"),
+			TypeColor:       field("type_color"),
+		}}
+		if row.Price, err = parseIntField(field("price"), "价格"); err != nil {
+			row.err = err
+		} else if row.Calorie, err = parseIntField(field("calorie"), "卡路里"); err != nil {
+			row.err = err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseIntField 解析整数字段，空值视为0
+func parseIntField(value string, label string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s格式错误: %s", label, value)
+	}
+	return n, nil
+}
+
+// splitIngredients 拆分 CSV 单元格中按行分隔的食材
+func splitIngredients(value string) []string {
+	var ingredients []string
+	for _, line := range strings.Split(value, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			ingredients = append(ingredients, line)
+		}
+	}
+	return ingredients
+}
+
+// joinAllergens 将过敏原合并为 CSV 单元格中逗号分隔的文本
+func joinAllergens(allergens []domain.Allergen) string {
+	parts := make([]string, 0, len(allergens))
+	for _, allergen := range allergens {
+		parts = append(parts, string(allergen))
+	}
+	return strings.Join(parts, ",")
+}
+
+// splitAllergens 拆分 CSV 单元格中逗号分隔的过敏原，取值在创建菜品时校验
+func splitAllergens(value string) []domain.Allergen {
+	var allergens []domain.Allergen
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			allergens = append(allergens, domain.Allergen(part))
+		}
+	}
+	return allergens
+}
+
+// isBlankRecord 判断是否为空行
+func isBlankRecord(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// indexDishTypes 按ID索引菜品种类
+func indexDishTypes(dishTypes []domain.DishType) map[int64]domain.DishType {
+	result := make(map[int64]domain.DishType, len(dishTypes))
+	for _, dt := range dishTypes {
+		result[dt.ID] = dt
+	}
+	return result
+}
@@ -0,0 +1,74 @@
+package dishes
+
+import (
+	"context"
+
+	"loverrecipe/internal/domain"
+)
+
+// FavoriteDishes 收藏菜品
+func (s *service) FavoriteDishes(ctx context.Context, userID int64, dishID int64) error {
+	if err := s.checkFeedbackTarget(ctx, userID, dishID); err != nil {
+		return err
+	}
+
+	return s.feedbackRepo.AddFavorite(ctx, userID, dishID)
+}
+
+// UnfavoriteDishes 取消收藏菜品
+func (s *service) UnfavoriteDishes(ctx context.Context, userID int64, dishID int64) error {
+	if err := s.checkFeedbackTarget(ctx, userID, dishID); err != nil {
+		return err
+	}
+
+	return s.feedbackRepo.RemoveFavorite(ctx, userID, dishID)
+}
+
+// RateDishes 为菜品评分，重复评分会覆盖之前的评分
+func (s *service) RateDishes(ctx context.Context, req domain.RateDishesRequest) (*domain.DishRating, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkFeedbackTarget(ctx, req.UserID, req.DishID); err != nil {
+		return nil, err
+	}
+
+	return s.feedbackRepo.SaveRating(ctx, req)
+}
+
+// DeleteDishesRating 删除当前用户对菜品的评分
+func (s *service) DeleteDishesRating(ctx context.Context, userID int64, dishID int64) error {
+	if err := s.checkFeedbackTarget(ctx, userID, dishID); err != nil {
+		return err
+	}
+
+	return s.feedbackRepo.DeleteRating(ctx, userID, dishID)
+}
+
+// ListDishesRatings 分页查询用户可读菜品的评分
+func (s *service) ListDishesRatings(ctx context.Context, userID int64, dishID int64, offset int, limit int) (*domain.DishRatingListResponse, error) {
+	if err := s.checkFeedbackTarget(ctx, userID, dishID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return s.feedbackRepo.ListRatings(ctx, dishID, offset, limit)
+}
+
+// checkFeedbackTarget 检查评分与收藏的用户有效，且菜品对该用户可读
+func (s *service) checkFeedbackTarget(ctx context.Context, userID int64, dishID int64) error {
+	if userID <= 0 {
+		return domain.ErrDishFeedbackUserIDInvalid
+	}
+
+	_, err := s.getReadableDishes(ctx, userID, dishID)
+	return err
+}
@@ -0,0 +1,123 @@
+package dishes
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/pkg/schemaorg"
+)
+
+// 菜品字段的长度上限，与 domain.CreateDishesRequest 的校验保持一致
+const (
+	maxDishNameLength = 100
+	maxDishDescLength = 200
+	maxDishImgLength  = 200
+	// maxDishTypeNameLength 与 domain.NewDishType 的种类名称上限一致
+	maxDishTypeNameLength = 50
+)
+
+// ImportRecipe 从 schema.org Recipe JSON-LD 创建菜品
+func (s *service) ImportRecipe(ctx context.Context, req domain.RecipeImportRequest) (*domain.Dishes, error) {
+	if req.UserID <= 0 {
+		return nil, domain.ErrDishesUserMismatch
+	}
+
+	var (
+		recipe *schemaorg.Recipe
+		err    error
+	)
+	switch {
+	case strings.TrimSpace(req.Content) != "":
+		recipe, err = schemaorg.ParseRecipe([]byte(req.Content))
+	case strings.TrimSpace(req.URL) != "":
+		recipe, err = s.recipeFetcher.FetchRecipe(ctx, strings.TrimSpace(req.URL))
+	default:
+		return nil, domain.ErrRecipeImportSourceEmpty
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	typeID, err := s.resolveRecipeType(ctx, req.UserID, recipe.Categories)
+	if err != nil {
+		return nil, err
+	}
+
+	// 网页中的描述与图片地址经常超出字段长度，截断或丢弃以保证能够导入
+	img := recipe.Image
+	if len(img) > maxDishImgLength {
+		img = ""
+	}
+	ingredients := recipe.Ingredients
+	if len(ingredients) > domain.MaxDishesIngredients {
+		ingredients = ingredients[:domain.MaxDishesIngredients]
+	}
+	for i, ingredient := range ingredients {
+		ingredients[i] = truncateUTF8(ingredient, domain.MaxDishesIngredientLength)
+	}
+
+	return s.CreateDishes(ctx, domain.CreateDishesRequest{
+		UserID:      req.UserID,
+		Name:        truncateUTF8(recipe.Name, maxDishNameLength),
+		Desc:        truncateUTF8(recipe.Description, maxDishDescLength),
+		Img:         img,
+		Type:        typeID,
+		Calorie:     recipe.Calories,
+		Ingredients: ingredients,
+	})
+}
+
+// resolveRecipeType 将菜谱分类映射为用户的菜品种类，优先匹配已有种类，否则以第一个分类创建新种类
+func (s *service) resolveRecipeType(ctx context.Context, userID int64, categories []string) (int64, error) {
+	dishTypes, err := s.typeRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	typeIDs := make(map[string]int64, len(dishTypes))
+	for _, dt := range dishTypes {
+		key := domain.NormalizeDishName(dt.Name)
+		if _, ok := typeIDs[key]; !ok {
+			typeIDs[key] = dt.ID
+		}
+	}
+
+	for _, category := range categories {
+		if id, ok := typeIDs[domain.NormalizeDishName(category)]; ok {
+			return id, nil
+		}
+	}
+
+	// 网页中的分类可能超出种类名称的长度上限，截断后再匹配或创建
+	name := uncategorizedTypeName
+	if len(categories) > 0 {
+		if category := strings.TrimSpace(truncateUTF8(categories[0], maxDishTypeNameLength)); category != "" {
+			name = category
+		}
+	}
+	if id, ok := typeIDs[domain.NormalizeDishName(name)]; ok {
+		return id, nil
+	}
+
+	dishType, err := domain.NewDishType(userID, name)
+	if err != nil {
+		return 0, err
+	}
+	created, err := s.typeRepo.Create(ctx, *dishType)
+	if err != nil {
+		return 0, err
+	}
+	return created.ID, nil
+}
+
+// truncateUTF8 按字节数截断字符串，不会截断多字节字符
+func truncateUTF8(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	for maxBytes > 0 && !utf8.RuneStart(s[maxBytes]) {
+		maxBytes--
+	}
+	return s[:maxBytes]
+}
@@ -0,0 +1,47 @@
+package dishes
+
+import (
+	"context"
+
+	"loverrecipe/internal/domain"
+)
+
+// ReorderDishes 按拖动后的顺序排列用户的菜品，必须传入该用户的全部菜品。
+// 只改写顺序发生变化的菜品的排序权重，全部改写在一个事务中完成
+func (s *service) ReorderDishes(ctx context.Context, req domain.ReorderRequest) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	dishes, err := s.repo.GetByIDs(ctx, req.IDs)
+	if err != nil {
+		return err
+	}
+	weights := make(map[int64]int64, len(req.IDs))
+	for _, id := range req.IDs {
+		dish, ok := dishes[id]
+		if !ok {
+			return domain.ErrDishesNotFound
+		}
+		if dish.UserID != req.UserID {
+			return domain.ErrDishesUserMismatch
+		}
+		weights[id] = dish.Sort
+	}
+	all, err := s.repo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	allIDs := make([]int64, 0, len(all))
+	for _, dish := range all {
+		allIDs = append(allIDs, dish.ID)
+	}
+	if err := req.CheckComplete(allIDs); err != nil {
+		return err
+	}
+
+	changed := domain.PlanReorder(req.IDs, weights)
+	if len(changed) == 0 {
+		return nil
+	}
+	return s.repo.UpdateSort(ctx, req.UserID, changed)
+}
@@ -0,0 +1,205 @@
+package dishtype
+
+import (
+	"context"
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/repository"
+	"strings"
+)
+
+type Service interface {
+	CreateDishType(ctx context.Context, req domain.CreateDishTypeRequest) (*domain.DishType, error)
+	// MoveDishType 调整种类的上级，子孙种类随之移动
+	MoveDishType(ctx context.Context, req domain.MoveDishTypeRequest) (*domain.DishType, error)
+	// GetDishTypeTree 获取用户的完整种类树及每个种类的菜品数
+	GetDishTypeTree(ctx context.Context, userID int64) ([]*domain.DishTypeNode, error)
+	// ReorderDishTypes 按拖动后的顺序排列种类，只改写顺序发生变化的种类
+	ReorderDishTypes(ctx context.Context, req domain.ReorderRequest) error
+}
+
+type service struct {
+	repo       repository.DishTypeRepository
+	dishesRepo repository.DishesRepository
+}
+
+// NewService 创建菜品种类服务实例
+func NewService(repo repository.DishTypeRepository, dishesRepo repository.DishesRepository) Service {
+	return &service{
+		repo:       repo,
+		dishesRepo: dishesRepo,
+	}
+}
+
+// CreateDishType 创建菜品种类，指定上级时上级必须属于当前用户，且层数不超过上限
+func (s *service) CreateDishType(ctx context.Context, req domain.CreateDishTypeRequest) (*domain.DishType, error) {
+	dishType, err := domain.NewDishType(req.UserID, strings.TrimSpace(req.Name))
+	if err != nil {
+		return nil, err
+	}
+	dishType.Description = req.Description
+	dishType.Icon = req.Icon
+	dishType.Color = req.Color
+
+	if req.ParentID > 0 {
+		parent, err := s.getParent(ctx, req.ParentID, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.Depth()+1 > domain.MaxDishTypeDepth {
+			return nil, domain.ErrDishTypeTooDeep
+		}
+		dishType.ParentID = parent.ID
+	}
+
+	return s.repo.Create(ctx, *dishType)
+}
+
+// MoveDishType 移动种类。新的上级不能是自身或自身的子孙种类，移动后整棵子树的层数不能超过上限
+func (s *service) MoveDishType(ctx context.Context, req domain.MoveDishTypeRequest) (*domain.DishType, error) {
+	dishType, err := s.getOwnedDishType(ctx, req.ID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if req.ParentID == dishType.ParentID {
+		return dishType, nil
+	}
+
+	if req.ParentID > 0 {
+		if _, err := s.getParent(ctx, req.ParentID, req.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 成环与层数在事务中按加锁后的路径校验，并发移动时不会基于过期的路径通过校验
+	return s.repo.Move(ctx, *dishType, req.ParentID, func(locked domain.DishType, parent *domain.DishType, height int) error {
+		parentDepth := 0
+		if req.ParentID > 0 {
+			if parent == nil {
+				return domain.ErrDishTypeParentInvalid
+			}
+			if locked.IsAncestorOf(*parent) {
+				return domain.ErrDishTypeCycle
+			}
+			parentDepth = parent.Depth()
+		}
+		if parentDepth+height > domain.MaxDishTypeDepth {
+			return domain.ErrDishTypeTooDeep
+		}
+		return nil
+	})
+}
+
+// GetDishTypeTree 用两次查询取出全部种类与按种类分组的菜品数，在内存中组装成树。
+// 同一层的种类按排序权重从大到小排列
+func (s *service) GetDishTypeTree(ctx context.Context, userID int64) ([]*domain.DishTypeNode, error) {
+	if userID <= 0 {
+		return nil, domain.ErrDishTypeUserMismatch
+	}
+	dishTypes, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.dishesRepo.CountByType(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[int64]*domain.DishTypeNode, len(dishTypes))
+	for _, dt := range dishTypes {
+		nodes[dt.ID] = &domain.DishTypeNode{
+			DishType:  dt,
+			DishCount: counts[dt.ID],
+			Children:  []*domain.DishTypeNode{},
+		}
+	}
+	roots := make([]*domain.DishTypeNode, 0)
+	for _, dt := range dishTypes {
+		node := nodes[dt.ID]
+		if parent, ok := nodes[dt.ParentID]; ok && dt.ParentID != dt.ID {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	for _, root := range roots {
+		sumCounts(root)
+	}
+	return roots, nil
+}
+
+// ReorderDishTypes 按给定顺序排列用户的种类，所有种类都必须属于该用户。
+// 种类树中同一层按排序权重排列，拖动时传入同一上级下的全部子种类
+func (s *service) ReorderDishTypes(ctx context.Context, req domain.ReorderRequest) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	dishTypes, err := s.repo.GetByIDs(ctx, req.IDs)
+	if err != nil {
+		return err
+	}
+	weights := make(map[int64]int64, len(req.IDs))
+	for _, id := range req.IDs {
+		dishType, ok := dishTypes[id]
+		if !ok {
+			return domain.ErrDishTypeNotFound
+		}
+		if dishType.UserID != req.UserID {
+			return domain.ErrDishTypeUserMismatch
+		}
+		weights[id] = dishType.Sort
+	}
+	all, err := s.repo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	parentID := dishTypes[req.IDs[0]].ParentID
+	siblingIDs := make([]int64, 0, len(req.IDs))
+	for _, dt := range all {
+		if dt.ParentID == parentID {
+			siblingIDs = append(siblingIDs, dt.ID)
+		}
+	}
+	if err := req.CheckComplete(siblingIDs); err != nil {
+		return err
+	}
+
+	changed := domain.PlanReorder(req.IDs, weights)
+	if len(changed) == 0 {
+		return nil
+	}
+	return s.repo.UpdateSort(ctx, req.UserID, changed)
+}
+
+// sumCounts 自底向上累加子树的菜品数
+func sumCounts(node *domain.DishTypeNode) int64 {
+	node.TotalCount = node.DishCount
+	for _, child := range node.Children {
+		node.TotalCount += sumCounts(child)
+	}
+	return node.TotalCount
+}
+
+// getOwnedDishType 获取种类并校验归属
+func (s *service) getOwnedDishType(ctx context.Context, id int64, userID int64) (*domain.DishType, error) {
+	if id <= 0 {
+		return nil, domain.ErrDishTypeNotFound
+	}
+
+	dishType, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if dishType.UserID != userID {
+		return nil, domain.ErrDishTypeUserMismatch
+	}
+	return dishType, nil
+}
+
+// getParent 获取上级种类，不存在或不属于当前用户时都视为上级无效
+func (s *service) getParent(ctx context.Context, id int64, userID int64) (*domain.DishType, error) {
+	parent, err := s.getOwnedDishType(ctx, id, userID)
+	if err == domain.ErrDishTypeNotFound || err == domain.ErrDishTypeUserMismatch {
+		return nil, domain.ErrDishTypeParentInvalid
+	}
+	return parent, err
+}
@@ -0,0 +1,186 @@
+package nutrition
+
+import (
+	"context"
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/repository"
+	"time"
+)
+
+type Service interface {
+	SetGoal(ctx context.Context, req domain.SetNutritionGoalRequest) (*domain.NutritionGoal, error)
+	GetGoal(ctx context.Context, userID int64) (*domain.NutritionGoal, error)
+	RecordMeal(ctx context.Context, req domain.CreateMealRecordRequest) (*domain.MealRecord, error)
+	DeleteMeal(ctx context.Context, id int64, userID int64) error
+	ListMeals(ctx context.Context, userID int64, day string) ([]domain.MealRecord, error)
+	GetProgress(ctx context.Context, userID int64, day string) (*domain.NutritionProgress, error)
+	Trend(ctx context.Context, userID int64, from string, to string) ([]domain.DailyNutritionSnapshot, error)
+	SnapshotDay(ctx context.Context, day time.Time) (int, error)
+}
+
+type service struct {
+	repo       repository.NutritionRepository
+	dishesRepo repository.DishesRepository
+}
+
+// NewService 创建饮食目标服务实例
+func NewService(repo repository.NutritionRepository, dishesRepo repository.DishesRepository) Service {
+	return &service{
+		repo:       repo,
+		dishesRepo: dishesRepo,
+	}
+}
+
+// SetGoal 设置卡路里目标与花费预算
+func (s *service) SetGoal(ctx context.Context, req domain.SetNutritionGoalRequest) (*domain.NutritionGoal, error) {
+	goal, err := domain.NewNutritionGoal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SaveGoal(ctx, *goal); err != nil {
+		return nil, err
+	}
+	return goal, nil
+}
+
+// GetGoal 获取卡路里目标与花费预算
+func (s *service) GetGoal(ctx context.Context, userID int64) (*domain.NutritionGoal, error) {
+	goal, err := s.repo.GetGoal(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &goal, nil
+}
+
+// RecordMeal 记录吃了某道菜，卡路里与花费按当前菜品数据保存
+func (s *service) RecordMeal(ctx context.Context, req domain.CreateMealRecordRequest) (*domain.MealRecord, error) {
+	dish, err := s.dishesRepo.GetByID(ctx, req.DishID)
+	if err != nil {
+		return nil, err
+	}
+	// 只能记录自己的菜品，避免把其他用户菜品的名称、价格与卡路里复制到自己的记录中
+	if dish.UserID != req.UserID {
+		return nil, domain.ErrDishesUserMismatch
+	}
+
+	meal, err := domain.NewMealRecord(req, *dish, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateMeal(ctx, *meal)
+}
+
+// DeleteMeal 删除饮食记录
+func (s *service) DeleteMeal(ctx context.Context, id int64, userID int64) error {
+	if id <= 0 {
+		return domain.ErrMealRecordNotFound
+	}
+
+	meal, err := s.repo.GetMeal(ctx, id)
+	if err != nil {
+		return err
+	}
+	if meal.UserID != userID {
+		return domain.ErrMealRecordUserMismatch
+	}
+
+	return s.repo.DeleteMeal(ctx, id)
+}
+
+// ListMeals 获取某一天的饮食记录，日期为空时取今天
+func (s *service) ListMeals(ctx context.Context, userID int64, day string) ([]domain.MealRecord, error) {
+	date, err := s.parseDay(day)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FindMeals(ctx, userID, domain.FormatNutritionDay(date))
+}
+
+// GetProgress 获取某一天及其所在周的目标完成情况，日期为空时取今天
+func (s *service) GetProgress(ctx context.Context, userID int64, day string) (*domain.NutritionProgress, error) {
+	date, err := s.parseDay(day)
+	if err != nil {
+		return nil, err
+	}
+
+	goal, err := s.repo.GetGoal(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dayStr := domain.FormatNutritionDay(date)
+	daily, err := s.repo.SumMeals(ctx, userID, dayStr, dayStr)
+	if err != nil {
+		return nil, err
+	}
+
+	monday, sunday := domain.NutritionWeek(date)
+	weekly, err := s.repo.SumMeals(ctx, userID, domain.FormatNutritionDay(monday), domain.FormatNutritionDay(sunday))
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &domain.NutritionProgress{
+		Day:    dayStr,
+		Goal:   goal,
+		Daily:  domain.NewGoalProgress(date, date, daily, goal.DailyCalorie, goal.DailyBudget),
+		Weekly: domain.NewGoalProgress(monday, sunday, weekly, goal.WeeklyCalorie, goal.WeeklyBudget),
+	}
+	progress.OverBudget = progress.Daily.Budget.Over || progress.Weekly.Budget.Over
+	return progress, nil
+}
+
+// Trend 获取日期范围内的每日快照，没有快照的日期补零。
+// 结束日期默认为今天，开始日期默认为结束日期前30天
+func (s *service) Trend(ctx context.Context, userID int64, from string, to string) ([]domain.DailyNutritionSnapshot, error) {
+	end, err := s.parseDay(to)
+	if err != nil {
+		return nil, err
+	}
+	start := end.AddDate(0, 0, 1-domain.DefaultNutritionTrendDays)
+	if from != "" {
+		if start, err = domain.ParseNutritionDay(from, time.Local); err != nil {
+			return nil, err
+		}
+	}
+	if start.After(end) || !start.AddDate(0, 0, domain.MaxNutritionTrendDays).After(end) {
+		return nil, domain.ErrNutritionRangeInvalid
+	}
+
+	snapshots, err := s.repo.FindSnapshots(ctx, userID, domain.FormatNutritionDay(start), domain.FormatNutritionDay(end))
+	if err != nil {
+		return nil, err
+	}
+
+	byDay := make(map[string]domain.DailyNutritionSnapshot, len(snapshots))
+	for _, snapshot := range snapshots {
+		byDay[snapshot.Day] = snapshot
+	}
+
+	result := make([]domain.DailyNutritionSnapshot, 0, len(snapshots))
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		key := domain.FormatNutritionDay(day)
+		snapshot, ok := byDay[key]
+		if !ok {
+			snapshot = domain.DailyNutritionSnapshot{UserID: userID, Day: key}
+		}
+		result = append(result, snapshot)
+	}
+	return result, nil
+}
+
+// SnapshotDay 保存某一天所有用户的饮食合计快照，返回写入的快照数
+func (s *service) SnapshotDay(ctx context.Context, day time.Time) (int, error) {
+	return s.repo.SnapshotDay(ctx, domain.FormatNutritionDay(day))
+}
+
+// parseDay 解析日期，为空时取今天
+func (s *service) parseDay(day string) (time.Time, error) {
+	if day == "" {
+		now := time.Now()
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local), nil
+	}
+	return domain.ParseNutritionDay(day, time.Local)
+}
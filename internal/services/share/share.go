@@ -0,0 +1,265 @@
+package share
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/repository"
+	"loverrecipe/internal/services/audit"
+	"strconv"
+	"time"
+
+	"github.com/gotomicro/ego/core/elog"
+)
+
+// tokenBytes 分享令牌的随机字节数，base64url 编码后为 22 个字符
+const tokenBytes = 16
+
+type Service interface {
+	// Create 为自己的菜品或种类创建分享链接
+	Create(ctx context.Context, req domain.CreateShareRequest) (domain.Share, error)
+	List(ctx context.Context, userID int64) ([]domain.Share, error)
+	Revoke(ctx context.Context, userID int64, id int64) error
+	// View 公开查看分享内容并记录一次访问，分享无效或内容已删除返回 ErrShareNotFound
+	View(ctx context.Context, token string) (domain.SharedContent, error)
+}
+
+type service struct {
+	repo       repository.ShareRepository
+	dishesRepo repository.DishesRepository
+	typeRepo   repository.DishTypeRepository
+	auditor    audit.Recorder
+}
+
+// NewService 创建分享服务实例
+func NewService(repo repository.ShareRepository, dishesRepo repository.DishesRepository,
+	typeRepo repository.DishTypeRepository, auditor audit.Recorder) Service {
+	return &service{
+		repo:       repo,
+		dishesRepo: dishesRepo,
+		typeRepo:   typeRepo,
+		auditor:    auditor,
+	}
+}
+
+// Create 校验分享对象属于当前用户后生成随机令牌
+func (s *service) Create(ctx context.Context, req domain.CreateShareRequest) (domain.Share, error) {
+	if req.ExpiresAt != 0 && req.ExpiresAt <= time.Now().Unix() {
+		return domain.Share{}, domain.ErrShareExpiryInvalid
+	}
+	if err := s.checkOwned(ctx, req.UserID, req.TargetType, req.TargetID); err != nil {
+		return domain.Share{}, err
+	}
+
+	count, err := s.repo.CountByUser(ctx, req.UserID)
+	if err != nil {
+		return domain.Share{}, err
+	}
+	if count >= domain.MaxSharesPerUser {
+		return domain.Share{}, domain.ErrShareLimitExceeded
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return domain.Share{}, err
+	}
+	share, err := s.repo.Create(ctx, domain.Share{
+		UserID:     req.UserID,
+		Token:      token,
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+		ExpiresAt:  req.ExpiresAt,
+	})
+	if err != nil {
+		return domain.Share{}, err
+	}
+
+	s.record(ctx, req.UserID, domain.AuditShareCreate, share.ID, domain.NewAuditDiff(nil, toShareAudit(share)))
+	return share, nil
+}
+
+// List 获取用户的分享链接及访问次数
+func (s *service) List(ctx context.Context, userID int64) ([]domain.Share, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+// Revoke 吊销分享链接，只能吊销自己的分享
+func (s *service) Revoke(ctx context.Context, userID int64, id int64) error {
+	if err := s.repo.Delete(ctx, id, userID); err != nil {
+		return err
+	}
+	s.record(ctx, userID, domain.AuditShareRevoke, id, nil)
+	return nil
+}
+
+// View 读取分享内容。访问次数在内容读取成功后记录，记录失败只打印日志
+func (s *service) View(ctx context.Context, token string) (domain.SharedContent, error) {
+	if len(token) != base64.RawURLEncoding.EncodedLen(tokenBytes) {
+		return domain.SharedContent{}, domain.ErrShareNotFound
+	}
+	share, err := s.repo.GetByToken(ctx, token)
+	if err != nil {
+		return domain.SharedContent{}, err
+	}
+	now := time.Now().Unix()
+	if share.Expired(now) {
+		return domain.SharedContent{}, domain.ErrShareNotFound
+	}
+
+	content := domain.SharedContent{TargetType: share.TargetType, ExpiresAt: share.ExpiresAt}
+	switch share.TargetType {
+	case domain.ShareTargetDish:
+		dish, err := s.sharedDish(ctx, share)
+		if err != nil {
+			return domain.SharedContent{}, err
+		}
+		content.Dish = dish
+	case domain.ShareTargetDishType:
+		menu, err := s.sharedMenu(ctx, share)
+		if err != nil {
+			return domain.SharedContent{}, err
+		}
+		content.Menu = menu
+	default:
+		return domain.SharedContent{}, domain.ErrShareNotFound
+	}
+
+	if err := s.repo.IncrViews(ctx, share.ID, now); err != nil {
+		elog.Warn("记录分享访问失败", elog.FieldErr(err), elog.Int64("shareID", share.ID))
+	}
+	return content, nil
+}
+
+// checkOwned 检查分享对象存在且属于该用户
+func (s *service) checkOwned(ctx context.Context, userID int64, targetType domain.ShareTargetType, targetID int64) error {
+	switch targetType {
+	case domain.ShareTargetDish:
+		dish, err := s.dishesRepo.GetByID(ctx, targetID)
+		if err != nil {
+			return err
+		}
+		if dish.UserID != userID {
+			return domain.ErrShareTargetNotOwned
+		}
+	case domain.ShareTargetDishType:
+		dishType, err := s.typeRepo.GetByID(ctx, targetID)
+		if err != nil {
+			return err
+		}
+		if dishType.UserID != userID {
+			return domain.ErrShareTargetNotOwned
+		}
+	default:
+		return domain.ErrShareNotFound
+	}
+	return nil
+}
+
+// sharedDish 读取分享的菜品及其种类信息。菜品已删除或已不属于分享者时视为分享失效
+func (s *service) sharedDish(ctx context.Context, share domain.Share) (*domain.SharedDish, error) {
+	dish, err := s.dishesRepo.GetByID(ctx, share.TargetID)
+	if err == domain.ErrDishesNotFound {
+		return nil, domain.ErrShareNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if dish.UserID != share.UserID {
+		return nil, domain.ErrShareNotFound
+	}
+
+	result := toSharedDish(*dish)
+	if dishType, err := s.typeRepo.GetByID(ctx, dish.Type); err == nil && dishType.UserID == share.UserID {
+		result.TypeName = dishType.Name
+		result.TypeDescription = dishType.Description
+		result.TypeIcon = dishType.Icon
+		result.TypeColor = dishType.Color
+	}
+	return &result, nil
+}
+
+// sharedMenu 读取分享的种类及分享者在该种类下的全部菜品
+func (s *service) sharedMenu(ctx context.Context, share domain.Share) (*domain.SharedMenu, error) {
+	dishType, err := s.typeRepo.GetByID(ctx, share.TargetID)
+	if err == domain.ErrDishTypeNotFound {
+		return nil, domain.ErrShareNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if dishType.UserID != share.UserID {
+		return nil, domain.ErrShareNotFound
+	}
+
+	dishes, err := s.dishesRepo.GetByUserIDAndType(ctx, share.UserID, dishType.ID)
+	if err != nil {
+		return nil, err
+	}
+	menu := &domain.SharedMenu{
+		Name:        dishType.Name,
+		Description: dishType.Description,
+		Icon:        dishType.Icon,
+		Color:       dishType.Color,
+		Dishes:      make([]domain.SharedDish, 0, len(dishes)),
+	}
+	for _, dish := range dishes {
+		item := toSharedDish(dish)
+		item.TypeName = dishType.Name
+		item.TypeDescription = dishType.Description
+		item.TypeIcon = dishType.Icon
+		item.TypeColor = dishType.Color
+		menu.Dishes = append(menu.Dishes, item)
+	}
+	return menu, nil
+}
+
+func (s *service) record(ctx context.Context, userID int64, action domain.AuditAction, shareID int64, diff []byte) {
+	s.auditor.Record(ctx, domain.AuditLog{
+		ActorID:    userID,
+		Action:     action,
+		TargetType: domain.AuditTargetShare,
+		TargetID:   strconv.FormatInt(shareID, 10),
+		Diff:       diff,
+	})
+}
+
+// shareAudit 审计差异中记录的分享字段。令牌在吊销前即可访问分享内容，不写入审计日志
+type shareAudit struct {
+	TargetType domain.ShareTargetType `json:"target_type"`
+	TargetID   int64                  `json:"target_id"`
+	ExpiresAt  int64                  `json:"expires_at"`
+}
+
+func toShareAudit(share domain.Share) shareAudit {
+	return shareAudit{
+		TargetType: share.TargetType,
+		TargetID:   share.TargetID,
+		ExpiresAt:  share.ExpiresAt,
+	}
+}
+
+// toSharedDish 只复制可以公开的字段
+func toSharedDish(dish domain.Dishes) domain.SharedDish {
+	return domain.SharedDish{
+		ID:          dish.ID,
+		Name:        dish.Name,
+		Desc:        dish.Desc,
+		Price:       dish.Price,
+		Img:         dish.Img,
+		Calorie:     dish.Calorie,
+		Ingredients: dish.Ingredients,
+		Allergens:   dish.Allergens,
+		RatingAvg:   dish.RatingAvg,
+		RatingCount: dish.RatingCount,
+	}
+}
+
+// generateToken 生成不可猜测的分享令牌
+func generateToken() (string, error) {
+	buf := make([]byte, tokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,145 @@
+package tags
+
+import (
+	"context"
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/repository"
+)
+
+type Service interface {
+	CreateTag(ctx context.Context, req domain.CreateTagRequest) (*domain.Tag, error)
+	ListTags(ctx context.Context, userID int64) ([]domain.Tag, error)
+	UpdateTag(ctx context.Context, req domain.UpdateTagRequest) (*domain.Tag, error)
+	DeleteTag(ctx context.Context, id int64, userID int64) error
+	AttachDishes(ctx context.Context, userID int64, tagID int64, dishIDs []int64) error
+	DetachDish(ctx context.Context, userID int64, tagID int64, dishID int64) error
+}
+
+type service struct {
+	repo       repository.TagRepository
+	dishesRepo repository.DishesRepository
+}
+
+// NewService 创建标签服务实例
+func NewService(repo repository.TagRepository, dishesRepo repository.DishesRepository) Service {
+	return &service{
+		repo:       repo,
+		dishesRepo: dishesRepo,
+	}
+}
+
+// CreateTag 创建标签，同一用户下名称不能重复
+func (s *service) CreateTag(ctx context.Context, req domain.CreateTagRequest) (*domain.Tag, error) {
+	tag, err := domain.NewTag(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkNameAvailable(ctx, tag.UserID, tag.Name, 0); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Create(ctx, *tag)
+}
+
+// ListTags 获取用户的全部标签
+func (s *service) ListTags(ctx context.Context, userID int64) ([]domain.Tag, error) {
+	if userID <= 0 {
+		return nil, domain.ErrTagUserMismatch
+	}
+	return s.repo.GetByUserID(ctx, userID)
+}
+
+// UpdateTag 更新标签
+func (s *service) UpdateTag(ctx context.Context, req domain.UpdateTagRequest) (*domain.Tag, error) {
+	tag, err := s.getOwnedTag(ctx, req.ID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tag.Update(req); err != nil {
+		return nil, err
+	}
+	if err := s.checkNameAvailable(ctx, tag.UserID, tag.Name, tag.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, *tag); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// DeleteTag 删除标签及其与菜品的关联
+func (s *service) DeleteTag(ctx context.Context, id int64, userID int64) error {
+	if _, err := s.getOwnedTag(ctx, id, userID); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// AttachDishes 为标签批量关联菜品，标签与菜品都必须属于当前用户
+func (s *service) AttachDishes(ctx context.Context, userID int64, tagID int64, dishIDs []int64) error {
+	if len(dishIDs) == 0 {
+		return domain.ErrTagDishesEmpty
+	}
+	if len(dishIDs) > domain.MaxTagDishesBatch {
+		return domain.ErrTagDishesTooMany
+	}
+	if _, err := s.getOwnedTag(ctx, tagID, userID); err != nil {
+		return err
+	}
+
+	dishes, err := s.dishesRepo.GetByIDs(ctx, dishIDs)
+	if err != nil {
+		return err
+	}
+	for _, id := range dishIDs {
+		dish, ok := dishes[id]
+		if !ok {
+			return domain.ErrDishesNotFound
+		}
+		if dish.UserID != userID {
+			return domain.ErrDishesUserMismatch
+		}
+	}
+
+	return s.repo.AttachDishes(ctx, userID, tagID, dishIDs)
+}
+
+// DetachDish 取消标签与菜品的关联
+func (s *service) DetachDish(ctx context.Context, userID int64, tagID int64, dishID int64) error {
+	if _, err := s.getOwnedTag(ctx, tagID, userID); err != nil {
+		return err
+	}
+	return s.repo.DetachDish(ctx, userID, tagID, dishID)
+}
+
+// getOwnedTag 获取标签并校验归属
+func (s *service) getOwnedTag(ctx context.Context, id int64, userID int64) (*domain.Tag, error) {
+	if id <= 0 {
+		return nil, domain.ErrTagNotFound
+	}
+
+	tag, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if tag.UserID != userID {
+		return nil, domain.ErrTagUserMismatch
+	}
+	return tag, nil
+}
+
+// checkNameAvailable 检查标签名称在用户下是否已被其他标签占用
+func (s *service) checkNameAvailable(ctx context.Context, userID int64, name string, selfID int64) error {
+	existing, err := s.repo.GetByUserIDAndName(ctx, userID, name)
+	if err == domain.ErrTagNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != selfID {
+		return domain.ErrTagNameExists
+	}
+	return nil
+}
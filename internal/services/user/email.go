@@ -0,0 +1,218 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"loverrecipe/internal/domain"
+	"loverrecipe/internal/pkg/mailer"
+	"loverrecipe/internal/utils"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gotomicro/ego/core/elog"
+)
+
+// sendMailTimeout 后台发送一封邮件的超时时间
+const sendMailTimeout = 30 * time.Second
+
+// EmailLinks 邮件中的链接模板，{token} 会被替换为令牌
+type EmailLinks struct {
+	VerifyURL string
+	ResetURL  string
+}
+
+// UpdateEmail 校验密码后设置邮箱，邮箱为空表示解除绑定。
+// 新邮箱先作为待验证的邮箱保存并发送验证邮件，验证前原来的邮箱仍然有效。
+// 不检查新邮箱是否已被其他账号使用，避免借此探测邮箱是否注册，冲突在验证时才会发现
+func (s *service) UpdateEmail(ctx context.Context, req domain.UpdateEmailRequest) error {
+	u, err := s.repo.GetByID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !utils.ValidatePassword(req.Password, u.Password) {
+		return domain.ErrPasswordIncorrect
+	}
+
+	email := domain.NormalizeEmail(req.Email)
+	if email != "" {
+		if err := domain.ValidateEmail(email); err != nil {
+			return err
+		}
+	}
+
+	after := u
+	if email == "" {
+		after.Email, after.PendingEmail = "", ""
+	} else if email == u.Email && u.EmailVerified {
+		// 改回当前已验证的邮箱时撤销待验证的邮箱
+		after.PendingEmail = ""
+	} else {
+		after.PendingEmail = email
+	}
+	if after.Email == u.Email && after.PendingEmail == u.PendingEmail && after.PendingEmail == "" {
+		return nil
+	}
+
+	if email == "" {
+		err = s.repo.ClearEmail(ctx, u.ID)
+	} else {
+		err = s.repo.SetPendingEmail(ctx, u.ID, after.PendingEmail)
+	}
+	if err != nil {
+		return err
+	}
+	s.record(ctx, u.ID, domain.AuditEmailChange, u.ID, domain.NewAuditDiff(
+		map[string]string{"email": u.Email, "pending_email": u.PendingEmail},
+		map[string]string{"email": after.Email, "pending_email": after.PendingEmail}))
+	if after.PendingEmail == "" {
+		return nil
+	}
+
+	return s.sendVerification(ctx, u, after.PendingEmail)
+}
+
+// ResendVerification 重新发送验证邮件，同一用户发送间隔受限
+func (s *service) ResendVerification(ctx context.Context, userID int64) error {
+	u, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if u.PendingEmail == "" {
+		if u.Email == "" {
+			return domain.ErrEmailNotSet
+		}
+		return domain.ErrEmailAlreadyVerified
+	}
+
+	allowed, err := s.emailTokens.AllowSend(ctx, domain.EmailTokenVerify, u.ID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return domain.ErrEmailSendTooFrequent
+	}
+	return s.sendVerification(ctx, u, u.PendingEmail)
+}
+
+// VerifyEmail 使用邮件中的令牌验证邮箱。令牌签发后修改过邮箱时验证失败，邮箱已被其他账号验证时返回 ErrEmailAlreadyUsed
+func (s *service) VerifyEmail(ctx context.Context, tokenStr string) error {
+	claims, err := s.emailTokens.Consume(ctx, domain.EmailTokenVerify, tokenStr)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.VerifyEmail(ctx, claims.UserID, claims.Email); err != nil {
+		return err
+	}
+	s.record(ctx, claims.UserID, domain.AuditEmailVerify, claims.UserID, nil)
+	return nil
+}
+
+// ForgotPassword 向已验证的邮箱发送重置密码邮件。
+// 邮箱未注册、未验证或发送过于频繁时同样返回成功，不泄露邮箱是否存在
+func (s *service) ForgotPassword(ctx context.Context, email string) error {
+	email = domain.NormalizeEmail(email)
+	if err := domain.ValidateEmail(email); err != nil {
+		return err
+	}
+
+	u, err := s.repo.GetByEmail(ctx, email)
+	if err == domain.ErrUserNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !u.EmailVerified || u.Status != domain.UserStatusNormal {
+		return nil
+	}
+
+	allowed, err := s.emailTokens.AllowSend(ctx, domain.EmailTokenReset, u.ID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return nil
+	}
+
+	tokenStr, err := s.emailTokens.Issue(ctx, domain.EmailTokenReset, domain.EmailTokenClaims{
+		UserID:       u.ID,
+		Email:        u.Email,
+		TokenVersion: u.TokenVersion,
+	})
+	if err != nil {
+		return err
+	}
+	s.sendMail(mailer.Message{
+		To:      u.Email,
+		Subject: "重置密码",
+		Body: fmt.Sprintf("你好 %s：\n\n请打开下面的链接重置密码，链接只能使用一次：\n%s\n\n如果不是你本人操作，请忽略这封邮件。\n",
+			u.Name, emailLink(s.links.ResetURL, tokenStr)),
+	})
+	return nil
+}
+
+// ResetPassword 使用邮件中的令牌重置密码。之前签发的登录令牌与重置链接全部失效，并解除用户名的登录锁定
+func (s *service) ResetPassword(ctx context.Context, req domain.ResetPasswordRequest) error {
+	claims, err := s.emailTokens.Consume(ctx, domain.EmailTokenReset, req.Token)
+	if err != nil {
+		return err
+	}
+	u, err := s.repo.GetByID(ctx, claims.UserID)
+	if err == domain.ErrUserNotFound {
+		return domain.ErrEmailTokenInvalid
+	}
+	if err != nil {
+		return err
+	}
+	if u.Email != claims.Email || !u.EmailVerified || u.TokenVersion != claims.TokenVersion {
+		return domain.ErrEmailTokenInvalid
+	}
+
+	if err := s.repo.UpdatePassword(ctx, u.ID, utils.HashPassword(req.NewPassword)); err != nil {
+		elog.Error("重置密码失败", elog.FieldErr(err))
+		return err
+	}
+	if err := s.attempts.ClearLockout(ctx, domain.LockoutScopeUsername, u.Name); err != nil {
+		elog.Warn("解除登录锁定失败", elog.FieldErr(err), elog.Int64("userID", u.ID))
+	}
+	s.record(ctx, u.ID, domain.AuditPasswordReset, u.ID, nil)
+	return nil
+}
+
+// sendVerification 为待验证的邮箱签发验证令牌并发送验证邮件
+func (s *service) sendVerification(ctx context.Context, u domain.User, email string) error {
+	tokenStr, err := s.emailTokens.Issue(ctx, domain.EmailTokenVerify, domain.EmailTokenClaims{
+		UserID: u.ID,
+		Email:  email,
+	})
+	if err != nil {
+		return err
+	}
+	s.sendMail(mailer.Message{
+		To:      email,
+		Subject: "验证邮箱",
+		Body: fmt.Sprintf("你好 %s：\n\n请打开下面的链接验证邮箱，验证后可以通过邮箱找回密码：\n%s\n\n如果不是你本人操作，请忽略这封邮件。\n",
+			u.Name, emailLink(s.links.VerifyURL, tokenStr)),
+	})
+	return nil
+}
+
+// sendMail 在后台发送邮件，请求耗时不受邮件服务器影响，也不会因耗时差异泄露邮箱是否存在
+func (s *service) sendMail(msg mailer.Message) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), sendMailTimeout)
+		defer cancel()
+		if err := s.mailer.Send(ctx, msg); err != nil {
+			elog.Error("发送邮件失败", elog.FieldErr(err), elog.String("subject", msg.Subject))
+		}
+	}()
+}
+
+// emailLink 生成邮件中的链接，未配置模板时直接返回令牌
+func emailLink(tmpl string, tokenStr string) string {
+	if tmpl == "" {
+		return tokenStr
+	}
+	return strings.ReplaceAll(tmpl, "{token}", url.QueryEscape(tokenStr))
+}
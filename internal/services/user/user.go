@@ -2,33 +2,89 @@ package user
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/gotomicro/ego/core/elog"
 	"github.com/sony/sonyflake"
 	"loverrecipe/internal/domain"
+	"loverrecipe/internal/pkg/mailer"
 	"loverrecipe/internal/repository"
+	"loverrecipe/internal/services/audit"
 	"loverrecipe/internal/token"
 	"loverrecipe/internal/utils"
+	"strconv"
+	"strings"
 )
 
 type Service interface {
 	Create(ctx context.Context, user *domain.CreateUserInput) (domain.CreateUserOutput, error)
+	GetDietaryProfile(ctx context.Context, userID int64) (domain.DietaryProfile, error)
+	UpdateDietaryProfile(ctx context.Context, req domain.UpdateDietaryProfileRequest) (domain.DietaryProfile, error)
+	Login(ctx context.Context, req domain.LoginReq) (domain.LoginOutput, error)
+	RefreshToken(ctx context.Context, refreshToken string) (domain.LoginOutput, error)
+	// CheckActive 检查用户是否可用并返回当前角色与令牌版本，禁用返回 ErrUserDisabled，不存在返回 ErrUserNotFound
+	CheckActive(ctx context.Context, userID int64) (domain.UserAuthState, error)
+	GetProfile(ctx context.Context, userID int64) (domain.User, error)
+	UpdateProfile(ctx context.Context, req domain.UpdateProfileRequest) (domain.User, error)
+	ChangePassword(ctx context.Context, req domain.ChangePasswordRequest) (domain.LoginOutput, error)
+	DeleteAccount(ctx context.Context, req domain.DeleteAccountRequest) error
+	ListUsers(ctx context.Context, query domain.UserQuery) ([]domain.User, int64, error)
+	CountUsers(ctx context.Context) (int64, error)
+	SetUserStatus(ctx context.Context, operatorID int64, userID int64, status int64) error
+	SetUserRole(ctx context.Context, operatorID int64, userID int64, role domain.Role) error
+	ForceLogout(ctx context.Context, operatorID int64, userID int64) error
+	// Logout 退出登录，吊销该用户全部设备上的刷新令牌
+	Logout(ctx context.Context, userID int64) error
+	ListLoginLockouts(ctx context.Context, scope domain.LockoutScope) ([]domain.LoginLockout, error)
+	ClearLoginLockout(ctx context.Context, scope domain.LockoutScope, subject string) error
+	UpdateEmail(ctx context.Context, req domain.UpdateEmailRequest) error
+	ResendVerification(ctx context.Context, userID int64) error
+	VerifyEmail(ctx context.Context, token string) error
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, req domain.ResetPasswordRequest) error
 }
 
 type service struct {
-	repo repository.UserRepository
-	jwt  *token.JwtTokenHandler
-	id   *sonyflake.Sonyflake
+	repo     repository.UserRepository
+	attempts repository.LoginAttemptRepository
+	jwt      *token.JwtTokenHandler
+	id       *sonyflake.Sonyflake
+	auditor  audit.Recorder
+
+	mailer      mailer.Mailer
+	emailTokens repository.EmailTokenRepository
+	links       EmailLinks
 }
 
-func NewService(repo repository.UserRepository, jwt *token.JwtTokenHandler, id *sonyflake.Sonyflake) Service {
+func NewService(repo repository.UserRepository, attempts repository.LoginAttemptRepository,
+	jwt *token.JwtTokenHandler, id *sonyflake.Sonyflake, auditor audit.Recorder,
+	mailer mailer.Mailer, emailTokens repository.EmailTokenRepository, links EmailLinks) Service {
 	return &service{
-		repo: repo,
-		jwt:  jwt,
-		id:   id,
+		repo:        repo,
+		attempts:    attempts,
+		jwt:         jwt,
+		id:          id,
+		auditor:     auditor,
+		mailer:      mailer,
+		emailTokens: emailTokens,
+		links:       links,
 	}
 }
 
+// Create 注册用户。用户名先规范化再校验保留名与是否已被注册
 func (s *service) Create(ctx context.Context, user *domain.CreateUserInput) (domain.CreateUserOutput, error) {
+	user.Name = domain.NormalizeUsername(user.Name)
+	if err := domain.ValidateUsername(user.Name); err != nil {
+		return domain.CreateUserOutput{}, err
+	}
+	exists, err := s.repo.UsernameExists(ctx, user.Name)
+	if err != nil {
+		elog.Error("检查用户名失败", elog.FieldErr(err))
+		return domain.CreateUserOutput{}, err
+	}
+	if exists {
+		return domain.CreateUserOutput{}, domain.ErrUserAlreadyExists
+	}
+
 	user.Password = utils.HashPassword(user.Password)
 
 	id, err := s.id.NextID()
@@ -39,6 +95,9 @@ func (s *service) Create(ctx context.Context, user *domain.CreateUserInput) (dom
 	user.ID = id
 
 	err = s.repo.CreateUser(ctx, user)
+	if err == domain.ErrUserAlreadyExists {
+		return domain.CreateUserOutput{}, err
+	}
 	if err != nil {
 		elog.Error("创建用户失败", elog.FieldErr(err))
 		return domain.CreateUserOutput{}, err
@@ -55,3 +114,312 @@ func (s *service) Create(ctx context.Context, user *domain.CreateUserInput) (dom
 	}, nil
 
 }
+
+// GetDietaryProfile 获取用户的过敏原与饮食要求
+func (s *service) GetDietaryProfile(ctx context.Context, userID int64) (domain.DietaryProfile, error) {
+	return s.repo.GetDietaryProfile(ctx, userID)
+}
+
+// UpdateDietaryProfile 更新用户的过敏原与饮食要求，整体覆盖
+func (s *service) UpdateDietaryProfile(ctx context.Context, req domain.UpdateDietaryProfileRequest) (domain.DietaryProfile, error) {
+	profile, err := domain.NewDietaryProfile(req)
+	if err != nil {
+		return domain.DietaryProfile{}, err
+	}
+
+	if err := s.repo.SaveDietaryProfile(ctx, req.UserID, profile); err != nil {
+		elog.Error("更新饮食档案失败", elog.FieldErr(err))
+		return domain.DietaryProfile{}, err
+	}
+	return profile, nil
+}
+
+// Login 用户名密码登录。
+// 用户名或IP处于锁定期时直接拒绝；用户名不存在与密码错误同样计入失败次数并返回同一个错误，
+// 失败次数达到阈值后返回 ErrLoginLocked 与剩余锁定时间。Redis 不可用时不做锁定检查
+func (s *service) Login(ctx context.Context, req domain.LoginReq) (domain.LoginOutput, error) {
+	rawUsername := req.Username
+	req.Username = domain.NormalizeUsername(req.Username)
+
+	retry, err := s.attempts.Locked(ctx, req.Username, req.IP)
+	if err != nil {
+		elog.Warn("查询登录锁定状态失败", elog.FieldErr(err), elog.String("username", req.Username))
+	}
+	if retry > 0 {
+		return domain.LoginOutput{RetryAfter: retry}, domain.ErrLoginLocked
+	}
+
+	u, err := s.repo.GetByUsername(ctx, req.Username)
+	if err == domain.ErrUserNotFound && rawUsername != req.Username {
+		// 兼容规范化之前注册的用户名
+		u, err = s.repo.GetByUsername(ctx, rawUsername)
+	}
+	if err != nil && err != domain.ErrUserNotFound {
+		elog.Error("查询用户失败", elog.FieldErr(err))
+		return domain.LoginOutput{}, err
+	}
+	// 用户不存在时同样计算一次哈希，避免通过响应时间判断用户名是否存在
+	if !utils.ValidatePassword(req.Password, u.Password) || err == domain.ErrUserNotFound {
+		return s.loginFailed(ctx, req)
+	}
+
+	if err := s.attempts.Reset(ctx, req.Username); err != nil {
+		elog.Warn("清除登录失败计数失败", elog.FieldErr(err), elog.String("username", req.Username))
+	}
+	// 密码正确后才提示账号已禁用，不泄露账号状态
+	if u.Status != domain.UserStatusNormal {
+		return domain.LoginOutput{}, domain.ErrUserDisabled
+	}
+	if err := s.repo.UpdateLastLogin(ctx, u.ID); err != nil {
+		elog.Warn("更新最后登录时间失败", elog.FieldErr(err), elog.Int64("userID", u.ID))
+	}
+	s.record(ctx, u.ID, domain.AuditLogin, u.ID, nil)
+
+	return s.issueTokens(u)
+}
+
+// loginFailed 记录一次登录失败，本次失败触发锁定时返回 ErrLoginLocked
+func (s *service) loginFailed(ctx context.Context, req domain.LoginReq) (domain.LoginOutput, error) {
+	// 登录失败时操作人未知，对象ID记录尝试的用户名
+	s.auditor.Record(ctx, domain.AuditLog{
+		Action:     domain.AuditLoginFailed,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   req.Username,
+	})
+
+	lock, err := s.attempts.RecordFailure(ctx, req.Username, req.IP)
+	if err != nil {
+		elog.Warn("记录登录失败次数失败", elog.FieldErr(err), elog.String("username", req.Username))
+	}
+	if lock > 0 {
+		elog.Warn("登录失败次数过多，已锁定", elog.String("username", req.Username),
+			elog.String("ip", req.IP), elog.Duration("lockout", lock))
+		return domain.LoginOutput{RetryAfter: lock}, domain.ErrLoginLocked
+	}
+	return domain.LoginOutput{}, domain.ErrInvalidCredentials
+}
+
+// ListUsers 分页查询用户
+func (s *service) ListUsers(ctx context.Context, query domain.UserQuery) ([]domain.User, int64, error) {
+	query.Keyword = strings.TrimSpace(query.Keyword)
+	return s.repo.List(ctx, query)
+}
+
+// CountUsers 统计用户总数
+func (s *service) CountUsers(ctx context.Context) (int64, error) {
+	return s.repo.Count(ctx)
+}
+
+// SetUserStatus 启用或禁用账号，禁用时同时吊销刷新令牌
+func (s *service) SetUserStatus(ctx context.Context, operatorID int64, userID int64, status int64) error {
+	if operatorID == userID {
+		return domain.ErrAdminSelfModify
+	}
+	u, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateStatus(ctx, userID, status); err != nil {
+		elog.Error("更新用户状态失败", elog.FieldErr(err))
+		return err
+	}
+	if status == domain.UserStatusDisabled {
+		if err := s.repo.RevokeTokens(ctx, userID); err != nil {
+			elog.Error("吊销令牌失败", elog.FieldErr(err))
+			return err
+		}
+	}
+	s.record(ctx, operatorID, domain.AuditStatusChange, userID,
+		domain.NewAuditDiff(map[string]int64{"status": u.Status}, map[string]int64{"status": status}))
+	return nil
+}
+
+// SetUserRole 修改用户角色，鉴权时读取当前角色，状态缓存清除后即生效
+func (s *service) SetUserRole(ctx context.Context, operatorID int64, userID int64, role domain.Role) error {
+	if operatorID == userID {
+		return domain.ErrAdminSelfModify
+	}
+	u, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateRole(ctx, userID, role); err != nil {
+		elog.Error("更新用户角色失败", elog.FieldErr(err))
+		return err
+	}
+	s.record(ctx, operatorID, domain.AuditRoleChange, userID,
+		domain.NewAuditDiff(map[string]domain.Role{"role": u.Role}, map[string]domain.Role{"role": role}))
+	return nil
+}
+
+// ForceLogout 强制下线：递增令牌版本，用户已签发的主令牌与刷新令牌全部失效
+func (s *service) ForceLogout(ctx context.Context, operatorID int64, userID int64) error {
+	if _, err := s.repo.GetByID(ctx, userID); err != nil {
+		return err
+	}
+	if err := s.repo.RevokeTokens(ctx, userID); err != nil {
+		elog.Error("吊销令牌失败", elog.FieldErr(err))
+		return err
+	}
+	s.record(ctx, operatorID, domain.AuditForceLogout, userID, nil)
+	return nil
+}
+
+// Logout 退出登录。令牌按用户版本吊销，其他设备上的刷新令牌同时失效
+func (s *service) Logout(ctx context.Context, userID int64) error {
+	if err := s.repo.RevokeTokens(ctx, userID); err != nil {
+		elog.Error("吊销令牌失败", elog.FieldErr(err))
+		return err
+	}
+	s.record(ctx, userID, domain.AuditLogout, userID, nil)
+	return nil
+}
+
+// ListLoginLockouts 列出当前生效的登录锁定
+func (s *service) ListLoginLockouts(ctx context.Context, scope domain.LockoutScope) ([]domain.LoginLockout, error) {
+	return s.attempts.ListLockouts(ctx, scope)
+}
+
+// ClearLoginLockout 解除一个用户名或IP的登录锁定
+func (s *service) ClearLoginLockout(ctx context.Context, scope domain.LockoutScope, subject string) error {
+	if scope == "" || subject == "" {
+		return domain.ErrLockoutScopeInvalid
+	}
+	if err := s.attempts.ClearLockout(ctx, scope, subject); err != nil {
+		elog.Error("解除登录锁定失败", elog.FieldErr(err))
+		return err
+	}
+	elog.Info("解除登录锁定", elog.String("scope", string(scope)), elog.String("subject", subject))
+	return nil
+}
+
+// RefreshToken 用刷新 Token 换取新的主 Token 与刷新 Token。
+// 用户已注销或修改过密码时，之前签发的刷新 Token 失效
+func (s *service) RefreshToken(ctx context.Context, refreshToken string) (domain.LoginOutput, error) {
+	claims, err := s.jwt.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return domain.LoginOutput{}, domain.ErrRefreshTokenInvalid
+	}
+
+	u, err := s.repo.GetByID(ctx, int64(claims.UserId))
+	if err == domain.ErrUserNotFound {
+		return domain.LoginOutput{}, domain.ErrRefreshTokenInvalid
+	}
+	if err != nil {
+		elog.Error("查询用户失败", elog.FieldErr(err))
+		return domain.LoginOutput{}, err
+	}
+	if claims.TokenVersion != u.TokenVersion {
+		return domain.LoginOutput{}, domain.ErrRefreshTokenInvalid
+	}
+	if u.Status != domain.UserStatusNormal {
+		return domain.LoginOutput{}, domain.ErrUserDisabled
+	}
+	return s.issueTokens(u)
+}
+
+// CheckActive 检查用户是否可用，读取短期缓存的用户状态
+func (s *service) CheckActive(ctx context.Context, userID int64) (domain.UserAuthState, error) {
+	state, err := s.repo.GetAuthState(ctx, userID)
+	if err != nil {
+		return domain.UserAuthState{}, err
+	}
+	if state.Status != domain.UserStatusNormal {
+		return domain.UserAuthState{}, domain.ErrUserDisabled
+	}
+	return state, nil
+}
+
+// GetProfile 获取个人资料
+func (s *service) GetProfile(ctx context.Context, userID int64) (domain.User, error) {
+	return s.repo.GetByID(ctx, userID)
+}
+
+// UpdateProfile 更新显示名称与头像，未传的字段保持不变
+func (s *service) UpdateProfile(ctx context.Context, req domain.UpdateProfileRequest) (domain.User, error) {
+	u, err := s.repo.GetByID(ctx, req.UserID)
+	if err != nil {
+		return domain.User{}, err
+	}
+	if req.Nickname != nil {
+		u.Nickname = strings.TrimSpace(*req.Nickname)
+	}
+	if req.Avatar != nil {
+		u.Avatar = strings.TrimSpace(*req.Avatar)
+	}
+
+	if err := s.repo.UpdateProfile(ctx, u); err != nil {
+		elog.Error("更新个人资料失败", elog.FieldErr(err))
+		return domain.User{}, err
+	}
+	return u, nil
+}
+
+// ChangePassword 校验原密码后修改密码，之前签发的刷新 Token 全部失效，返回新的一组 Token
+func (s *service) ChangePassword(ctx context.Context, req domain.ChangePasswordRequest) (domain.LoginOutput, error) {
+	u, err := s.repo.GetByID(ctx, req.UserID)
+	if err != nil {
+		return domain.LoginOutput{}, err
+	}
+	if !utils.ValidatePassword(req.OldPassword, u.Password) {
+		return domain.LoginOutput{}, domain.ErrPasswordIncorrect
+	}
+	if req.OldPassword == req.NewPassword {
+		return domain.LoginOutput{}, domain.ErrPasswordUnchanged
+	}
+
+	if err := s.repo.UpdatePassword(ctx, u.ID, utils.HashPassword(req.NewPassword)); err != nil {
+		elog.Error("修改密码失败", elog.FieldErr(err))
+		return domain.LoginOutput{}, err
+	}
+	u.TokenVersion++
+	s.record(ctx, u.ID, domain.AuditPasswordChange, u.ID, nil)
+	return s.issueTokens(u)
+}
+
+// DeleteAccount 校验密码后注销账号，删除用户及其全部数据
+func (s *service) DeleteAccount(ctx context.Context, req domain.DeleteAccountRequest) error {
+	u, err := s.repo.GetByID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !utils.ValidatePassword(req.Password, u.Password) {
+		return domain.ErrPasswordIncorrect
+	}
+
+	if err := s.repo.DeleteAccount(ctx, u.ID); err != nil {
+		elog.Error("注销账号失败", elog.FieldErr(err))
+		return err
+	}
+	s.record(ctx, u.ID, domain.AuditAccountDelete, u.ID, nil)
+	return nil
+}
+
+// issueTokens 为用户签发主 Token 与刷新 Token
+func (s *service) issueTokens(u domain.User) (domain.LoginOutput, error) {
+	claims := token.BaseClaims{UserId: uint(u.ID), Username: u.Name, Role: string(u.Role), TokenVersion: u.TokenVersion}
+	accessToken, err := s.jwt.GenerateToken(claims)
+	if err != nil {
+		elog.Error("生成token失败", elog.FieldErr(err))
+		return domain.LoginOutput{}, err
+	}
+	refreshToken, err := s.jwt.GenerateRefreshToken(claims)
+	if err != nil {
+		elog.Error("生成token失败", elog.FieldErr(err))
+		return domain.LoginOutput{}, err
+	}
+	return domain.LoginOutput{Token: accessToken, RefreshToken: refreshToken}, nil
+}
+
+// record 记录一条以用户为对象的审计日志
+func (s *service) record(ctx context.Context, actorID int64, action domain.AuditAction, userID int64, diff json.RawMessage) {
+	s.auditor.Record(ctx, domain.AuditLog{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   strconv.FormatInt(userID, 10),
+		Diff:       diff,
+	})
+}
@@ -24,36 +24,54 @@ func RegisterJwt() *JwtTokenHandler {
 	}
 }
 
+// Token 类型
+const (
+	TypeAccess  = "access"
+	TypeRefresh = "refresh"
+)
+
+var (
+	ErrTokenExpired = errors.New("登录过期，请重新登录")
+	// ErrTokenType 令牌类型不符，例如用刷新 Token 访问接口
+	ErrTokenType = errors.New("token 类型错误")
+)
+
 // BaseClaims 基本声明结构体
 type BaseClaims struct {
 	UserId   uint
 	Username string
+	Role     string
+	// TokenVersion 签发时的用户令牌版本，用于吊销修改密码前签发的刷新 Token
+	TokenVersion int64
 }
 
 // CustomClaims 自定义声明结构体
 type CustomClaims struct {
 	BaseClaims
+	TokenType string
 	jwt.RegisteredClaims
 }
 
 // GenerateToken 生成主 Token
 func (j *JwtTokenHandler) GenerateToken(baseClaims BaseClaims) (string, error) {
-	return j.generateToken(baseClaims, ExpireTime)
+	return j.generateToken(baseClaims, TypeAccess, ExpireTime)
 }
 
 // GenerateRefreshToken 生成刷新 Token
 func (j *JwtTokenHandler) GenerateRefreshToken(baseClaims BaseClaims) (string, error) {
-	return j.generateToken(baseClaims, RefreshExpireTime)
+	return j.generateToken(baseClaims, TypeRefresh, RefreshExpireTime)
 }
 
 // generateToken 生成 Token 的通用方法
-func (j *JwtTokenHandler) generateToken(baseClaims BaseClaims, duration time.Duration) (string, error) {
+func (j *JwtTokenHandler) generateToken(baseClaims BaseClaims, tokenType string, duration time.Duration) (string, error) {
 	expireTime := time.Now().Add(duration)
 	claims := CustomClaims{
 		BaseClaims: baseClaims,
+		TokenType:  tokenType,
 		RegisteredClaims: jwt.RegisteredClaims{
 			NotBefore: jwt.NewNumericDate(time.Now().Add(-1000 * time.Millisecond)), // 签名生效时间
 			ExpiresAt: jwt.NewNumericDate(expireTime),                               // 过期时间
+			IssuedAt:  jwt.NewNumericDate(time.Now()),                               // 签发时间
 			Issuer:    "lover",                                                      // 签名的发行者
 		},
 	}
@@ -80,10 +98,31 @@ func (j *JwtTokenHandler) ParseToken(tokenString string) (*CustomClaims, error)
 	return nil, errors.New("解析 token 失败")
 }
 
+// ParseAccessToken 解析主 Token，刷新 Token 不能用于访问接口
+func (j *JwtTokenHandler) ParseAccessToken(tokenString string) (*CustomClaims, error) {
+	return j.parseTyped(tokenString, TypeAccess)
+}
+
+// ParseRefreshToken 解析刷新 Token
+func (j *JwtTokenHandler) ParseRefreshToken(tokenString string) (*CustomClaims, error) {
+	return j.parseTyped(tokenString, TypeRefresh)
+}
+
+func (j *JwtTokenHandler) parseTyped(tokenString string, tokenType string) (*CustomClaims, error) {
+	claims, err := j.ParseToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.TokenType != tokenType {
+		return nil, ErrTokenType
+	}
+	return claims, nil
+}
+
 // parseTokenError 处理 Token 解析错误
 func parseTokenError(err error) error {
 	if errors.Is(err, jwt.ErrTokenExpired) {
-		return errors.New("登录过期，请重新登录")
+		return ErrTokenExpired
 	}
 	return errors.New("token 不可用: " + err.Error())
 }